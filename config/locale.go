@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// CatalogDefaultLocale returns the language of the names and descriptions of the catalog, the other languages being
+// their translations.
+func CatalogDefaultLocale() string {
+	locale := strings.ToLower(strings.TrimSpace(os.Getenv("CATALOG_DEFAULT_LOCALE")))
+	if locale == "" {
+		return "fr"
+	}
+
+	return locale
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// LoyaltyPointsPerEuro returns how many loyalty points a customer earns for each euro spent.
+func LoyaltyPointsPerEuro() float64 {
+	points, err := strconv.ParseFloat(os.Getenv("LOYALTY_POINTS_PER_EURO"), 64)
+	if err != nil || points < 0 {
+		return 1
+	}
+
+	return points
+}
@@ -0,0 +1,18 @@
+package config
+
+import "os"
+
+const (
+	MenuPricingWarn   = "warn"
+	MenuPricingReject = "reject"
+)
+
+// MenuPricingPolicy returns what happens when a menu is priced higher than its products bought separately:
+// the menu is saved with a warning, or rejected.
+func MenuPricingPolicy() string {
+	if os.Getenv("MENU_PRICING_POLICY") == MenuPricingReject {
+		return MenuPricingReject
+	}
+
+	return MenuPricingWarn
+}
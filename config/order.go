@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// OrderPreparationLeadTime returns how long before its requested ready time
+// a scheduled order enters the kitchen queue.
+func OrderPreparationLeadTime() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("ORDER_PREPARATION_LEAD_TIME"))
+	if err != nil || minutes <= 0 {
+		return 15 * time.Minute
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// OrderItemPreparationTime returns how long an item takes to prepare,
+// used to estimate when orders will be ready until enough orders have been prepared.
+func OrderItemPreparationTime() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("ORDER_ITEM_PREPARATION_TIME"))
+	if err != nil || seconds <= 0 {
+		return time.Minute
+	}
+
+	return time.Duration(seconds) * time.Second
+}
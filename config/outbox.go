@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// OutboxDispatchInterval returns how often the outbox events are delivered to their subscribers.
+func OutboxDispatchInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("OUTBOX_DISPATCH_INTERVAL"))
+	if err != nil || seconds <= 0 {
+		return 2 * time.Second
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// OutboxRetryDelay returns the delay before an event is delivered again after a subscriber failed,
+// doubled at each new attempt up to one hour.
+func OutboxRetryDelay() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("OUTBOX_RETRY_DELAY"))
+	if err != nil || seconds <= 0 {
+		return 10 * time.Second
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// PriceChangeInterval returns how often the scheduled price changes taking effect are applied.
+func PriceChangeInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("PRICE_CHANGE_INTERVAL"))
+	if err != nil || seconds <= 0 {
+		return time.Minute
+	}
+
+	return time.Duration(seconds) * time.Second
+}
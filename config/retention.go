@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	ArchiveModeTable = "table"
+	ArchiveModeFile  = "file"
+)
+
+// OrderRetentionDays returns how many days delivered and cancelled orders are kept,
+// 0 disabling the retention job.
+func OrderRetentionDays() int {
+	days, err := strconv.Atoi(os.Getenv("ORDER_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		return 0
+	}
+
+	return days
+}
+
+// OrderArchiveMode returns where purged orders are archived: in the archived_orders table, or in compressed JSON files.
+func OrderArchiveMode() string {
+	if os.Getenv("ORDER_ARCHIVE_MODE") == ArchiveModeFile {
+		return ArchiveModeFile
+	}
+
+	return ArchiveModeTable
+}
+
+func OrderArchiveDirectory() string {
+	directory := os.Getenv("ORDER_ARCHIVE_DIRECTORY")
+	if directory == "" {
+		return "archives"
+	}
+
+	return directory
+}
+
+// OrderRetentionInterval returns how often the retention job runs inside the server.
+func OrderRetentionInterval() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("ORDER_RETENTION_INTERVAL"))
+	if err != nil || hours <= 0 {
+		return 24 * time.Hour
+	}
+
+	return time.Duration(hours) * time.Hour
+}
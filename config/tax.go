@@ -0,0 +1,16 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// VATRate returns the VAT rate, in percent, included in the prices.
+func VATRate() float64 {
+	rate, err := strconv.ParseFloat(os.Getenv("VAT_RATE"), 64)
+	if err != nil || rate < 0 {
+		return 10
+	}
+
+	return rate
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// WebhookDispatchInterval returns how often the queued webhook deliveries are sent.
+func WebhookDispatchInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("WEBHOOK_DISPATCH_INTERVAL"))
+	if err != nil || seconds <= 0 {
+		return 10 * time.Second
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// WebhookRetryDelay returns the delay before the first retry of a failed delivery, doubled at each new attempt.
+func WebhookRetryDelay() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("WEBHOOK_RETRY_DELAY"))
+	if err != nil || seconds <= 0 {
+		return 30 * time.Second
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// WebhookMaxAttempts returns how many times a delivery is attempted before being given up.
+func WebhookMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil || attempts <= 0 {
+		return 8
+	}
+
+	return attempts
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+	"wacdo/config"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCatalogExport godoc
+// @Description Exporter tout le catalogue (catégories, produits avec leurs variantes, menus) en JSON ou en CSV, chaque élément étant identifié par sa clé externe
+// @Tags Catalog
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "Format de l'export (json, csv)"
+// @Success 200 {object} models.Catalog
+// @Failure 400 {object} map[string]string "Format invalide"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /catalog/export [get]
+func GetCatalogExport(context *gin.Context) {
+	format, err := models.ParseCatalogFormat(context.Query("format"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	catalog, err := models.ExportCatalog(config.DB.WithContext(context))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to export catalog."})
+
+		return
+	}
+
+	if format == models.CatalogJSON {
+		context.JSON(http.StatusOK, catalog)
+
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := models.EncodeCatalog(&buffer, catalog, format); err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to export catalog."})
+
+		return
+	}
+
+	context.Header("Content-Disposition", `attachment; filename="catalog.csv"`)
+	context.Data(http.StatusOK, "text/csv; charset=utf-8", buffer.Bytes())
+}
+
+// PostCatalogImport godoc
+// @Description Importer un catalogue en JSON ou en CSV dans une seule transaction, en créant ou en mettant à jour chaque élément selon sa clé externe. Rien n'est importé si le catalogue contient une erreur, ni lors d'une simulation
+// @Tags Catalog
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param format query string false "Format du catalogue (json, csv)"
+// @Param dryRun query bool false "Simulation : valider le catalogue et indiquer ce qui serait importé, sans rien importer"
+// @Param input body models.Catalog true "Catalogue"
+// @Success 200 {object} models.CatalogImportReport
+// @Failure 400 {object} models.CatalogImportReport "Catalogue invalide"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /catalog/import [post]
+func PostCatalogImport(context *gin.Context) {
+	format, err := models.ParseCatalogFormat(context.Query("format"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	catalog, err := models.DecodeCatalog(context.Request.Body, format)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	dryRun := context.Query("dryRun") == "true"
+
+	report, err := models.ImportCatalog(config.DB.WithContext(context), catalog, *middlewares.GetUserId(context), dryRun, time.Now())
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to import catalog."})
+
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		context.JSON(http.StatusBadRequest, report)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"wacdo/config"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetCustomers godoc
+// @Description Récupérer les clients, éventuellement filtrés par nom, email ou téléphone
+// @Tags Customers
+// @Produce json
+// @Param search query string false "Nom, email ou téléphone recherché"
+// @Success 200 {array} models.Customer
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers [get]
+func GetCustomers(context *gin.Context) {
+	var customers []models.Customer
+
+	query := config.DB.WithContext(context).Order("id")
+
+	if search := strings.ToLower(strings.TrimSpace(context.Query("search"))); search != "" {
+		pattern := "%" + search + "%"
+		condition := config.DB.Where("LOWER(name) LIKE ?", pattern).Or("email LIKE ?", pattern)
+
+		if phone, err := models.NormalizePhone(search); err == nil {
+			condition = condition.Or("phone LIKE ?", "%"+phone+"%")
+		}
+
+		query = query.Where(condition)
+	}
+
+	if err := query.Find(&customers).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch customers."})
+		return
+	}
+
+	context.JSON(http.StatusOK, customers)
+}
+
+// GetCustomer godoc
+// @Description Récupérer un client par son ID, avec son solde de points de fidélité
+// @Tags Customers
+// @Produce json
+// @Param id path int true "ID du client"
+// @Success 200 {object} models.Customer
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Client non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers/{id} [get]
+func GetCustomer(context *gin.Context) {
+	customer, err := models.FindCustomerByContext(context)
+
+	if err == nil {
+		context.JSON(http.StatusOK, customer)
+	}
+}
+
+// GetCustomerLoyaltyTransactions godoc
+// @Description Récupérer l'historique des points de fidélité gagnés et dépensés par un client
+// @Tags Customers
+// @Produce json
+// @Param id path int true "ID du client"
+// @Success 200 {array} models.LoyaltyTransaction
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Client non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers/{id}/loyalty-transactions [get]
+func GetCustomerLoyaltyTransactions(context *gin.Context) {
+	customer, err := models.FindCustomerByContext(context)
+
+	if err == nil {
+		transactions, err := models.FindCustomerLoyaltyTransactions(context, customer)
+
+		if err == nil {
+			context.JSON(http.StatusOK, transactions)
+		}
+	}
+}
+
+// PostCustomer godoc
+// @Description Créer un nouveau client, identifié par son email ou son téléphone
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param customer body models.CustomerInsertInput true "Données du client"
+// @Success 201 {object} models.Customer
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers [post]
+func PostCustomer(context *gin.Context) {
+	var input models.CustomerInsertInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	customer := models.Customer{
+		Name:             strings.TrimSpace(input.Name),
+		Email:            input.Email,
+		Phone:            input.Phone,
+		LoyaltyConsent:   input.LoyaltyConsent,
+		MarketingConsent: input.MarketingConsent,
+	}
+
+	if !models.ValidateCustomerContact(context, &customer) {
+		return
+	}
+
+	if err := config.DB.WithContext(context).Create(&customer).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create customer."})
+
+		return
+	}
+
+	context.JSON(http.StatusCreated, customer)
+}
+
+// PutCustomer godoc
+// @Description Mettre à jour un client existant, notamment ses consentements
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du client"
+// @Param input body models.CustomerUpdateInput true "Données de mise à jour"
+// @Success 200 {object} models.Customer
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Client non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers/{id} [put]
+func PutCustomer(context *gin.Context) {
+	customer, err := models.FindCustomerByContext(context)
+
+	if err == nil {
+		var input models.CustomerUpdateInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		updates := make(map[string]interface{})
+
+		if input.Name != nil {
+			updates["name"] = strings.TrimSpace(*input.Name)
+		}
+
+		if input.Email != nil || input.Phone != nil {
+			contact := *customer
+
+			if input.Email != nil {
+				contact.Email = input.Email
+			}
+
+			if input.Phone != nil {
+				contact.Phone = input.Phone
+			}
+
+			if !models.ValidateCustomerContact(context, &contact) {
+				return
+			}
+
+			updates["email"] = contact.Email
+			updates["phone"] = contact.Phone
+		}
+
+		if input.LoyaltyConsent != nil {
+			updates["loyaltyConsent"] = *input.LoyaltyConsent
+		}
+
+		if input.MarketingConsent != nil {
+			updates["marketingConsent"] = *input.MarketingConsent
+		}
+
+		if len(updates) == 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+
+			return
+		}
+
+		if err := config.DB.WithContext(context).Model(&customer).Updates(updates).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update customer."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, customer)
+	}
+}
+
+// DeleteCustomer godoc
+// @Description Supprimer un client et son historique de points de fidélité, ses commandes étant conservées sans client
+// @Tags Customers
+// @Produce json
+// @Param id path int true "ID du client"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Client non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /customers/{id} [delete]
+func DeleteCustomer(context *gin.Context) {
+	customer, err := models.FindCustomerByContext(context)
+
+	if err == nil {
+		// Orders of every restaurant are detached from the customer.
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Order{}).Where("customer_id = ?", customer.ID).Update("customer_id", nil).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Where("customer_id = ?", customer.ID).Delete(&models.LoyaltyTransaction{}).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(&customer).Error
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete customer."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Customer deleted successfully."})
+	}
+}
@@ -0,0 +1,336 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+	"wacdo/config"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetKitchenStations godoc
+// @Description Récupérer tous les postes de préparation
+// @Tags KitchenStations
+// @Produce json
+// @Success 200 {array} models.KitchenStation
+// @Security BearerAuth
+// @Router /kitchen-stations [get]
+func GetKitchenStations(context *gin.Context) {
+	var kitchenStations []models.KitchenStation
+
+	if err := config.DB.WithContext(context).Find(&kitchenStations).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch kitchen stations."})
+		return
+	}
+
+	context.JSON(http.StatusOK, kitchenStations)
+}
+
+// GetKitchenStation godoc
+// @Description Récupérer un poste de préparation par son ID
+// @Tags KitchenStations
+// @Produce json
+// @Param id path int true "ID du poste de préparation"
+// @Success 200 {object} models.KitchenStation
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Poste de préparation non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/{id} [get]
+func GetKitchenStation(context *gin.Context) {
+	kitchenStation, err := models.FindKitchenStationByContext(context)
+
+	if err == nil {
+		context.JSON(http.StatusOK, kitchenStation)
+	}
+}
+
+// GetKitchenStationQueue godoc
+// @Description Récupérer la file des articles à préparer par un poste, triée par priorité des commandes
+// @Tags KitchenStations
+// @Produce json
+// @Param id path int true "ID du poste de préparation"
+// @Success 200 {array} models.KitchenTicketOutput
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Poste de préparation non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/{id}/queue [get]
+func GetKitchenStationQueue(context *gin.Context) {
+	kitchenStation, err := models.FindKitchenStationByContext(context)
+
+	if err == nil {
+		routedOrderIDs := config.DB.WithContext(context).Model(&models.OrderItem{}).
+			Select("order_items.order_id").
+			Joins("JOIN order_station_items ON order_station_items.order_item_id = order_items.id").
+			Where("order_station_items.kitchen_station_id = ?", kitchenStation.ID)
+
+		var orders []models.Order
+
+		if err := config.DB.WithContext(context).Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").
+			Where("status IN ?", []models.OrderStatus{models.Created, models.InPreparation}).
+			Where("id IN (?)", routedOrderIDs).
+			Find(&orders).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch orders."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, models.BuildKitchenTickets(kitchenStation.ID, orders, time.Now()))
+	}
+}
+
+// PostKitchenStation godoc
+// @Description Créer un nouveau poste de préparation
+// @Tags KitchenStations
+// @Accept json
+// @Produce json
+// @Param kitchenStation body models.KitchenStationInsertInput true "Données du poste de préparation"
+// @Success 201 {object} models.KitchenStation
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations [post]
+func PostKitchenStation(context *gin.Context) {
+	var input models.KitchenStationInsertInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	kitchenStation := models.KitchenStation{
+		Name:        input.Name,
+		Description: input.Description,
+	}
+
+	if err := config.DB.WithContext(context).Create(&kitchenStation).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create kitchen station."})
+
+		return
+	}
+
+	context.JSON(http.StatusCreated, kitchenStation)
+}
+
+// PutKitchenStation godoc
+// @Description Mettre à jour un poste de préparation existant
+// @Tags KitchenStations
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du poste de préparation"
+// @Param input body models.KitchenStationUpdateInput true "Données de mise à jour"
+// @Success 200 {object} models.KitchenStation
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Poste de préparation non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/{id} [put]
+func PutKitchenStation(context *gin.Context) {
+	kitchenStation, err := models.FindKitchenStationByContext(context)
+
+	if err == nil {
+		var input models.KitchenStationUpdateInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		updates := make(map[string]interface{})
+
+		if input.Name != nil {
+			updates["name"] = *input.Name
+		}
+
+		if input.Description != nil {
+			updates["description"] = *input.Description
+		}
+
+		if len(updates) == 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+
+			return
+		}
+
+		if err := config.DB.WithContext(context).Model(&kitchenStation).Updates(updates).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update kitchen station."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, kitchenStation)
+	}
+}
+
+// DeleteKitchenStation godoc
+// @Description Supprimer un poste de préparation
+// @Tags KitchenStations
+// @Produce json
+// @Param id path int true "ID du poste de préparation"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Poste de préparation non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/{id} [delete]
+func DeleteKitchenStation(context *gin.Context) {
+	kitchenStation, err := models.FindKitchenStationByContext(context)
+
+	if err == nil {
+		var productsCount, productsCategoriesCount int64
+		config.DB.WithContext(context).Model(&models.Product{}).Where("kitchen_station_id = ?", kitchenStation.ID).Count(&productsCount)
+		config.DB.WithContext(context).Model(&models.ProductCategory{}).Where("kitchen_station_id = ?", kitchenStation.ID).Count(&productsCategoriesCount)
+
+		if productsCount > 0 || productsCategoriesCount > 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete kitchen station: there are products or products categories associated with it."})
+
+			return
+		}
+
+		if err = config.DB.WithContext(context).Delete(&kitchenStation).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete kitchen station."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Kitchen station deleted successfully."})
+	}
+}
+
+// PatchKitchenStationItemInPreparation godoc
+// @Description Indiquer qu'un article est en préparation sur son poste
+// @Tags KitchenStations
+// @Produce json
+// @Param id path int true "ID de l'article à préparer"
+// @Success 200 {object} models.OrderStationItem
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Article non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/items/{id}/in-preparation [patch]
+func PatchKitchenStationItemInPreparation(context *gin.Context) {
+	stationItem, order, err := models.FindOrderStationItemByContext(context)
+
+	if err == nil {
+		if order.Status == models.Cancelled {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is cancelled."})
+
+			return
+		}
+
+		if stationItem.Status == models.InPreparation {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Item is already in preparation."})
+
+			return
+		}
+
+		if stationItem.Status == models.Prepared {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Item is already prepared."})
+
+			return
+		}
+
+		if order.IsHeld(time.Now()) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is scheduled for later and cannot be prepared yet."})
+
+			return
+		}
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := order.LockStatus(tx); err != nil {
+				return err
+			}
+
+			if err := tx.Model(&stationItem).Updates(map[string]interface{}{"status": models.InPreparation}).Error; err != nil {
+				return err
+			}
+
+			if order.Status == models.Created {
+				return order.ApplyStatusTransition(tx, models.InPreparation, *middlewares.GetUserId(context), time.Now())
+			}
+
+			return nil
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order item."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, stationItem)
+	}
+}
+
+// PatchKitchenStationItemPrepared godoc
+// @Description Indiquer qu'un article a été préparé sur son poste (la commande passe à l'état préparé lorsque tous ses articles le sont)
+// @Tags KitchenStations
+// @Produce json
+// @Param id path int true "ID de l'article à préparer"
+// @Success 200 {object} models.OrderStationItem
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Article non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /kitchen-stations/items/{id}/prepared [patch]
+func PatchKitchenStationItemPrepared(context *gin.Context) {
+	stationItem, order, err := models.FindOrderStationItemByContext(context)
+
+	if err == nil {
+		if order.Status == models.Cancelled {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is cancelled."})
+
+			return
+		}
+
+		if stationItem.Status == models.Prepared {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Item is already prepared."})
+
+			return
+		}
+
+		if stationItem.Status == models.Created {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Item must be in preparation before it can be prepared."})
+
+			return
+		}
+
+		updates := map[string]interface{}{
+			"status":     models.Prepared,
+			"preparedAt": time.Now(),
+		}
+
+		// The order is locked first, so that when two stations complete its last items at the same time,
+		// the second one sees the order already prepared.
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := order.LockStatus(tx); err != nil {
+				return err
+			}
+
+			if err := tx.Model(&stationItem).Updates(updates).Error; err != nil {
+				return err
+			}
+
+			pendingItemsCount, err := models.CountPendingOrderStationItems(tx, order)
+			if err != nil {
+				return err
+			}
+
+			if pendingItemsCount == 0 && order.Status == models.InPreparation {
+				return order.ApplyStatusTransition(tx, models.Prepared, *middlewares.GetUserId(context), time.Now())
+			}
+
+			return nil
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order item."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, stationItem)
+	}
+}
@@ -2,29 +2,50 @@ package controllers
 
 import (
 	"net/http"
+	"time"
 	"wacdo/config"
+	"wacdo/middlewares"
 	"wacdo/models"
 	"wacdo/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetMenus godoc
-// @Description Récupérer tous les menus
+// @Description Récupérer les menus, page par page, éventuellement filtrés, recherchés et triés
 // @Tags Menus
 // @Produce json
-// @Success 200 {array} models.Menu
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, name, price), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Param available query bool false "Disponibilité dans le restaurant"
+// @Param minPrice query number false "Prix minimum"
+// @Param maxPrice query number false "Prix maximum"
+// @Param excludeAllergens query string false "Allergènes à exclure, séparés par des virgules (ex : gluten,milk)"
+// @Param vegetarian query bool false "Uniquement les menus végétariens"
+// @Success 200 {object} models.ListOutput[models.Menu]
+// @Failure 400 {object} map[string]string "Filtres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
 // @Router /menus [get]
 func GetMenus(context *gin.Context) {
-	var menus []models.Menu
+	dietaryFilters, ok := models.ParseDietaryFilters(context)
+	if !ok {
+		return
+	}
 
-	if err := config.DB.Preload("Products").Find(&menus).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch menus."})
+	catalogFilters, ok := models.ParseCatalogFilters(context)
+	if !ok {
 		return
 	}
 
-	context.JSON(http.StatusOK, menus)
+	query := catalogFilters.ApplyToMenus(context, dietaryFilters.ApplyToMenus(config.DB.WithContext(context).Model(&models.Menu{}), config.DB))
+
+	if menus, ok := models.FindList[models.Menu](context, query, models.MenuListOptions); ok {
+		context.JSON(http.StatusOK, menus)
+	}
 }
 
 // GetMenu godoc
@@ -47,13 +68,13 @@ func GetMenu(context *gin.Context) {
 }
 
 // PostMenu godoc
-// @Description Créer un nouveau menu
+// @Description Créer un nouveau menu. Un menu plus cher que ses produits achetés séparément est refusé, ou accepté avec un en-tête Warning, selon MENU_PRICING_POLICY
 // @Tags Menus
 // @Accept json
 // @Produce json
 // @Param menu body models.MenuInsertInput true "Données du menu"
 // @Success 201 {object} models.Menu
-// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 400 {object} map[string]string "Données invalides ou menu plus cher que ses produits"
 // @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
 // @Router /menus [post]
@@ -70,12 +91,26 @@ func PostMenu(context *gin.Context) {
 		return
 	}
 
+	translations, ok := models.ValidateTranslations(context, input.Translations)
+	if !ok {
+		return
+	}
+
 	menu := models.Menu{
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       input.Price,
-		IsAvailable: input.IsAvailable,
-		Products:    *products,
+		Name:          input.Name,
+		Description:   input.Description,
+		Translations:  translations,
+		Price:         input.Price,
+		LoyaltyPoints: input.LoyaltyPoints,
+		IsAvailable:   input.IsAvailable,
+		Products:      *products,
+	}
+
+	menu.ComputeDietaryInformation()
+	menu.ComputePricing()
+
+	if !models.CheckMenuPricing(context, &menu) {
+		return
 	}
 
 	if input.Image != "" {
@@ -87,7 +122,14 @@ func PostMenu(context *gin.Context) {
 		menu.Image = *image
 	}
 
-	if err := config.DB.Create(&menu).Error; err != nil {
+	err := config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&menu).Error; err != nil {
+			return err
+		}
+
+		return models.RecordPriceChange(tx, &menu, 0, menu.Price, *middlewares.GetUserId(context), time.Now())
+	})
+	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create menu."})
 		return
 	}
@@ -96,14 +138,14 @@ func PostMenu(context *gin.Context) {
 }
 
 // PutMenu godoc
-// @Description Mettre à jour un menu existant
+// @Description Mettre à jour un menu existant. Si son prix ou ses produits changent, un menu plus cher que ses produits achetés séparément est refusé, ou accepté avec un en-tête Warning, selon MENU_PRICING_POLICY
 // @Tags Menus
 // @Accept json
 // @Produce json
 // @Param id path int true "ID du menu"
 // @Param input body models.MenuUpdateInput true "Données de mise à jour"
 // @Success 200 {object} models.Menu
-// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 400 {object} map[string]string "Données invalides ou menu plus cher que ses produits"
 // @Failure 404 {object} map[string]string "Produit non trouvé"
 // @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
@@ -133,6 +175,19 @@ func PutMenu(context *gin.Context) {
 			updates["price"] = *input.Price
 		}
 
+		if input.Translations != nil {
+			translations, ok := models.ValidateTranslations(context, *input.Translations)
+			if !ok {
+				return
+			}
+
+			updates["translations"] = translations
+		}
+
+		if input.LoyaltyPoints != nil {
+			updates["loyaltyPoints"] = *input.LoyaltyPoints
+		}
+
 		if input.IsAvailable != nil {
 			updates["isAvailable"] = *input.IsAvailable
 		}
@@ -145,6 +200,23 @@ func PutMenu(context *gin.Context) {
 			}
 		}
 
+		if input.Price != nil || products != nil {
+			priced := *menu
+			if input.Price != nil {
+				priced.Price = *input.Price
+			}
+
+			if products != nil {
+				priced.Products = *products
+			}
+
+			priced.ComputePricing()
+
+			if !models.CheckMenuPricing(context, &priced) {
+				return
+			}
+		}
+
 		if input.Image != nil {
 			image, err := utils.UploadBase64Image(context, *input.Image)
 			if err != nil {
@@ -160,26 +232,43 @@ func PutMenu(context *gin.Context) {
 			return
 		}
 
-		if err := config.DB.Model(&menu).Updates(updates).Error; err != nil {
+		previousPrice := menu.Price
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&menu).Updates(updates).Error; err != nil {
+				return err
+			}
+
+			if input.Price != nil && *input.Price != previousPrice {
+				return models.RecordPriceChange(tx, menu, previousPrice, *input.Price, *middlewares.GetUserId(context), time.Now())
+			}
+
+			return nil
+		})
+		if err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update menu."})
 
 			return
 		}
 
 		if input.ProductsIDs != nil {
-			if err := config.DB.Model(&menu).Association("Products").Replace(products); err != nil {
+			if err := config.DB.WithContext(context).Model(&menu).Association("Products").Replace(products); err != nil {
 				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update menu products."})
 
 				return
 			}
+
+			menu.ComputeDietaryInformation()
 		}
 
+		menu.ComputePricing()
+
 		context.JSON(http.StatusOK, menu)
 	}
 }
 
 // DeleteMenu godoc
-// @Description Supprimer un menu
+// @Description Supprimer un menu, qui peut ensuite être restauré ou supprimé définitivement
 // @Tags Menus
 // @Produce json
 // @Param id path int true "ID du menu"
@@ -193,18 +282,292 @@ func DeleteMenu(context *gin.Context) {
 	menu, err := models.FindMenuByContext(context)
 
 	if err == nil {
-		if err := config.DB.Model(&menu).Association("Products").Replace([]models.Product{}); err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete menu products."})
+		if err = config.DB.WithContext(context).Delete(&menu).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete menu."})
 
 			return
 		}
 
-		if err = config.DB.Delete(&menu).Error; err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete menu."})
+		context.JSON(http.StatusOK, gin.H{"message": "Menu deleted successfully."})
+	}
+}
+
+// GetDeletedMenus godoc
+// @Description Récupérer les menus supprimés, page par page, éventuellement recherchés et triés
+// @Tags Menus
+// @Produce json
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, name, price), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Success 200 {object} models.ListOutput[models.Menu]
+// @Failure 400 {object} map[string]string "Paramètres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/deleted [get]
+func GetDeletedMenus(context *gin.Context) {
+	query := models.DeletedQuery(context, &models.Menu{}, "menus")
+
+	if menus, ok := models.FindList[models.Menu](context, query, models.MenuListOptions); ok {
+		context.JSON(http.StatusOK, menus)
+	}
+}
+
+// PostMenuRestore godoc
+// @Description Restaurer un menu supprimé
+// @Tags Menus
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Success 200 {object} models.Menu
+// @Failure 400 {object} map[string]string "ID invalide ou produits du menu supprimés"
+// @Failure 404 {object} map[string]string "Menu supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/restore [post]
+func PostMenuRestore(context *gin.Context) {
+	menu, err := models.FindDeletedByContext[models.Menu](context, "menu")
+
+	if err == nil {
+		if !models.ValidateMenuRestore(context, menu) {
+			return
+		}
+
+		if err := models.Restore(context, menu); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to restore menu."})
 
 			return
 		}
 
-		context.JSON(http.StatusOK, gin.H{"message": "Menu deleted successfully."})
+		if menu, err = models.FindMenuById(context, menu.ID); err == nil {
+			context.JSON(http.StatusOK, menu)
+		}
 	}
 }
+
+// DeleteMenuPurge godoc
+// @Description Supprimer définitivement un menu supprimé, avec ses disponibilités, ses créneaux, son historique de prix et ses changements de prix programmés
+// @Tags Menus
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide ou menu présent dans des commandes"
+// @Failure 404 {object} map[string]string "Menu supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/purge [delete]
+func DeleteMenuPurge(context *gin.Context) {
+	menu, err := models.FindDeletedByContext[models.Menu](context, "menu")
+
+	if err == nil {
+		if !models.ValidatePurge(context, &models.OrderItem{}, "menu_id", menu.ID, "Cannot purge menu: there are orders associated with it.") {
+			return
+		}
+
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			return models.PurgeMenu(tx, menu)
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to purge menu."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Menu purged successfully."})
+	}
+}
+
+// PutMenuAvailability godoc
+// @Description Définir la disponibilité d'un menu dans le restaurant courant
+// @Tags Menus
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Param input body models.CatalogAvailabilityInput true "Disponibilité"
+// @Success 200 {object} models.Menu
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Menu non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/availability [put]
+func PutMenuAvailability(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		var input models.CatalogAvailabilityInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if err := models.SaveCatalogAvailability(context, "menu_id", menu.ID, *input.IsAvailable); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update menu availability."})
+
+			return
+		}
+
+		menu.IsAvailable = *input.IsAvailable
+
+		context.JSON(http.StatusOK, menu)
+	}
+}
+
+// GetMenuPrices godoc
+// @Description Récupérer l'historique des prix d'un menu et ses changements de prix programmés
+// @Tags Menus
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Success 200 {object} models.PriceHistoryOutput
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Menu non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/prices [get]
+func GetMenuPrices(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		history, err := models.FindPriceHistory(context, menu)
+
+		if err == nil {
+			context.JSON(http.StatusOK, history)
+		}
+	}
+}
+
+// GetMenuPrice godoc
+// @Description Récupérer le prix d'un menu en vigueur à une date, changements programmés compris
+// @Tags Menus
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Param at query string false "Date au format RFC 3339 (maintenant par défaut)"
+// @Success 200 {object} models.PriceAtOutput
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Menu non trouvé ou aucun prix à cette date"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/price [get]
+func GetMenuPrice(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		price, err := models.FindPriceAt(context, menu, time.Now())
+
+		if err == nil {
+			context.JSON(http.StatusOK, price)
+		}
+	}
+}
+
+// PostMenuPrice godoc
+// @Description Programmer un changement de prix d'un menu, appliqué automatiquement à la date indiquée
+// @Tags Menus
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Param input body models.PriceChangeInsertInput true "Prix et date d'application"
+// @Success 201 {object} models.PriceChange
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Menu non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/prices [post]
+func PostMenuPrice(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		var input models.PriceChangeInsertInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if change, ok := models.SchedulePriceChange(context, menu, input, *middlewares.GetUserId(context), time.Now()); ok {
+			context.JSON(http.StatusCreated, change)
+		}
+	}
+}
+
+// DeleteMenuPrice godoc
+// @Description Annuler un changement de prix programmé d'un menu
+// @Tags Menus
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Param priceChangeID path int true "ID du changement de prix"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Menu non trouvé ou changement de prix programmé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/prices/{priceChangeID} [delete]
+func DeleteMenuPrice(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		change, err := models.FindScheduledPriceChangeByContext(context, menu)
+
+		if err == nil {
+			if err := config.DB.WithContext(context).Delete(change).Error; err != nil {
+				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to cancel price change."})
+
+				return
+			}
+
+			context.JSON(http.StatusOK, gin.H{"message": "Price change cancelled successfully."})
+		}
+	}
+}
+
+// PutMenuSchedule godoc
+// @Description Définir les créneaux de disponibilité d'un menu (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure
+// @Tags Menus
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du menu"
+// @Param input body models.AvailabilityScheduleInput true "Créneaux de disponibilité"
+// @Success 200 {object} models.Menu
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Menu non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/{id}/schedule [put]
+func PutMenuSchedule(context *gin.Context) {
+	menu, err := models.FindMenuByContext(context)
+
+	if err == nil {
+		var input models.AvailabilityScheduleInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if _, ok := models.ReplaceAvailabilitySchedule(context, "menu_id", menu.ID, input); !ok {
+			return
+		}
+
+		if menu, err = models.FindMenuById(context, menu.ID); err == nil {
+			context.JSON(http.StatusOK, menu)
+		}
+	}
+}
+
+// GetMenusPricingReport godoc
+// @Description Lister les menus dont le prix est supérieur à celui de leurs produits achetés séparément
+// @Tags Menus
+// @Produce json
+// @Success 200 {array} models.MenuPricingReportItem
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /menus/pricing-report [get]
+func GetMenusPricingReport(context *gin.Context) {
+	items, err := models.FindOverpricedMenus(context)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch menus."})
+
+		return
+	}
+
+	context.JSON(http.StatusOK, items)
+}
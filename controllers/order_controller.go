@@ -1,13 +1,17 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 	"wacdo/config"
 	"wacdo/middlewares"
 	"wacdo/models"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetOrders godoc
@@ -20,7 +24,48 @@ import (
 func GetOrders(context *gin.Context) {
 	var orders []models.Order
 
-	if err := config.DB.Preload("User").Preload("Items").Find(&orders).Error; err != nil {
+	if err := config.DB.WithContext(context).Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").Find(&orders).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch orders."})
+		return
+	}
+
+	context.JSON(http.StatusOK, models.TransformOrdersToOutput(orders))
+}
+
+// GetOrdersQueue godoc
+// @Description Récupérer la file des commandes à préparer, triée par priorité
+// @Tags Orders
+// @Produce json
+// @Success 200 {array} models.OrderQueueOutput
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/queue [get]
+func GetOrdersQueue(context *gin.Context) {
+	var orders []models.Order
+
+	if err := config.DB.WithContext(context).Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").Where("status IN ?", []models.OrderStatus{models.Created, models.InPreparation}).Find(&orders).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch orders."})
+		return
+	}
+
+	context.JSON(http.StatusOK, models.PrioritizeOrders(orders, time.Now()))
+}
+
+// GetOrdersDeliveries godoc
+// @Description Récupérer les commandes en livraison préparées, réservées par un livreur ou en cours de livraison, les plus anciennes en premier
+// @Tags Orders
+// @Produce json
+// @Success 200 {array} models.Order
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/deliveries [get]
+func GetOrdersDeliveries(context *gin.Context) {
+	var orders []models.Order
+
+	if err := config.DB.WithContext(context).Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").
+		Where("channel = ? AND status IN ?", models.Delivery, []models.OrderStatus{models.Prepared, models.Claimed, models.OutForDelivery}).
+		Order("prepared_at, id").
+		Find(&orders).Error; err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch orders."})
 		return
 	}
@@ -43,7 +88,10 @@ func GetOrder(context *gin.Context) {
 	order, err := models.FindOrderByContext(context)
 
 	if err == nil {
-		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
+		output := models.TransformOrderToOutput(order)
+		output.EstimatedReadyAt = models.EstimateOrderReadyAt(context, order, time.Now())
+
+		context.JSON(http.StatusOK, output)
 	}
 }
 
@@ -73,24 +121,80 @@ func PostOrder(context *gin.Context) {
 		return
 	}
 
-	orderItems := models.TransformOrderItemInputsToOrderItems(context, input.Items)
-	if orderItems == nil {
+	order := models.NewOrderFromInput(context, &input, user)
+	if order == nil {
 		return
 	}
 
-	order := models.Order{
-		TicketNumber: input.TicketNumber,
-		User:         *user,
-		Status:       models.Created,
-		Items:        *orderItems,
+	if order.TicketNumber == "" {
+		restaurantID, _ := models.GetRestaurantId(context)
+
+		order.TicketNumber, err = models.NextTicketNumber(config.DB, restaurantID)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to generate ticket number."})
+
+			return
+		}
+	}
+
+	err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Customer").Create(order).Error; err != nil {
+			return err
+		}
+
+		if err := models.EmitOrderEvent(tx, models.OrderCreated, order, "", time.Now()); err != nil {
+			return err
+		}
+
+		return models.SettleLoyaltyPoints(tx, order, time.Now())
+	})
+	if errors.Is(err, models.ErrNotEnoughLoyaltyPoints) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Not enough loyalty points."})
+		return
 	}
 
-	if err := config.DB.Create(&order).Error; err != nil {
+	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create order."})
 		return
 	}
 
-	context.JSON(http.StatusCreated, models.TransformOrderToOutput(&order))
+	output := models.TransformOrderToOutput(order)
+	output.EstimatedReadyAt = models.EstimateOrderReadyAt(context, order, time.Now())
+
+	context.JSON(http.StatusCreated, output)
+}
+
+// PostOrderQuote godoc
+// @Description Calculer le détail et le total d'une commande en cours de saisie, sans l'enregistrer
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param order body models.OrderInsertInput true "Données de la commande"
+// @Success 200 {object} models.OrderQuoteOutput
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/quote [post]
+func PostOrderQuote(context *gin.Context) {
+	var input models.OrderInsertInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	user, err := models.FindUserById(context, *middlewares.GetUserId(context))
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to find user."})
+		return
+	}
+
+	order := models.NewOrderFromInput(context, &input, user)
+	if order == nil {
+		return
+	}
+
+	context.JSON(http.StatusOK, models.TransformOrderToQuoteOutput(order))
 }
 
 // PutOrder godoc
@@ -110,7 +214,13 @@ func PutOrder(context *gin.Context) {
 	order, err := models.FindOrderByContext(context)
 
 	if err == nil {
-		if order.Status == models.Prepared || order.Status == models.Delivered {
+		if order.Status == models.Cancelled {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is cancelled."})
+
+			return
+		}
+
+		if order.Status != models.Created && order.Status != models.InPreparation {
 			context.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be modified because it has already been prepared."})
 
 			return
@@ -129,32 +239,159 @@ func PutOrder(context *gin.Context) {
 			updates["ticketNumber"] = *input.TicketNumber
 		}
 
+		if input.Channel != nil {
+			if !input.Channel.IsValid() {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel."})
+
+				return
+			}
+
+			updates["channel"] = *input.Channel
+		}
+
+		if input.RequestedReadyAt.IsSet {
+			if order.Status != models.Created {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be rescheduled because its preparation has started."})
+
+				return
+			}
+
+			if !models.ValidateRequestedReadyAt(context, input.RequestedReadyAt.Value) {
+				return
+			}
+
+			user, err := models.FindUserById(context, *middlewares.GetUserId(context))
+			if err != nil {
+				return
+			}
+
+			if !models.ValidateOrderAcceptance(context, user, input.RequestedReadyAt.Value, input.OverrideOpeningHours) {
+				return
+			}
+
+			if input.Items == nil && !models.ValidateOrderItemsAvailableAt(context, order.Items, input.RequestedReadyAt.Value) {
+				return
+			}
+
+			updates["requestedReadyAt"] = input.RequestedReadyAt.Value
+		}
+
+		if input.Notes != nil {
+			notes, ok := models.SanitizeNotes(context, *input.Notes, models.OrderNotesMaxLength)
+			if !ok {
+				return
+			}
+
+			updates["notes"] = notes
+		}
+
+		if input.Allergens != nil {
+			if !models.ValidateAllergens(context, *input.Allergens) {
+				return
+			}
+
+			updates["allergens"] = *input.Allergens
+		}
+
 		var orderItems *[]models.OrderItem
 		if input.Items != nil {
-			orderItems = models.TransformOrderItemInputsToOrderItems(context, *input.Items)
+			requestedReadyAt := order.RequestedReadyAt
+			if input.RequestedReadyAt.IsSet {
+				requestedReadyAt = input.RequestedReadyAt.Value
+			}
+
+			orderItems = models.TransformOrderItemInputsToOrderItems(context, *input.Items, requestedReadyAt)
 			if orderItems == nil {
 				return
 			}
 		}
 
+		if input.Channel != nil || input.DeliveryAddress != nil || input.DeliveryPhone != nil {
+			channel, address, phone := order.Channel, order.DeliveryAddress, order.DeliveryPhone
+
+			if input.Channel != nil {
+				channel = *input.Channel
+			}
+
+			if input.DeliveryAddress != nil {
+				address = *input.DeliveryAddress
+			}
+
+			if input.DeliveryPhone != nil {
+				phone = *input.DeliveryPhone
+			}
+
+			address, phone, ok := models.ValidateDeliveryContact(context, channel, address, phone)
+			if !ok {
+				return
+			}
+
+			updates["deliveryAddress"] = address
+			updates["deliveryPhone"] = phone
+		}
+
+		customerID := order.CustomerID
+		if input.CustomerID != nil {
+			if order.CustomerID != nil && *order.CustomerID != *input.CustomerID {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Order customer cannot be changed."})
+
+				return
+			}
+
+			customerID = input.CustomerID
+			updates["customerID"] = *input.CustomerID
+		}
+
+		updatedOrder := *order
+		if orderItems != nil {
+			updatedOrder.Items = *orderItems
+		}
+
+		if !models.ValidateOrderCustomer(context, customerID, &updatedOrder, order.RedeemedPoints()) {
+			return
+		}
+
 		if len(updates) == 0 && orderItems == nil {
 			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
 
 			return
 		}
 
-		if err := config.DB.Model(&order).Updates(updates).Error; err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
+		previousItems := order.Items
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&order).Updates(updates).Error; err != nil {
+				return err
+			}
+
+			if input.Items != nil {
+				if err := order.ReplaceItems(tx, *orderItems); err != nil {
+					return err
+				}
+
+				if order.Status == models.InPreparation {
+					if err := order.RecordAmendment(tx, previousItems, *middlewares.GetUserId(context), time.Now()); err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := models.SettleLoyaltyPoints(tx, order, time.Now()); err != nil {
+				return err
+			}
+
+			return models.EmitOrderEvent(tx, models.OrderUpdated, order, "", time.Now())
+		})
+		if errors.Is(err, models.ErrNotEnoughLoyaltyPoints) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Not enough loyalty points."})
 
 			return
 		}
 
-		if input.Items != nil {
-			if err := config.DB.Model(&order).Association("Items").Unscoped().Replace(orderItems); err != nil {
-				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order items."})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
-				return
-			}
+			return
 		}
 
 		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
@@ -177,29 +414,49 @@ func PatchOrderInPreparation(context *gin.Context) {
 	order, err := models.FindOrderByContext(context)
 
 	if err == nil {
-		if order.Status == models.InPreparation {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already in preparation."})
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.InPreparation, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
 
 			return
 		}
 
-		if order.Status == models.Prepared {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already prepared."})
+		if err := order.ApplyStatusTransition(config.DB, models.InPreparation, *middlewares.GetUserId(context), now); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
 			return
 		}
 
-		if order.Status == models.Delivered {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already delivered."})
+		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
+	}
+}
 
-			return
-		}
+// PatchOrderPrepared godoc
+// @Description Indiquer que la commande a été préparée
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la commande"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Commande non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/{id}/prepared [patch]
+func PatchOrderPrepared(context *gin.Context) {
+	order, err := models.FindOrderByContext(context)
+
+	if err == nil {
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.Prepared, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
 
-		updates := map[string]interface{}{
-			"status": models.InPreparation,
+			return
 		}
 
-		if err := config.DB.Model(&order).Updates(updates).Error; err != nil {
+		if err := order.ApplyStatusTransition(config.DB, models.Prepared, *middlewares.GetUserId(context), now); err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
 			return
@@ -209,8 +466,8 @@ func PatchOrderInPreparation(context *gin.Context) {
 	}
 }
 
-// PatchOrderPrepared godoc
-// @Description Indiquer que la commande a été préparée
+// PatchOrderClaimed godoc
+// @Description Réserver une commande en livraison préparée, par le livreur qui va la livrer
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -220,35 +477,66 @@ func PatchOrderInPreparation(context *gin.Context) {
 // @Failure 404 {object} map[string]string "Commande non trouvée"
 // @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
-// @Router /orders/{id}/prepared [patch]
-func PatchOrderPrepared(context *gin.Context) {
+// @Router /orders/{id}/claimed [patch]
+func PatchOrderClaimed(context *gin.Context) {
 	order, err := models.FindOrderByContext(context)
 
 	if err == nil {
-		if order.Status == models.Prepared {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already prepared."})
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.Claimed, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
 
 			return
 		}
 
-		if order.Status == models.Created {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order must be in preparation before it can be prepared."})
+		if err := order.ApplyStatusTransition(config.DB, models.Claimed, *middlewares.GetUserId(context), now); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
 			return
 		}
 
-		if order.Status == models.Delivered {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already delivered."})
+		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
+	}
+}
+
+// PatchOrderOutForDelivery godoc
+// @Description Indiquer que la commande est en cours de livraison (un livreur ne peut indiquer que les commandes qu'il a réservées)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la commande"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 403 {object} map[string]string "Commande réservée par un autre livreur"
+// @Failure 404 {object} map[string]string "Commande non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/{id}/out-for-delivery [patch]
+func PatchOrderOutForDelivery(context *gin.Context) {
+	order, err := models.FindOrderByContext(context)
+
+	if err == nil {
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.OutForDelivery, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
 
 			return
 		}
 
-		updates := map[string]interface{}{
-			"status":     models.Prepared,
-			"preparedAt": time.Now(),
+		user, err := models.FindUserById(context, *middlewares.GetUserId(context))
+		if err != nil {
+			return
 		}
 
-		if err := config.DB.Model(&order).Updates(updates).Error; err != nil {
+		if err := order.ValidateDriver(models.OutForDelivery, user); err != nil {
+			context.JSON(http.StatusForbidden, gin.H{"error": orderStatusErrorMessages[err]})
+
+			return
+		}
+
+		if err := order.ApplyStatusTransition(config.DB, models.OutForDelivery, user.ID, now); err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
 			return
@@ -259,13 +547,14 @@ func PatchOrderPrepared(context *gin.Context) {
 }
 
 // PatchOrderDelivered godoc
-// @Description Indiquer que la commande a été livrée
+// @Description Indiquer que la commande a été livrée (un livreur ne peut indiquer que les commandes qu'il a réservées)
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param id path int true "ID de la commande"
 // @Success 200 {object} models.Order
 // @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 403 {object} map[string]string "Commande réservée par un autre livreur"
 // @Failure 404 {object} map[string]string "Commande non trouvée"
 // @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
@@ -274,24 +563,60 @@ func PatchOrderDelivered(context *gin.Context) {
 	order, err := models.FindOrderByContext(context)
 
 	if err == nil {
-		if order.Status == models.Delivered {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order is already delivered."})
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.Delivered, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
+
+			return
+		}
 
+		user, err := models.FindUserById(context, *middlewares.GetUserId(context))
+		if err != nil {
 			return
 		}
 
-		if order.Status != models.Prepared {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Order must be prepared before it can be delivered."})
+		if err := order.ValidateDriver(models.Delivered, user); err != nil {
+			context.JSON(http.StatusForbidden, gin.H{"error": orderStatusErrorMessages[err]})
 
 			return
 		}
 
-		updates := map[string]interface{}{
-			"status":      models.Delivered,
-			"deliveredAt": time.Now(),
+		if err := order.ApplyStatusTransition(config.DB, models.Delivered, user.ID, now); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
+	}
+}
+
+// PatchOrderCancelled godoc
+// @Description Annuler une commande qui n'a pas encore été livrée
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la commande"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Commande non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/{id}/cancelled [patch]
+func PatchOrderCancelled(context *gin.Context) {
+	order, err := models.FindOrderByContext(context)
+
+	if err == nil {
+		now := time.Now()
+
+		if err := order.ValidateStatusTransition(models.Cancelled, now); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": orderStatusErrorMessages[err]})
+
+			return
 		}
 
-		if err := config.DB.Model(&order).Updates(updates).Error; err != nil {
+		if err := order.ApplyStatusTransition(config.DB, models.Cancelled, *middlewares.GetUserId(context), now); err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
 
 			return
@@ -300,3 +625,260 @@ func PatchOrderDelivered(context *gin.Context) {
 		context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
 	}
 }
+
+// GetOrderHistory godoc
+// @Description Récupérer l'historique des changements d'état d'une commande
+// @Tags Orders
+// @Produce json
+// @Param id path int true "ID de la commande"
+// @Success 200 {array} models.OrderStatusHistory
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Commande non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/{id}/history [get]
+func GetOrderHistory(context *gin.Context) {
+	order, err := models.FindOrderByContext(context)
+
+	if err == nil {
+		history, err := models.FindOrderStatusHistory(context, order)
+
+		if err == nil {
+			context.JSON(http.StatusOK, history)
+		}
+	}
+}
+
+// PatchOrderRevert godoc
+// @Description Ramener une commande à son état précédent, en indiquant la raison (par exemple une commande déclarée préparée par erreur)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la commande"
+// @Param input body models.OrderRevertInput true "Raison du retour à l'état précédent"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Commande non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/{id}/revert [patch]
+func PatchOrderRevert(context *gin.Context) {
+	order, err := models.FindOrderByContext(context)
+
+	if err == nil {
+		var input models.OrderRevertInput
+
+		if err := context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if strings.TrimSpace(input.Reason) == "" {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Reason is required."})
+
+			return
+		}
+
+		previousStatus, err := order.PreviousStatus(config.DB)
+		if err != nil {
+			if message, ok := orderStatusErrorMessages[err]; ok {
+				context.JSON(http.StatusBadRequest, gin.H{"error": message})
+
+				return
+			}
+
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch order history."})
+
+			return
+		}
+
+		if err := order.RevertStatusTransition(config.DB, previousStatus, *middlewares.GetUserId(context), strings.TrimSpace(input.Reason), time.Now()); err != nil {
+			if errors.Is(err, models.ErrNotEnoughLoyaltyPoints) {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Not enough loyalty points."})
+
+				return
+			}
+
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update order."})
+
+			return
+		}
+
+		order, err = models.FindOrderById(context, order.ID)
+
+		if err == nil {
+			context.JSON(http.StatusOK, models.TransformOrderToOutput(order))
+		}
+	}
+}
+
+// PatchOrdersStatus godoc
+// @Description Modifier l'état d'avancement de plusieurs commandes à la fois, soit toutes ou aucune (atomic), soit toutes celles qui le peuvent (bestEffort)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param input body models.OrderStatusBulkInput true "Commandes et état à appliquer"
+// @Success 200 {object} models.OrderStatusBulkOutput
+// @Failure 400 {object} models.OrderStatusBulkOutput "Données invalides"
+// @Failure 403 {object} map[string]string "Accès non autorisé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /orders/status [patch]
+func PatchOrdersStatus(context *gin.Context) {
+	var input models.OrderStatusBulkInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	if input.Mode == "" {
+		input.Mode = models.Atomic
+	}
+
+	if !input.Mode.IsValid() {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mode."})
+
+		return
+	}
+
+	roles, ok := models.OrderStatusRoles[input.Status]
+	if !ok {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status."})
+
+		return
+	}
+
+	user, err := models.FindUserById(context, *middlewares.GetUserId(context))
+	if err != nil {
+		return
+	}
+
+	if !slices.Contains(roles, user.Role) {
+		context.JSON(http.StatusForbidden, gin.H{"error": "Access not allowed."})
+
+		return
+	}
+
+	var orders []models.Order
+	if err := config.DB.WithContext(context).Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").Find(&orders, input.OrderIDs).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch orders."})
+
+		return
+	}
+
+	ordersById := make(map[uint]*models.Order, len(orders))
+	for index := range orders {
+		ordersById[orders[index].ID] = &orders[index]
+	}
+
+	now := time.Now()
+	output := models.OrderStatusBulkOutput{Status: input.Status, Mode: input.Mode}
+	var validOrders []*models.Order
+	processedOrderIDs := make(map[uint]bool)
+
+	for _, orderID := range input.OrderIDs {
+		// An order listed twice is handled once.
+		if processedOrderIDs[orderID] {
+			continue
+		}
+
+		processedOrderIDs[orderID] = true
+
+		result := models.OrderStatusBulkResult{OrderID: orderID}
+
+		order, ok := ordersById[orderID]
+		if !ok {
+			result.Error = "Order not found."
+		} else if err := order.ValidateStatusTransition(input.Status, now); err != nil {
+			result.Error = orderStatusErrorMessages[err]
+		} else if err := order.ValidateDriver(input.Status, user); err != nil {
+			result.Error = orderStatusErrorMessages[err]
+		} else {
+			result.Success = true
+			validOrders = append(validOrders, order)
+		}
+
+		output.Results = append(output.Results, result)
+	}
+
+	if input.Mode == models.Atomic {
+		if len(validOrders) < len(output.Results) {
+			for index := range output.Results {
+				if output.Results[index].Success {
+					output.Results[index].Success = false
+					output.Results[index].Error = "Order was not updated because other orders cannot be."
+				}
+			}
+
+			output.Failed = len(output.Results)
+			context.JSON(http.StatusBadRequest, output)
+
+			return
+		}
+
+		err := config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			for _, order := range validOrders {
+				if err := order.ApplyStatusTransition(tx, input.Status, user.ID, now); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update orders."})
+
+			return
+		}
+	}
+
+	for index := range output.Results {
+		result := &output.Results[index]
+
+		if result.Success {
+			order := ordersById[result.OrderID]
+
+			if input.Mode == models.BestEffort {
+				if err := order.ApplyStatusTransition(config.DB, input.Status, user.ID, now); err != nil {
+					result.Success = false
+					result.Error = "Unable to update order."
+					output.Failed++
+
+					continue
+				}
+			}
+
+			orderOutput := models.TransformOrderToOutput(order)
+			result.Order = &orderOutput
+			output.Succeeded++
+		} else {
+			output.Failed++
+		}
+	}
+
+	context.JSON(http.StatusOK, output)
+}
+
+// orderStatusErrorMessages gives the message returned for each error of a status transition.
+var orderStatusErrorMessages = map[error]string{
+	models.ErrOrderAlreadyInPreparation:   "Order is already in preparation.",
+	models.ErrOrderAlreadyPrepared:        "Order is already prepared.",
+	models.ErrOrderAlreadyClaimed:         "Order is already claimed.",
+	models.ErrOrderAlreadyOutForDelivery:  "Order is already out for delivery.",
+	models.ErrOrderAlreadyDelivered:       "Order is already delivered.",
+	models.ErrOrderAlreadyCancelled:       "Order is already cancelled.",
+	models.ErrOrderCancelled:              "Order is cancelled.",
+	models.ErrOrderHeld:                   "Order is scheduled for later and cannot be prepared yet.",
+	models.ErrOrderNotInPreparation:       "Order must be in preparation before it can be prepared.",
+	models.ErrOrderNotDelivery:            "Order is not a delivery order.",
+	models.ErrOrderNotPreparedForClaim:    "Order must be prepared before it can be claimed.",
+	models.ErrOrderNotClaimed:             "Order must be claimed before it can be out for delivery.",
+	models.ErrOrderNotOutForDelivery:      "Order must be out for delivery before it can be delivered.",
+	models.ErrOrderNotPreparedForDelivery: "Order must be prepared before it can be delivered.",
+	models.ErrInvalidOrderStatus:          "Invalid status.",
+	models.ErrOrderClaimedByOtherDriver:   "Order is not claimed by this driver.",
+	models.ErrOrderNoPreviousStatus:       "Order has no previous status.",
+	models.ErrOrderUnknownPreviousStatus:  "Previous status of the order is unknown.",
+}
@@ -6,24 +6,37 @@ import (
 	"wacdo/models"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetProductsCategories godoc
-// @Description Récupérer toutes les catégories de produits
+// @Description Récupérer l'arbre des catégories de produits, sans leurs produits : les catégories de premier niveau, page par page, avec leurs sous-catégories imbriquées dans Children, dans l'ordre d'affichage. Une recherche porte sur les catégories de tous les niveaux.
 // @Tags ProductsCategories
 // @Produce json
-// @Success 200 {array} models.ProductCategory
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (position par défaut, id, name), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Success 200 {object} models.ListOutput[models.ProductCategory]
+// @Failure 400 {object} map[string]string "Paramètres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
 // @Router /products/categories [get]
 func GetProductsCategories(context *gin.Context) {
-	var productsCategories []models.ProductCategory
-
-	if err := config.DB.Preload("Products").Find(&productsCategories).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch products categories."})
-		return
+	query := config.DB.WithContext(context).Model(&models.ProductCategory{})
+	if context.Query("search") == "" {
+		query = query.Where("product_categories.parent_id IS NULL")
 	}
 
-	context.JSON(http.StatusOK, productsCategories)
+	if productsCategories, ok := models.FindList[models.ProductCategory](context, query, models.ProductCategoryTreeOptions); ok {
+		if err := models.LoadProductCategoryChildren(context, productsCategories.Items); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch list."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, productsCategories)
+	}
 }
 
 // GetProductCategory GetProduct godoc
@@ -64,12 +77,41 @@ func PostProductCategory(context *gin.Context) {
 		return
 	}
 
+	kitchenStationID, ok := models.FindOptionalKitchenStationId(context, input.KitchenStationID)
+	if !ok {
+		return
+	}
+
+	parentID, ok := models.FindProductCategoryParentId(context, 0, input.ParentID)
+	if !ok {
+		return
+	}
+
+	translations, ok := models.ValidateTranslations(context, input.Translations)
+	if !ok {
+		return
+	}
+
 	productCategory := models.ProductCategory{
-		Name:        input.Name,
-		Description: input.Description,
+		Name:             input.Name,
+		Description:      input.Description,
+		Translations:     translations,
+		KitchenStationID: kitchenStationID,
+		ParentID:         parentID,
 	}
 
-	if err := config.DB.Create(&productCategory).Error; err != nil {
+	if input.Position != nil {
+		productCategory.Position = *input.Position
+	} else {
+		var err error
+		if productCategory.Position, err = models.NextProductCategoryPosition(config.DB.WithContext(context), parentID); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create product category."})
+
+			return
+		}
+	}
+
+	if err := config.DB.WithContext(context).Create(&productCategory).Error; err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create product category."})
 
 		return
@@ -112,13 +154,56 @@ func PutProductCategory(context *gin.Context) {
 			updates["description"] = *input.Description
 		}
 
+		if input.Translations != nil {
+			translations, ok := models.ValidateTranslations(context, *input.Translations)
+			if !ok {
+				return
+			}
+
+			updates["translations"] = translations
+		}
+
+		if input.KitchenStationID != nil {
+			kitchenStationID, ok := models.FindOptionalKitchenStationId(context, input.KitchenStationID)
+			if !ok {
+				return
+			}
+
+			updates["kitchenStationID"] = kitchenStationID
+		}
+
+		if input.ParentID != nil {
+			parentID, ok := models.FindProductCategoryParentId(context, productCategory.ID, input.ParentID)
+			if !ok {
+				return
+			}
+
+			updates["parent_id"] = parentID
+
+			// Moved to the end of its new parent, unless given a position.
+			if !sameParent(productCategory.ParentID, parentID) && input.Position == nil {
+				position, err := models.NextProductCategoryPosition(config.DB.WithContext(context), parentID)
+				if err != nil {
+					context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product category."})
+
+					return
+				}
+
+				updates["position"] = position
+			}
+		}
+
+		if input.Position != nil {
+			updates["position"] = *input.Position
+		}
+
 		if len(updates) == 0 {
 			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
 
 			return
 		}
 
-		if err := config.DB.Model(&productCategory).Updates(updates).Error; err != nil {
+		if err := config.DB.WithContext(context).Model(&productCategory).Updates(updates).Error; err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product category."})
 
 			return
@@ -129,7 +214,7 @@ func PutProductCategory(context *gin.Context) {
 }
 
 // DeleteProductCategory godoc
-// @Description Supprimer une catégorie de produit
+// @Description Supprimer une catégorie de produit, qui peut ensuite être restaurée ou supprimée définitivement
 // @Tags ProductsCategories
 // @Produce json
 // @Param id path int true "ID de la catégorie de produit"
@@ -149,7 +234,20 @@ func DeleteProductCategory(context *gin.Context) {
 			return
 		}
 
-		if err = config.DB.Delete(&productCategory).Error; err != nil {
+		var childrenCount int64
+		if err = config.DB.WithContext(context).Model(&models.ProductCategory{}).Where("parent_id = ?", productCategory.ID).Count(&childrenCount).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete product category."})
+
+			return
+		}
+
+		if childrenCount > 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete product category: there are subcategories in it."})
+
+			return
+		}
+
+		if err = config.DB.WithContext(context).Delete(&productCategory).Error; err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete product category."})
 
 			return
@@ -158,3 +256,239 @@ func DeleteProductCategory(context *gin.Context) {
 		context.JSON(http.StatusOK, gin.H{"message": "Product category deleted successfully."})
 	}
 }
+
+// GetDeletedProductsCategories godoc
+// @Description Récupérer les catégories de produits supprimées, page par page, éventuellement recherchées et triées
+// @Tags ProductsCategories
+// @Produce json
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, name), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Success 200 {object} models.ListOutput[models.ProductCategory]
+// @Failure 400 {object} map[string]string "Paramètres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/deleted [get]
+func GetDeletedProductsCategories(context *gin.Context) {
+	query := models.DeletedQuery(context, &models.ProductCategory{}, "product_categories")
+
+	if productCategories, ok := models.FindList[models.ProductCategory](context, query, models.ProductCategoryListOptions); ok {
+		context.JSON(http.StatusOK, productCategories)
+	}
+}
+
+// PostProductCategoryRestore godoc
+// @Description Restaurer une catégorie de produit supprimée
+// @Tags ProductsCategories
+// @Produce json
+// @Param id path int true "ID de la catégorie de produit"
+// @Success 200 {object} models.ProductCategory
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Catégorie de produit supprimée non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/{id}/restore [post]
+func PostProductCategoryRestore(context *gin.Context) {
+	productCategory, err := models.FindDeletedByContext[models.ProductCategory](context, "product category")
+
+	if err == nil {
+		if !models.ValidateProductCategoryRestore(context, productCategory) {
+			return
+		}
+
+		if err := models.Restore(context, productCategory); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to restore product category."})
+
+			return
+		}
+
+		if productCategory, err = models.FindProductCategoryById(context, productCategory.ID, true); err == nil {
+			context.JSON(http.StatusOK, productCategory)
+		}
+	}
+}
+
+// DeleteProductCategoryPurge godoc
+// @Description Supprimer définitivement une catégorie de produit supprimée, avec ses créneaux
+// @Tags ProductsCategories
+// @Produce json
+// @Param id path int true "ID de la catégorie de produit"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide ou catégorie ayant des produits ou des sous-catégories, même supprimés"
+// @Failure 404 {object} map[string]string "Catégorie de produit supprimée non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/{id}/purge [delete]
+func DeleteProductCategoryPurge(context *gin.Context) {
+	productCategory, err := models.FindDeletedByContext[models.ProductCategory](context, "product category")
+
+	if err == nil {
+		if !models.ValidatePurge(context, &models.Product{}, "category_id", productCategory.ID, "Cannot purge product category: there are products associated with it.") {
+			return
+		}
+
+		if !models.ValidatePurge(context, &models.ProductCategory{}, "parent_id", productCategory.ID, "Cannot purge product category: there are subcategories in it.") {
+			return
+		}
+
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("product_category_id = ?", productCategory.ID).Delete(&models.AvailabilitySlot{}).Error; err != nil {
+				return err
+			}
+
+			return tx.Unscoped().Delete(productCategory).Error
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to purge product category."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Product category purged successfully."})
+	}
+}
+
+// PutProductCategorySchedule godoc
+// @Description Définir les créneaux de disponibilité d'une catégorie de produits, appliqués à ses produits (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure
+// @Tags ProductsCategories
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la catégorie"
+// @Param input body models.AvailabilityScheduleInput true "Créneaux de disponibilité"
+// @Success 200 {object} models.ProductCategory
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Catégorie non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/{id}/schedule [put]
+func PutProductCategorySchedule(context *gin.Context) {
+	productCategory, err := models.FindProductCategoryByContext(context)
+
+	if err == nil {
+		var input models.AvailabilityScheduleInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if _, ok := models.ReplaceAvailabilitySchedule(context, "product_category_id", productCategory.ID, input); !ok {
+			return
+		}
+
+		if productCategory, err = models.FindProductCategoryById(context, productCategory.ID, true); err == nil {
+			context.JSON(http.StatusOK, productCategory)
+		}
+	}
+}
+
+// PutProductCategoriesReorder godoc
+// @Description Réordonner les catégories de premier niveau, ou les sous-catégories d'une catégorie, en les listant toutes dans leur ordre d'affichage
+// @Tags ProductsCategories
+// @Accept json
+// @Produce json
+// @Param input body models.ProductCategoryReorderInput true "Catégorie parente, absente pour le premier niveau, et IDs des catégories dans l'ordre"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "Données invalides ou liste incomplète"
+// @Failure 404 {object} map[string]string "Catégorie parente non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/reorder [put]
+func PutProductCategoriesReorder(context *gin.Context) {
+	var input models.ProductCategoryReorderInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	query := config.DB.WithContext(context).Model(&models.ProductCategory{})
+	if input.ParentID == nil || *input.ParentID == 0 {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		if _, err := models.FindProductCategoryById(context, *input.ParentID, false); err != nil {
+			return
+		}
+
+		query = query.Where("parent_id = ?", *input.ParentID)
+	}
+
+	var currentIDs []uint
+	if err := query.Pluck("id", &currentIDs).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch product categories."})
+
+		return
+	}
+
+	if !models.ValidateReorder(context, input.IDs, currentIDs) {
+		return
+	}
+
+	err := config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+		return models.ApplyPositions(tx, &models.ProductCategory{}, input.IDs)
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to reorder product categories."})
+
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": "Product categories reordered successfully."})
+}
+
+// PutProductCategoryProductsReorder godoc
+// @Description Réordonner les produits d'une catégorie, en les listant tous dans leur ordre d'affichage
+// @Tags ProductsCategories
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de la catégorie de produit"
+// @Param input body models.ReorderInput true "IDs des produits dans l'ordre"
+// @Success 200 {object} models.ProductCategory
+// @Failure 400 {object} map[string]string "Données invalides ou liste incomplète"
+// @Failure 404 {object} map[string]string "Catégorie de produit non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/categories/{id}/products/reorder [put]
+func PutProductCategoryProductsReorder(context *gin.Context) {
+	productCategory, err := models.FindProductCategoryByContext(context)
+
+	if err == nil {
+		var input models.ReorderInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		currentIDs := make([]uint, 0, len(productCategory.Products))
+		for _, product := range productCategory.Products {
+			currentIDs = append(currentIDs, product.ID)
+		}
+
+		if !models.ValidateReorder(context, input.IDs, currentIDs) {
+			return
+		}
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			return models.ApplyPositions(tx, &models.Product{}, input.IDs)
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to reorder products."})
+
+			return
+		}
+
+		if productCategory, err = models.FindProductCategoryById(context, productCategory.ID, true); err == nil {
+			context.JSON(http.StatusOK, productCategory)
+		}
+	}
+}
+
+func sameParent(current *uint, next *uint) bool {
+	if current == nil || next == nil {
+		return current == next
+	}
+
+	return *current == *next
+}
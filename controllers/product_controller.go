@@ -2,29 +2,51 @@ package controllers
 
 import (
 	"net/http"
+	"time"
 	"wacdo/config"
+	"wacdo/middlewares"
 	"wacdo/models"
 	"wacdo/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetProducts godoc
-// @Description Récupérer tous les produits
+// @Description Récupérer les produits, page par page, éventuellement filtrés, recherchés et triés
 // @Tags Products
 // @Produce json
-// @Success 200 {array} models.Product
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, name, price), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Param categoryID query int false "ID de la catégorie"
+// @Param available query bool false "Disponibilité dans le restaurant"
+// @Param minPrice query number false "Prix minimum"
+// @Param maxPrice query number false "Prix maximum"
+// @Param excludeAllergens query string false "Allergènes à exclure, séparés par des virgules (ex : gluten,milk)"
+// @Param vegetarian query bool false "Uniquement les produits végétariens"
+// @Success 200 {object} models.ListOutput[models.Product]
+// @Failure 400 {object} map[string]string "Filtres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
 // @Router /products [get]
 func GetProducts(context *gin.Context) {
-	var products []models.Product
+	dietaryFilters, ok := models.ParseDietaryFilters(context)
+	if !ok {
+		return
+	}
 
-	if err := config.DB.Preload("Category").Find(&products).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch products."})
+	catalogFilters, ok := models.ParseCatalogFilters(context)
+	if !ok {
 		return
 	}
 
-	context.JSON(http.StatusOK, products)
+	query := catalogFilters.ApplyToProducts(context, dietaryFilters.ApplyToProducts(config.DB.WithContext(context).Model(&models.Product{})))
+
+	if products, ok := models.FindList[models.Product](context, query, models.ProductListOptions); ok {
+		context.JSON(http.StatusOK, products)
+	}
 }
 
 // GetProduct godoc
@@ -70,12 +92,40 @@ func PostProduct(context *gin.Context) {
 		return
 	}
 
+	kitchenStationID, ok := models.FindOptionalKitchenStationId(context, input.KitchenStationID)
+	if !ok {
+		return
+	}
+
+	if !models.ValidateAllergens(context, input.Allergens) {
+		return
+	}
+
+	translations, ok := models.ValidateTranslations(context, input.Translations)
+	if !ok {
+		return
+	}
+
 	product := models.Product{
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       input.Price,
-		IsAvailable: input.IsAvailable,
-		Category:    *productCategory,
+		Name:             input.Name,
+		Description:      input.Description,
+		Translations:     translations,
+		Price:            input.Price,
+		LoyaltyPoints:    input.LoyaltyPoints,
+		IsAvailable:      input.IsAvailable,
+		Category:         *productCategory,
+		KitchenStationID: kitchenStationID,
+		Allergens:        input.Allergens,
+		IsVegetarian:     input.IsVegetarian,
+		Nutrition:        input.Nutrition.ToNutrition(),
+	}
+
+	if input.Position != nil {
+		product.Position = *input.Position
+	} else if product.Position, err = models.NextProductPosition(config.DB.WithContext(context), productCategory.ID); err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create product."})
+
+		return
 	}
 
 	if input.Image != "" {
@@ -87,7 +137,14 @@ func PostProduct(context *gin.Context) {
 		product.Image = *image
 	}
 
-	if err := config.DB.Create(&product).Error; err != nil {
+	err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&product).Error; err != nil {
+			return err
+		}
+
+		return models.RecordPriceChange(tx, &product, 0, product.Price, *middlewares.GetUserId(context), time.Now())
+	})
+	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create product."})
 
 		return
@@ -134,16 +191,72 @@ func PutProduct(context *gin.Context) {
 			updates["price"] = *input.Price
 		}
 
+		if input.Translations != nil {
+			translations, ok := models.ValidateTranslations(context, *input.Translations)
+			if !ok {
+				return
+			}
+
+			updates["translations"] = translations
+		}
+
+		if input.LoyaltyPoints != nil {
+			updates["loyaltyPoints"] = *input.LoyaltyPoints
+		}
+
 		if input.IsAvailable != nil {
 			updates["isAvailable"] = *input.IsAvailable
 		}
 
+		if input.KitchenStationID != nil {
+			kitchenStationID, ok := models.FindOptionalKitchenStationId(context, input.KitchenStationID)
+			if !ok {
+				return
+			}
+
+			updates["kitchenStationID"] = kitchenStationID
+		}
+
+		if input.Allergens != nil {
+			if !models.ValidateAllergens(context, *input.Allergens) {
+				return
+			}
+
+			updates["allergens"] = *input.Allergens
+		}
+
+		if input.IsVegetarian != nil {
+			updates["isVegetarian"] = *input.IsVegetarian
+		}
+
+		if input.Nutrition != nil {
+			for column, value := range input.Nutrition.ToNutrition().Columns("nutrition_") {
+				updates[column] = value
+			}
+		}
+
 		var productCategory *models.ProductCategory
 		if input.CategoryID != nil {
 			productCategory, _ = models.FindProductCategoryById(context, *input.CategoryID, false)
 			if productCategory == nil {
 				return
 			}
+
+			// Moved to the end of its new category, unless given a position.
+			if productCategory.ID != product.CategoryID && input.Position == nil {
+				position, err := models.NextProductPosition(config.DB.WithContext(context), productCategory.ID)
+				if err != nil {
+					context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product."})
+
+					return
+				}
+
+				updates["position"] = position
+			}
+		}
+
+		if input.Position != nil {
+			updates["position"] = *input.Position
 		}
 
 		if input.Image != nil {
@@ -161,14 +274,27 @@ func PutProduct(context *gin.Context) {
 			return
 		}
 
-		if err := config.DB.Model(&product).Updates(updates).Error; err != nil {
+		previousPrice := product.Price
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&product).Updates(updates).Error; err != nil {
+				return err
+			}
+
+			if input.Price != nil && *input.Price != previousPrice {
+				return models.RecordPriceChange(tx, product, previousPrice, *input.Price, *middlewares.GetUserId(context), time.Now())
+			}
+
+			return nil
+		})
+		if err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product."})
 
 			return
 		}
 
 		if productCategory != nil {
-			if err := config.DB.Model(&product).Association("Category").Replace(productCategory); err != nil {
+			if err := config.DB.WithContext(context).Model(&product).Association("Category").Replace(productCategory); err != nil {
 				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product category."})
 
 				return
@@ -180,7 +306,7 @@ func PutProduct(context *gin.Context) {
 }
 
 // DeleteProduct godoc
-// @Description Supprimer un produit
+// @Description Supprimer un produit, qui peut ensuite être restauré ou supprimé définitivement
 // @Tags Products
 // @Produce json
 // @Param id path int true "ID du produit"
@@ -200,7 +326,7 @@ func DeleteProduct(context *gin.Context) {
 			return
 		}
 
-		if err = config.DB.Delete(&product).Error; err != nil {
+		if err = config.DB.WithContext(context).Delete(&product).Error; err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete product."})
 
 			return
@@ -209,3 +335,264 @@ func DeleteProduct(context *gin.Context) {
 		context.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully."})
 	}
 }
+
+// GetDeletedProducts godoc
+// @Description Récupérer les produits supprimés, page par page, éventuellement recherchés et triés
+// @Tags Products
+// @Produce json
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, name, price), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans le nom et la description"
+// @Success 200 {object} models.ListOutput[models.Product]
+// @Failure 400 {object} map[string]string "Paramètres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/deleted [get]
+func GetDeletedProducts(context *gin.Context) {
+	query := models.DeletedQuery(context, &models.Product{}, "products")
+
+	if products, ok := models.FindList[models.Product](context, query, models.ProductListOptions); ok {
+		context.JSON(http.StatusOK, products)
+	}
+}
+
+// PostProductRestore godoc
+// @Description Restaurer un produit supprimé
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string "ID invalide ou catégorie du produit supprimée"
+// @Failure 404 {object} map[string]string "Produit supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/restore [post]
+func PostProductRestore(context *gin.Context) {
+	product, err := models.FindDeletedByContext[models.Product](context, "product")
+
+	if err == nil {
+		if !models.ValidateProductRestore(context, product) {
+			return
+		}
+
+		if err := models.Restore(context, product); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to restore product."})
+
+			return
+		}
+
+		if product, err = models.FindProductById(context, product.ID); err == nil {
+			context.JSON(http.StatusOK, product)
+		}
+	}
+}
+
+// DeleteProductPurge godoc
+// @Description Supprimer définitivement un produit supprimé, avec ses variantes, ses disponibilités, ses créneaux, son historique de prix, ses changements de prix programmés et sa présence dans les menus supprimés
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide ou produit présent dans des commandes"
+// @Failure 404 {object} map[string]string "Produit supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/purge [delete]
+func DeleteProductPurge(context *gin.Context) {
+	product, err := models.FindDeletedByContext[models.Product](context, "product")
+
+	if err == nil {
+		if !models.ValidatePurge(context, &models.OrderItem{}, "product_id", product.ID, "Cannot purge product: there are orders associated with it.") {
+			return
+		}
+
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			return models.PurgeProduct(tx, product)
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to purge product."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Product purged successfully."})
+	}
+}
+
+// PutProductAvailability godoc
+// @Description Définir la disponibilité d'un produit dans le restaurant courant
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param input body models.CatalogAvailabilityInput true "Disponibilité"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/availability [put]
+func PutProductAvailability(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		var input models.CatalogAvailabilityInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if err := models.SaveCatalogAvailability(context, "product_id", product.ID, *input.IsAvailable); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product availability."})
+
+			return
+		}
+
+		product.IsAvailable = *input.IsAvailable
+
+		context.JSON(http.StatusOK, product)
+	}
+}
+
+// GetProductPrices godoc
+// @Description Récupérer l'historique des prix d'un produit et ses changements de prix programmés
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Success 200 {object} models.PriceHistoryOutput
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Produit non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/prices [get]
+func GetProductPrices(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		history, err := models.FindPriceHistory(context, product)
+
+		if err == nil {
+			context.JSON(http.StatusOK, history)
+		}
+	}
+}
+
+// GetProductPrice godoc
+// @Description Récupérer le prix d'un produit en vigueur à une date, changements programmés compris
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param at query string false "Date au format RFC 3339 (maintenant par défaut)"
+// @Success 200 {object} models.PriceAtOutput
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit non trouvé ou aucun prix à cette date"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/price [get]
+func GetProductPrice(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		price, err := models.FindPriceAt(context, product, time.Now())
+
+		if err == nil {
+			context.JSON(http.StatusOK, price)
+		}
+	}
+}
+
+// PostProductPrice godoc
+// @Description Programmer un changement de prix d'un produit, appliqué automatiquement à la date indiquée
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param input body models.PriceChangeInsertInput true "Prix et date d'application"
+// @Success 201 {object} models.PriceChange
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/prices [post]
+func PostProductPrice(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		var input models.PriceChangeInsertInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if change, ok := models.SchedulePriceChange(context, product, input, *middlewares.GetUserId(context), time.Now()); ok {
+			context.JSON(http.StatusCreated, change)
+		}
+	}
+}
+
+// DeleteProductPrice godoc
+// @Description Annuler un changement de prix programmé d'un produit
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param priceChangeID path int true "ID du changement de prix"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Produit non trouvé ou changement de prix programmé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/prices/{priceChangeID} [delete]
+func DeleteProductPrice(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		change, err := models.FindScheduledPriceChangeByContext(context, product)
+
+		if err == nil {
+			if err := config.DB.WithContext(context).Delete(change).Error; err != nil {
+				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to cancel price change."})
+
+				return
+			}
+
+			context.JSON(http.StatusOK, gin.H{"message": "Price change cancelled successfully."})
+		}
+	}
+}
+
+// PutProductSchedule godoc
+// @Description Définir les créneaux de disponibilité d'un produit (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param input body models.AvailabilityScheduleInput true "Créneaux de disponibilité"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/schedule [put]
+func PutProductSchedule(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		var input models.AvailabilityScheduleInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if _, ok := models.ReplaceAvailabilitySchedule(context, "product_id", product.ID, input); !ok {
+			return
+		}
+
+		if product, err = models.FindProductById(context, product.ID); err == nil {
+			context.JSON(http.StatusOK, product)
+		}
+	}
+}
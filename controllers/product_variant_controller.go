@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"net/http"
+	"wacdo/config"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostProductVariant godoc
+// @Description Créer une variante d'un produit (par exemple une taille), avec son prix, sa disponibilité, son SKU et son supplément dans les menus
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param input body models.ProductVariantInsertInput true "Données de la variante"
+// @Success 201 {object} models.ProductVariant
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit non trouvé"
+// @Failure 409 {object} map[string]string "SKU déjà utilisé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/variants [post]
+func PostProductVariant(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		var input models.ProductVariantInsertInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		sku, ok := models.ValidateVariantSKU(context, input.SKU, 0)
+		if !ok {
+			return
+		}
+
+		variant := models.ProductVariant{
+			ProductID:      product.ID,
+			Name:           input.Name,
+			SKU:            sku,
+			Price:          input.Price,
+			MenuSupplement: input.MenuSupplement,
+			IsAvailable:    input.IsAvailable == nil || *input.IsAvailable,
+		}
+
+		if err := config.DB.WithContext(context).Create(&variant).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create product variant."})
+
+			return
+		}
+
+		context.JSON(http.StatusCreated, variant)
+	}
+}
+
+// PutProductVariant godoc
+// @Description Mettre à jour une variante d'un produit
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param variantID path int true "ID de la variante"
+// @Param input body models.ProductVariantUpdateInput true "Données de mise à jour"
+// @Success 200 {object} models.ProductVariant
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Produit ou variante non trouvé"
+// @Failure 409 {object} map[string]string "SKU déjà utilisé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/variants/{variantID} [put]
+func PutProductVariant(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		variant, err := models.FindProductVariantByContext(context, product)
+
+		if err == nil {
+			var input models.ProductVariantUpdateInput
+			if err = context.ShouldBindJSON(&input); err != nil {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+				return
+			}
+
+			updates := make(map[string]interface{})
+
+			if input.Name != nil {
+				updates["name"] = *input.Name
+			}
+
+			if input.SKU != nil {
+				sku, ok := models.ValidateVariantSKU(context, *input.SKU, variant.ID)
+				if !ok {
+					return
+				}
+
+				updates["sku"] = sku
+			}
+
+			if input.Price != nil {
+				updates["price"] = *input.Price
+			}
+
+			if input.MenuSupplement != nil {
+				updates["menuSupplement"] = *input.MenuSupplement
+			}
+
+			if input.IsAvailable != nil {
+				updates["isAvailable"] = *input.IsAvailable
+			}
+
+			if len(updates) == 0 {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+
+				return
+			}
+
+			if err := config.DB.WithContext(context).Model(&variant).Updates(updates).Error; err != nil {
+				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update product variant."})
+
+				return
+			}
+
+			context.JSON(http.StatusOK, variant)
+		}
+	}
+}
+
+// DeleteProductVariant godoc
+// @Description Supprimer une variante d'un produit
+// @Tags Products
+// @Produce json
+// @Param id path int true "ID du produit"
+// @Param variantID path int true "ID de la variante"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Produit ou variante non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /products/{id}/variants/{variantID} [delete]
+func DeleteProductVariant(context *gin.Context) {
+	product, err := models.FindProductByContext(context)
+
+	if err == nil {
+		variant, err := models.FindProductVariantByContext(context, product)
+
+		if err == nil {
+			if err = config.DB.WithContext(context).Delete(&variant).Error; err != nil {
+				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete product variant."})
+
+				return
+			}
+
+			context.JSON(http.StatusOK, gin.H{"message": "Product variant deleted successfully."})
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetRestaurants godoc
+// @Description Récupérer les restaurants accessibles à l'utilisateur connecté
+// @Tags Restaurants
+// @Produce json
+// @Success 200 {array} models.Restaurant
+// @Security BearerAuth
+// @Router /restaurants [get]
+func GetRestaurants(context *gin.Context) {
+	var restaurants []models.Restaurant
+
+	if err := config.DB.WithContext(context).Find(&restaurants, models.GetRestaurantIds(context)).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch restaurants."})
+		return
+	}
+
+	context.JSON(http.StatusOK, restaurants)
+}
+
+// GetRestaurant godoc
+// @Description Récupérer un restaurant par son ID
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Success 200 {object} models.Restaurant
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id} [get]
+func GetRestaurant(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		context.JSON(http.StatusOK, restaurant)
+	}
+}
+
+// PostRestaurant godoc
+// @Description Créer un restaurant, géré ensuite par l'administrateur qui l'a créé
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param input body models.RestaurantInsertInput true "Données du restaurant"
+// @Success 201 {object} models.Restaurant
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants [post]
+func PostRestaurant(context *gin.Context) {
+	var input models.RestaurantInsertInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	if input.TimeZone == "" {
+		input.TimeZone = models.DefaultTimeZone
+	}
+
+	if !models.ValidateTimeZone(context, input.TimeZone) {
+		return
+	}
+
+	restaurant := models.Restaurant{
+		Name:            input.Name,
+		Address:         input.Address,
+		TimeZone:        input.TimeZone,
+		LastOrderCutoff: input.LastOrderCutoff,
+	}
+
+	if err := config.DB.WithContext(context).Create(&restaurant).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create restaurant."})
+
+		return
+	}
+
+	user := models.User{ID: *middlewares.GetUserId(context)}
+	if err := config.DB.WithContext(context).Model(&user).Association("Restaurants").Append(&restaurant); err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to add restaurant to user."})
+
+		return
+	}
+
+	context.JSON(http.StatusCreated, restaurant)
+}
+
+// PutRestaurant godoc
+// @Description Mettre à jour un restaurant existant
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Param input body models.RestaurantUpdateInput true "Données de mise à jour"
+// @Success 200 {object} models.Restaurant
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id} [put]
+func PutRestaurant(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		var input models.RestaurantUpdateInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		updates := make(map[string]interface{})
+
+		if input.Name != nil {
+			updates["name"] = *input.Name
+		}
+
+		if input.Address != nil {
+			updates["address"] = *input.Address
+		}
+
+		if input.TimeZone != nil {
+			if !models.ValidateTimeZone(context, *input.TimeZone) {
+				return
+			}
+
+			updates["timeZone"] = *input.TimeZone
+		}
+
+		if input.LastOrderCutoff != nil {
+			updates["lastOrderCutoff"] = *input.LastOrderCutoff
+		}
+
+		if len(updates) == 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+
+			return
+		}
+
+		if err := config.DB.WithContext(context).Model(&restaurant).Updates(updates).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update restaurant."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, restaurant)
+	}
+}
+
+// GetRestaurantStatus godoc
+// @Description Savoir si un restaurant est ouvert et accepte les commandes (accessible sans authentification, pour les bornes et l'écran client)
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Success 200 {object} models.RestaurantStatusOutput
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Router /restaurants/{id}/status [get]
+func GetRestaurantStatus(context *gin.Context) {
+	id, err := strconv.Atoi(context.Param("id"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return
+	}
+
+	schedule, err := models.LoadRestaurantSchedule(config.DB, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Restaurant not found."})
+
+			return
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch restaurant."})
+
+		return
+	}
+
+	context.JSON(http.StatusOK, schedule.Status(time.Now()))
+}
+
+// GetRestaurantOpeningHours godoc
+// @Description Récupérer les horaires d'ouverture d'un restaurant
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Success 200 {array} models.OpeningHour
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id}/opening-hours [get]
+func GetRestaurantOpeningHours(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		var openingHours []models.OpeningHour
+
+		if err := config.DB.WithContext(context).Where("restaurant_id = ?", restaurant.ID).Order("day_of_week, opens_at").Find(&openingHours).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch opening hours."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, openingHours)
+	}
+}
+
+// PutRestaurantOpeningHours godoc
+// @Description Remplacer les horaires d'ouverture d'un restaurant (jour de la semaine de 0 pour dimanche à 6 pour samedi, heures au format HH:MM ; une fermeture avant l'ouverture signifie une fermeture après minuit)
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Param input body []models.OpeningHourInput true "Horaires d'ouverture"
+// @Success 200 {array} models.OpeningHour
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id}/opening-hours [put]
+func PutRestaurantOpeningHours(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		var input []models.OpeningHourInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		openingHours := make([]models.OpeningHour, 0, len(input))
+		for _, openingHourInput := range input {
+			openingHour, err := openingHourInput.ToOpeningHour(restaurant.ID)
+			if err != nil {
+				context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+				return
+			}
+
+			openingHours = append(openingHours, openingHour)
+		}
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("restaurant_id = ?", restaurant.ID).Delete(&models.OpeningHour{}).Error; err != nil {
+				return err
+			}
+
+			if len(openingHours) == 0 {
+				return nil
+			}
+
+			return tx.Create(&openingHours).Error
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update opening hours."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, openingHours)
+	}
+}
+
+// GetRestaurantClosures godoc
+// @Description Récupérer les fermetures exceptionnelles d'un restaurant
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Success 200 {array} models.Closure
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id}/closures [get]
+func GetRestaurantClosures(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		var closures []models.Closure
+
+		if err := config.DB.WithContext(context).Where("restaurant_id = ?", restaurant.ID).Order("starts_at").Find(&closures).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch closures."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, closures)
+	}
+}
+
+// PostRestaurantClosure godoc
+// @Description Ajouter une fermeture exceptionnelle à un restaurant
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Param input body models.ClosureInsertInput true "Fermeture exceptionnelle"
+// @Success 201 {object} models.Closure
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Restaurant non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id}/closures [post]
+func PostRestaurantClosure(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		var input models.ClosureInsertInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		if !input.EndsAt.After(input.StartsAt) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Closure must end after it starts."})
+
+			return
+		}
+
+		closure := models.Closure{
+			RestaurantID: restaurant.ID,
+			StartsAt:     input.StartsAt,
+			EndsAt:       input.EndsAt,
+			Reason:       input.Reason,
+		}
+
+		if err := config.DB.WithContext(context).Create(&closure).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create closure."})
+
+			return
+		}
+
+		context.JSON(http.StatusCreated, closure)
+	}
+}
+
+// DeleteRestaurantClosure godoc
+// @Description Supprimer une fermeture exceptionnelle d'un restaurant
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID du restaurant"
+// @Param closureId path int true "ID de la fermeture"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Fermeture non trouvée"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /restaurants/{id}/closures/{closureId} [delete]
+func DeleteRestaurantClosure(context *gin.Context) {
+	restaurant, err := models.FindRestaurantByContext(context)
+
+	if err == nil {
+		closureID, err := strconv.Atoi(context.Param("closureId"))
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+			return
+		}
+
+		result := config.DB.WithContext(context).Where("restaurant_id = ?", restaurant.ID).Delete(&models.Closure{}, closureID)
+		if result.Error != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete closure."})
+
+			return
+		}
+
+		if result.RowsAffected == 0 {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Closure not found."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Closure deleted successfully."})
+	}
+}
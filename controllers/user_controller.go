@@ -8,24 +8,39 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // GetUsers godoc
-// @Description Récupérer tous les utilisateurs
+// @Description Récupérer les utilisateurs, page par page, éventuellement filtrés par rôle, recherchés et triés
 // @Tags Users
 // @Produce json
-// @Success 200 {array} models.User
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, email, role), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans l'email"
+// @Param role query string false "Rôle des utilisateurs"
+// @Success 200 {object} models.ListOutput[models.UserOutput]
+// @Failure 400 {object} map[string]string "Filtres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
 // @Security BearerAuth
 // @Router /users [get]
 func GetUsers(context *gin.Context) {
-	var users []models.User
+	query := config.DB.WithContext(context).Model(&models.User{})
 
-	if err := config.DB.Find(&users).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch users."})
-		return
+	if role := models.UserRole(context.Query("role")); role != "" {
+		if !role.IsValid() {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role filter."})
+
+			return
+		}
+
+		query = query.Where("users.role = ?", role)
 	}
 
-	context.JSON(http.StatusOK, models.TransformUsersToOutput(users))
+	if users, ok := models.FindList[models.User](context, query, models.UserListOptions); ok {
+		context.JSON(http.StatusOK, models.TransformList(users, models.TransformUsersToOutput))
+	}
 }
 
 // GetUser godoc
@@ -67,10 +82,17 @@ func PostUser(context *gin.Context) {
 		return
 	}
 
-	var count int64
-	config.DB.Model(&models.User{}).Where("email = ?", input.Email).Count(&count)
+	// A deleted user keeps its email until it is purged.
+	var users []models.User
+	config.DB.Unscoped().Where("email = ?", input.Email).Limit(1).Find(&users)
+
+	if len(users) > 0 {
+		if users[0].DeletedAt.Valid {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Email already used by a deleted user."})
+
+			return
+		}
 
-	if count > 0 {
 		context.JSON(http.StatusBadRequest, gin.H{"error": "Email already used."})
 
 		return
@@ -88,6 +110,11 @@ func PostUser(context *gin.Context) {
 		return
 	}
 
+	restaurants, ok := models.FindManagedRestaurants(context, input.Role, input.RestaurantIDs)
+	if !ok {
+		return
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to hash password."})
@@ -96,12 +123,13 @@ func PostUser(context *gin.Context) {
 	}
 
 	user := models.User{
-		Email:    input.Email,
-		Password: string(hashedPassword),
-		Role:     input.Role,
+		Email:       input.Email,
+		Password:    string(hashedPassword),
+		Role:        input.Role,
+		Restaurants: restaurants,
 	}
 
-	if err := config.DB.Create(&user).Error; err != nil {
+	if err := config.DB.WithContext(context).Create(&user).Error; err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create user."})
 		return
 	}
@@ -174,24 +202,47 @@ func PutUser(context *gin.Context) {
 			updates["password"] = string(hashedPassword)
 		}
 
-		if len(updates) == 0 {
+		var restaurants []models.Restaurant
+		if input.RestaurantIDs != nil {
+			role := user.Role
+			if input.Role != nil {
+				role = *input.Role
+			}
+
+			managedRestaurants, ok := models.FindManagedRestaurants(context, role, *input.RestaurantIDs)
+			if !ok {
+				return
+			}
+
+			restaurants = managedRestaurants
+		}
+
+		if len(updates) == 0 && input.RestaurantIDs == nil {
 			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
 
 			return
 		}
 
-		if err := config.DB.Model(&user).Updates(updates).Error; err != nil {
+		if err := config.DB.WithContext(context).Model(&user).Updates(updates).Error; err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update user."})
 
 			return
 		}
 
+		if input.RestaurantIDs != nil {
+			if err := config.DB.WithContext(context).Model(&user).Association("Restaurants").Replace(restaurants); err != nil {
+				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update user restaurants."})
+
+				return
+			}
+		}
+
 		context.JSON(http.StatusOK, models.TransformUserToOutput(user))
 	}
 }
 
 // DeleteUser godoc
-// @Description Supprimer un utilisateur
+// @Description Supprimer un utilisateur, qui ne peut plus se connecter, et qui peut ensuite être restauré ou supprimé définitivement
 // @Tags Users
 // @Param id path int true "ID de l'utilisateur"
 // @Success 204 "Pas de contenu"
@@ -203,7 +254,7 @@ func PutUser(context *gin.Context) {
 func DeleteUser(context *gin.Context) {
 	user, err := models.FindUserByContext(context)
 	if err == nil {
-		if err := config.DB.Delete(&user).Error; err != nil {
+		if err := config.DB.WithContext(context).Delete(&user).Error; err != nil {
 			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete user."})
 			return
 		}
@@ -211,3 +262,85 @@ func DeleteUser(context *gin.Context) {
 		context.JSON(http.StatusOK, gin.H{"message": "User deleted successfully."})
 	}
 }
+
+// GetDeletedUsers godoc
+// @Description Récupérer les utilisateurs supprimés, page par page, éventuellement filtrés, recherchés et triés
+// @Tags Users
+// @Produce json
+// @Param limit query int false "Nombre d'éléments par page (50 par défaut, 100 au maximum)"
+// @Param cursor query string false "Curseur de la page suivante, renvoyé par la page précédente"
+// @Param sort query string false "Tri (id, email, role), précédé de - pour un tri décroissant"
+// @Param search query string false "Texte recherché dans l'email"
+// @Success 200 {object} models.ListOutput[models.UserOutput]
+// @Failure 400 {object} map[string]string "Paramètres invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /users/deleted [get]
+func GetDeletedUsers(context *gin.Context) {
+	query := models.DeletedQuery(context, &models.User{}, "users")
+
+	if users, ok := models.FindList[models.User](context, query, models.UserListOptions); ok {
+		context.JSON(http.StatusOK, models.TransformList(users, models.TransformUsersToOutput))
+	}
+}
+
+// PostUserRestore godoc
+// @Description Restaurer un utilisateur supprimé
+// @Tags Users
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Success 200 {object} models.UserOutput
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Utilisateur supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /users/{id}/restore [post]
+func PostUserRestore(context *gin.Context) {
+	user, err := models.FindDeletedByContext[models.User](context, "user")
+
+	if err == nil {
+		if err := models.Restore(context, user); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to restore user."})
+			return
+		}
+
+		if user, err = models.FindUserById(context, user.ID); err == nil {
+			context.JSON(http.StatusOK, models.TransformUserToOutput(user))
+		}
+	}
+}
+
+// DeleteUserPurge godoc
+// @Description Supprimer définitivement un utilisateur supprimé, qui n'a pris aucune commande
+// @Tags Users
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide ou utilisateur ayant pris des commandes"
+// @Failure 404 {object} map[string]string "Utilisateur supprimé non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /users/{id}/purge [delete]
+func DeleteUserPurge(context *gin.Context) {
+	user, err := models.FindDeletedByContext[models.User](context, "user")
+
+	if err == nil {
+		if !models.ValidatePurge(context, &models.Order{}, "user_id", user.ID, "Cannot purge user: there are orders associated with it.") {
+			return
+		}
+
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(user).Association("Restaurants").Clear(); err != nil {
+				return err
+			}
+
+			return tx.Unscoped().Delete(user).Error
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to purge user."})
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "User purged successfully."})
+	}
+}
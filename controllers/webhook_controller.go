@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetWebhooks godoc
+// @Description Récupérer tous les webhooks
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {array} models.Webhook
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks [get]
+func GetWebhooks(context *gin.Context) {
+	var webhooks []models.Webhook
+
+	if err := config.DB.WithContext(context).Order("id").Find(&webhooks).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhooks."})
+		return
+	}
+
+	context.JSON(http.StatusOK, webhooks)
+}
+
+// GetWebhook godoc
+// @Description Récupérer un webhook par son ID
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "ID du webhook"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Webhook non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks/{id} [get]
+func GetWebhook(context *gin.Context) {
+	webhook, err := models.FindWebhookByContext(context)
+
+	if err == nil {
+		context.JSON(http.StatusOK, webhook)
+	}
+}
+
+// GetWebhookDeliveries godoc
+// @Description Récupérer le journal des envois d'un webhook, du plus récent au plus ancien
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "ID du webhook"
+// @Param status query string false "Etat des envois (pending, succeeded, failed)"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Webhook non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks/{id}/deliveries [get]
+func GetWebhookDeliveries(context *gin.Context) {
+	webhook, err := models.FindWebhookByContext(context)
+
+	if err == nil {
+		deliveries, err := models.FindWebhookDeliveries(context, webhook)
+
+		if err == nil {
+			context.JSON(http.StatusOK, deliveries)
+		}
+	}
+}
+
+// PostWebhook godoc
+// @Description Enregistrer un webhook abonné à des événements des commandes du restaurant, un secret de signature étant généré s'il n'est pas fourni, et renvoyé uniquement à la création
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body models.WebhookInsertInput true "Données du webhook"
+// @Success 201 {object} models.WebhookSecretOutput
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks [post]
+func PostWebhook(context *gin.Context) {
+	var input models.WebhookInsertInput
+	if err := context.ShouldBindJSON(&input); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+		return
+	}
+
+	url, ok := models.ValidateWebhookURL(context, input.URL)
+	if !ok {
+		return
+	}
+
+	events, ok := models.ValidateWebhookEvents(context, input.Events)
+	if !ok {
+		return
+	}
+
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		var err error
+		if secret, err = models.GenerateWebhookSecret(); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create webhook."})
+
+			return
+		}
+	}
+
+	webhook := models.Webhook{
+		URL:         url,
+		Description: input.Description,
+		Secret:      secret,
+		Events:      events,
+		Active:      input.Active == nil || *input.Active,
+	}
+
+	if err := config.DB.WithContext(context).Create(&webhook).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create webhook."})
+
+		return
+	}
+
+	context.JSON(http.StatusCreated, models.WebhookSecretOutput{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// PutWebhook godoc
+// @Description Mettre à jour un webhook existant, notamment ses abonnements ou son activation
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du webhook"
+// @Param input body models.WebhookUpdateInput true "Données de mise à jour"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]string "Données invalides"
+// @Failure 404 {object} map[string]string "Webhook non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks/{id} [put]
+func PutWebhook(context *gin.Context) {
+	webhook, err := models.FindWebhookByContext(context)
+
+	if err == nil {
+		var input models.WebhookUpdateInput
+		if err = context.ShouldBindJSON(&input); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+
+			return
+		}
+
+		updates := make(map[string]interface{})
+
+		if input.URL != nil {
+			url, ok := models.ValidateWebhookURL(context, *input.URL)
+			if !ok {
+				return
+			}
+
+			updates["url"] = url
+		}
+
+		if input.Description != nil {
+			updates["description"] = *input.Description
+		}
+
+		if input.Secret != nil {
+			secret := strings.TrimSpace(*input.Secret)
+			if secret == "" {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Webhook secret cannot be empty."})
+
+				return
+			}
+
+			updates["secret"] = secret
+		}
+
+		if input.Events != nil {
+			events, ok := models.ValidateWebhookEvents(context, *input.Events)
+			if !ok {
+				return
+			}
+
+			updates["events"] = events
+		}
+
+		if input.Active != nil {
+			updates["active"] = *input.Active
+		}
+
+		if len(updates) == 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+
+			return
+		}
+
+		if err := config.DB.WithContext(context).Model(&webhook).Updates(updates).Error; err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update webhook."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, webhook)
+	}
+}
+
+// DeleteWebhook godoc
+// @Description Supprimer un webhook et son journal des envois
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "ID du webhook"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Webhook non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks/{id} [delete]
+func DeleteWebhook(context *gin.Context) {
+	webhook, err := models.FindWebhookByContext(context)
+
+	if err == nil {
+		err = config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(&webhook).Error
+		})
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete webhook."})
+
+			return
+		}
+
+		context.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully."})
+	}
+}
+
+// PostWebhookDeliveryReplay godoc
+// @Description Renvoyer un envoi d'un webhook, remis dans la file d'envoi avec un nouveau nombre de tentatives
+// @Tags Webhooks
+// @Produce json
+// @Param id path int true "ID de l'envoi"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 400 {object} map[string]string "ID invalide"
+// @Failure 404 {object} map[string]string "Envoi non trouvé"
+// @Failure 500 {object} map[string]string "Erreur interne"
+// @Security BearerAuth
+// @Router /webhooks/deliveries/{id}/replay [post]
+func PostWebhookDeliveryReplay(context *gin.Context) {
+	delivery, err := models.FindWebhookDeliveryByContext(context)
+
+	if err == nil {
+		if err := delivery.Replay(config.DB.WithContext(context), time.Now()); err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to replay webhook delivery."})
+
+			return
+		}
+
+		context.JSON(http.StatusAccepted, delivery)
+	}
+}
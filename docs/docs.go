@@ -69,14 +69,139 @@ const docTemplate = `{
                 }
             }
         },
-        "/menus": {
+        "/catalog/export": {
             "get": {
-                "description": "Récupérer tous les menus",
+                "description": "Exporter tout le catalogue (catégories, produits avec leurs variantes, menus) en JSON ou en CSV, chaque élément étant identifié par sa clé externe",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Catalog"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Format de l'export (json, csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Catalog"
+                        }
+                    },
+                    "400": {
+                        "description": "Format invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/catalog/import": {
+            "post": {
+                "description": "Importer un catalogue en JSON ou en CSV dans une seule transaction, en créant ou en mettant à jour chaque élément selon sa clé externe. Rien n'est importé si le catalogue contient une erreur, ni lors d'une simulation",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menus"
+                    "Catalog"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Format du catalogue (json, csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Simulation : valider le catalogue et indiquer ce qui serait importé, sans rien importer",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Catalogue",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Catalog"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Catalogue invalide",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers": {
+            "get": {
+                "description": "Récupérer les clients, éventuellement filtrés par nom, email ou téléphone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom, email ou téléphone recherché",
+                        "name": "search",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -84,7 +209,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Menu"
+                                "$ref": "#/definitions/models.Customer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
@@ -96,7 +230,7 @@ const docTemplate = `{
                 ]
             },
             "post": {
-                "description": "Créer un nouveau menu",
+                "description": "Créer un nouveau client, identifié par son email ou son téléphone",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,16 +238,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Menus"
+                    "Customers"
                 ],
                 "parameters": [
                     {
-                        "description": "Données du menu",
-                        "name": "menu",
+                        "description": "Données du client",
+                        "name": "customer",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MenuInsertInput"
+                            "$ref": "#/definitions/models.CustomerInsertInput"
                         }
                     }
                 ],
@@ -121,7 +255,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Menu"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
                     "400": {
@@ -150,19 +284,19 @@ const docTemplate = `{
                 ]
             }
         },
-        "/menus/{id}": {
+        "/customers/{id}": {
             "get": {
-                "description": "Récupérer un menu par son ID",
+                "description": "Récupérer un client par son ID, avec son solde de points de fidélité",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menus"
+                    "Customers"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du menu",
+                        "description": "ID du client",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -172,7 +306,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Menu"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
                     "400": {
@@ -185,7 +319,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Menu non trouvé",
+                        "description": "Client non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -210,7 +344,7 @@ const docTemplate = `{
                 ]
             },
             "put": {
-                "description": "Mettre à jour un menu existant",
+                "description": "Mettre à jour un client existant, notamment ses consentements",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,12 +352,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Menus"
+                    "Customers"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du menu",
+                        "description": "ID du client",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -234,7 +368,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MenuUpdateInput"
+                            "$ref": "#/definitions/models.CustomerUpdateInput"
                         }
                     }
                 ],
@@ -242,7 +376,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Menu"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
                     "400": {
@@ -255,7 +389,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Produit non trouvé",
+                        "description": "Client non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -280,17 +414,17 @@ const docTemplate = `{
                 ]
             },
             "delete": {
-                "description": "Supprimer un menu",
+                "description": "Supprimer un client et son historique de points de fidélité, ses commandes étant conservées sans client",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menus"
+                    "Customers"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du menu",
+                        "description": "ID du client",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -316,7 +450,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Produit non trouvé",
+                        "description": "Client non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -341,14 +475,23 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders": {
+        "/customers/{id}/loyalty-transactions": {
             "get": {
-                "description": "Récupérer toutes les commandes",
+                "description": "Récupérer l'historique des points de fidélité gagnés et dépensés par un client",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du client",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -356,7 +499,61 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Order"
+                                "$ref": "#/definitions/models.LoyaltyTransaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Client non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/kitchen-stations": {
+            "get": {
+                "description": "Récupérer tous les postes de préparation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "KitchenStations"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.KitchenStation"
                             }
                         }
                     }
@@ -368,7 +565,7 @@ const docTemplate = `{
                 ]
             },
             "post": {
-                "description": "Créer une nouvelle commande",
+                "description": "Créer un nouveau poste de préparation",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,16 +573,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
-                        "description": "Données de la commande",
-                        "name": "order",
+                        "description": "Données du poste de préparation",
+                        "name": "kitchenStation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.OrderInsertInput"
+                            "$ref": "#/definitions/models.KitchenStationInsertInput"
                         }
                     }
                 ],
@@ -393,7 +590,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.KitchenStation"
                         }
                     },
                     "400": {
@@ -422,19 +619,19 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders/{id}": {
-            "get": {
-                "description": "Récupérerer une commande par son ID",
+        "/kitchen-stations/items/{id}/in-preparation": {
+            "patch": {
+                "description": "Indiquer qu'un article est en préparation sur son poste",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la commande",
+                        "description": "ID de l'article à préparer",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -444,11 +641,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.OrderStationItem"
                         }
                     },
                     "400": {
-                        "description": "ID invalide",
+                        "description": "Données invalides",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -457,7 +654,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Commande non trouvée",
+                        "description": "Article non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -480,41 +677,31 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "put": {
-                "description": "Mettre à jour une commande existante",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/kitchen-stations/items/{id}/prepared": {
+            "patch": {
+                "description": "Indiquer qu'un article a été préparé sur son poste (la commande passe à l'état préparé lorsque tous ses articles le sont)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la commande",
+                        "description": "ID de l'article à préparer",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Données de mise à jour",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.OrderUpdateInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.OrderStationItem"
                         }
                     },
                     "400": {
@@ -527,7 +714,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Commande non trouvée",
+                        "description": "Article non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -552,22 +739,19 @@ const docTemplate = `{
                 ]
             }
         },
-        "/orders/{id}/delivered": {
-            "patch": {
-                "description": "Indiquer que la commande a été livrée",
-                "consumes": [
-                    "application/json"
-                ],
+        "/kitchen-stations/{id}": {
+            "get": {
+                "description": "Récupérer un poste de préparation par son ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la commande",
+                        "description": "ID du poste de préparation",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -577,11 +761,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.KitchenStation"
                         }
                     },
                     "400": {
-                        "description": "Données invalides",
+                        "description": "ID invalide",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -590,7 +774,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Commande non trouvée",
+                        "description": "Poste de préparation non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -613,11 +797,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            }
-        },
-        "/orders/{id}/in-preparation": {
-            "patch": {
-                "description": "Indiquer que la commande est en préparation",
+            },
+            "put": {
+                "description": "Mettre à jour un poste de préparation existant",
                 "consumes": [
                     "application/json"
                 ],
@@ -625,22 +807,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la commande",
+                        "description": "ID du poste de préparation",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.KitchenStationUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.KitchenStation"
                         }
                     },
                     "400": {
@@ -653,7 +844,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Commande non trouvée",
+                        "description": "Poste de préparation non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -676,24 +867,82 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            }
-        },
-        "/orders/{id}/prepared": {
-            "patch": {
-                "description": "Indiquer que la commande a été préparée",
-                "consumes": [
+            },
+            "delete": {
+                "description": "Supprimer un poste de préparation",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "KitchenStations"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du poste de préparation",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Poste de préparation non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/kitchen-stations/{id}/queue": {
+            "get": {
+                "description": "Récupérer la file des articles à préparer par un poste, triée par priorité des commandes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "KitchenStations"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la commande",
+                        "description": "ID du poste de préparation",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -703,11 +952,14 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.KitchenTicketOutput"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Données invalides",
+                        "description": "ID invalide",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -716,7 +968,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Commande non trouvée",
+                        "description": "Poste de préparation non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -741,22 +993,93 @@ const docTemplate = `{
                 ]
             }
         },
-        "/products": {
+        "/menus": {
             "get": {
-                "description": "Récupérer tous les produits",
+                "description": "Récupérer les menus, page par page, éventuellement filtrés, recherchés et triés",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Menus"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, name, price), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Disponibilité dans le restaurant",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Prix minimum",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Prix maximum",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Allergènes à exclure, séparés par des virgules (ex : gluten,milk)",
+                        "name": "excludeAllergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Uniquement les menus végétariens",
+                        "name": "vegetarian",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ListOutput-models_Menu"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
@@ -768,7 +1091,7 @@ const docTemplate = `{
                 ]
             },
             "post": {
-                "description": "Créer un nouveau produit",
+                "description": "Créer un nouveau menu. Un menu plus cher que ses produits achetés séparément est refusé, ou accepté avec un en-tête Warning, selon MENU_PRICING_POLICY",
                 "consumes": [
                     "application/json"
                 ],
@@ -776,16 +1099,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Menus"
                 ],
                 "parameters": [
                     {
-                        "description": "Données du produit",
-                        "name": "product",
+                        "description": "Données du menu",
+                        "name": "menu",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductInsertInput"
+                            "$ref": "#/definitions/models.MenuInsertInput"
                         }
                     }
                 ],
@@ -793,11 +1116,11 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     },
                     "400": {
-                        "description": "Données invalides",
+                        "description": "Données invalides ou menu plus cher que ses produits",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -822,22 +1145,63 @@ const docTemplate = `{
                 ]
             }
         },
-        "/products/categories": {
+        "/menus/deleted": {
             "get": {
-                "description": "Récupérer toutes les catégories de produits",
+                "description": "Récupérer les menus supprimés, page par page, éventuellement recherchés et triés",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductsCategories"
+                    "Menus"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, name, price), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.ListOutput-models_Menu"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
@@ -847,42 +1211,24 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "post": {
-                "description": "Créer une nouvelle catégorie de produit",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/menus/pricing-report": {
+            "get": {
+                "description": "Lister les menus dont le prix est supérieur à celui de leurs produits achetés séparément",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductsCategories"
-                ],
-                "parameters": [
-                    {
-                        "description": "Données de la catégorie du produit",
-                        "name": "productCategory",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductCategoryInsertInput"
-                        }
-                    }
+                    "Menus"
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
-                        }
-                    },
-                    "400": {
-                        "description": "Données invalides",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MenuPricingReportItem"
                             }
                         }
                     },
@@ -903,19 +1249,19 @@ const docTemplate = `{
                 ]
             }
         },
-        "/products/categories/{id}": {
+        "/menus/{id}": {
             "get": {
-                "description": "Récupérer une catégorie de produit par son ID",
+                "description": "Récupérer un menu par son ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductsCategories"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la catégorie du produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -925,7 +1271,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     },
                     "400": {
@@ -938,7 +1284,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Catégorie de produit non trouvée",
+                        "description": "Menu non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -963,7 +1309,7 @@ const docTemplate = `{
                 ]
             },
             "put": {
-                "description": "Mettre à jour une catégorie de produit existante",
+                "description": "Mettre à jour un menu existant. Si son prix ou ses produits changent, un menu plus cher que ses produits achetés séparément est refusé, ou accepté avec un en-tête Warning, selon MENU_PRICING_POLICY",
                 "consumes": [
                     "application/json"
                 ],
@@ -971,12 +1317,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ProductsCategories"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la catégorie de produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -987,7 +1333,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategoryUpdateInput"
+                            "$ref": "#/definitions/models.MenuUpdateInput"
                         }
                     }
                 ],
@@ -995,11 +1341,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     },
                     "400": {
-                        "description": "Données invalides",
+                        "description": "Données invalides ou menu plus cher que ses produits",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1008,7 +1354,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Catégorie de produit non trouvée",
+                        "description": "Produit non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1033,17 +1379,17 @@ const docTemplate = `{
                 ]
             },
             "delete": {
-                "description": "Supprimer une catégorie de produit",
+                "description": "Supprimer un menu, qui peut ensuite être restauré ou supprimé définitivement",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ProductsCategories"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de la catégorie de produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1069,7 +1415,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Catégorie de produit non trouvée",
+                        "description": "Produit non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1094,33 +1440,45 @@ const docTemplate = `{
                 ]
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Récupérer un produit par son ID",
+        "/menus/{id}/availability": {
+            "put": {
+                "description": "Définir la disponibilité d'un menu dans le restaurant courant",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Disponibilité",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogAvailabilityInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     },
                     "400": {
-                        "description": "ID invalide",
+                        "description": "Données invalides",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1129,7 +1487,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Produit non trouvé",
+                        "description": "Menu non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1152,41 +1510,37 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "put": {
-                "description": "Mettre à jour un produit existant",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/menus/{id}/price": {
+            "get": {
+                "description": "Récupérer le prix d'un menu en vigueur à une date, changements programmés compris",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Données de mise à jour",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProductUpdateInput"
-                        }
+                        "type": "string",
+                        "description": "Date au format RFC 3339 (maintenant par défaut)",
+                        "name": "at",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.PriceAtOutput"
                         }
                     },
                     "400": {
@@ -1199,7 +1553,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Produit non trouvé",
+                        "description": "Menu non trouvé ou aucun prix à cette date",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1222,19 +1576,21 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "delete": {
-                "description": "Supprimer un produit",
+            }
+        },
+        "/menus/{id}/prices": {
+            "get": {
+                "description": "Récupérer l'historique des prix d'un menu et ses changements de prix programmés",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du produit",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1242,12 +1598,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message de succès",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.PriceHistoryOutput"
                         }
                     },
                     "400": {
@@ -1260,7 +1613,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Produit non trouvé",
+                        "description": "Menu non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1283,36 +1636,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            }
-        },
-        "/users": {
-            "get": {
-                "description": "Récupérer tous les utilisateurs",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
-                        }
-                    }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ]
             },
             "post": {
-                "description": "Créer un nouvel utilisateur",
+                "description": "Programmer un changement de prix d'un menu, appliqué automatiquement à la date indiquée",
                 "consumes": [
                     "application/json"
                 ],
@@ -1320,16 +1646,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Menus"
                 ],
                 "parameters": [
                     {
-                        "description": "Données de l'utilisateur",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "ID du menu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Prix et date d'application",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserInsertInput"
+                            "$ref": "#/definitions/models.PriceChangeInsertInput"
                         }
                     }
                 ],
@@ -1337,7 +1670,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.UserOutput"
+                            "$ref": "#/definitions/models.PriceChange"
                         }
                     },
                     "400": {
@@ -1349,6 +1682,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Menu non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Erreur interne",
                         "schema": {
@@ -1366,29 +1708,39 @@ const docTemplate = `{
                 ]
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Récupérer un utilisateur par son ID",
+        "/menus/{id}/prices/{priceChangeID}": {
+            "delete": {
+                "description": "Annuler un changement de prix programmé d'un menu",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID du changement de prix",
+                        "name": "priceChangeID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Message de succès",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1401,7 +1753,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Utilisateur non trouvé",
+                        "description": "Menu non trouvé ou changement de prix programmé non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1424,45 +1776,98 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "put": {
-                "description": "Mettre à jour un utilisateur",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/menus/{id}/purge": {
+            "delete": {
+                "description": "Supprimer définitivement un menu supprimé, avec ses disponibilités, ses créneaux, son historique de prix et ses changements de prix programmés",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
-                    {
-                        "description": "Données de l'utilisateur à mettre à jour",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "ID invalide ou menu présent dans des commandes",
                         "schema": {
-                            "$ref": "#/definitions/models.UserUpdateInput"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Menu supprimé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/menus/{id}/restore": {
+            "post": {
+                "description": "Restaurer un menu supprimé",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menus"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du menu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserOutput"
+                            "$ref": "#/definitions/models.Menu"
                         }
                     },
                     "400": {
-                        "description": "Données ou ID invalides",
+                        "description": "ID invalide ou produits du menu supprimés",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1471,7 +1876,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Utilisateur non trouvé",
+                        "description": "Menu supprimé non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1494,27 +1899,47 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ]
-            },
-            "delete": {
-                "description": "Supprimer un utilisateur",
+            }
+        },
+        "/menus/{id}/schedule": {
+            "put": {
+                "description": "Définir les créneaux de disponibilité d'un menu (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Users"
+                    "Menus"
                 ],
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'utilisateur",
+                        "description": "ID du menu",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Créneaux de disponibilité",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AvailabilityScheduleInput"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Pas de contenu"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Menu"
+                        }
                     },
                     "400": {
-                        "description": "ID invalide",
+                        "description": "Données invalides",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1523,7 +1948,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Utilisateur non trouvé",
+                        "description": "Menu non trouvé",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1547,51 +1972,5092 @@ const docTemplate = `{
                     }
                 ]
             }
-        }
-    },
-    "definitions": {
-        "models.Menu": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "isAvailable": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number",
-                    "format": "float64"
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Product"
-                    }
-                },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
         },
-        "models.MenuInsertInput": {
-            "type": "object",
-            "required": [
-                "description",
-                "isAvailable",
-                "name",
+        "/orders": {
+            "get": {
+                "description": "Récupérer toutes les commandes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Créer une nouvelle commande",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données de la commande",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/deliveries": {
+            "get": {
+                "description": "Récupérer les commandes en livraison préparées, réservées par un livreur ou en cours de livraison, les plus anciennes en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/queue": {
+            "get": {
+                "description": "Récupérer la file des commandes à préparer, triée par priorité",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrderQueueOutput"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/quote": {
+            "post": {
+                "description": "Calculer le détail et le total d'une commande en cours de saisie, sans l'enregistrer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données de la commande",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderQuoteOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/status": {
+            "patch": {
+                "description": "Modifier l'état d'avancement de plusieurs commandes à la fois, soit toutes ou aucune (atomic), soit toutes celles qui le peuvent (bestEffort)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "description": "Commandes et état à appliquer",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderStatusBulkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderStatusBulkOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderStatusBulkOutput"
+                        }
+                    },
+                    "403": {
+                        "description": "Accès non autorisé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Récupérerer une commande par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour une commande existante",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/cancelled": {
+            "patch": {
+                "description": "Annuler une commande qui n'a pas encore été livrée",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/claimed": {
+            "patch": {
+                "description": "Réserver une commande en livraison préparée, par le livreur qui va la livrer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/delivered": {
+            "patch": {
+                "description": "Indiquer que la commande a été livrée (un livreur ne peut indiquer que les commandes qu'il a réservées)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Commande réservée par un autre livreur",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/history": {
+            "get": {
+                "description": "Récupérer l'historique des changements d'état d'une commande",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrderStatusHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/in-preparation": {
+            "patch": {
+                "description": "Indiquer que la commande est en préparation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/out-for-delivery": {
+            "patch": {
+                "description": "Indiquer que la commande est en cours de livraison (un livreur ne peut indiquer que les commandes qu'il a réservées)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Commande réservée par un autre livreur",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/prepared": {
+            "patch": {
+                "description": "Indiquer que la commande a été préparée",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/revert": {
+            "patch": {
+                "description": "Ramener une commande à son état précédent, en indiquant la raison (par exemple une commande déclarée préparée par erreur)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la commande",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Raison du retour à l'état précédent",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderRevertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Commande non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Récupérer les produits, page par page, éventuellement filtrés, recherchés et triés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, name, price), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie",
+                        "name": "categoryID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Disponibilité dans le restaurant",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Prix minimum",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Prix maximum",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Allergènes à exclure, séparés par des virgules (ex : gluten,milk)",
+                        "name": "excludeAllergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Uniquement les produits végétariens",
+                        "name": "vegetarian",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Créer un nouveau produit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données du produit",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories": {
+            "get": {
+                "description": "Récupérer l'arbre des catégories de produits, sans leurs produits : les catégories de premier niveau, page par page, avec leurs sous-catégories imbriquées dans Children, dans l'ordre d'affichage. Une recherche porte sur les catégories de tous les niveaux.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (position par défaut, id, name), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Créer une nouvelle catégorie de produit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données de la catégorie du produit",
+                        "name": "productCategory",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategoryInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/deleted": {
+            "get": {
+                "description": "Récupérer les catégories de produits supprimées, page par page, éventuellement recherchées et triées",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, name), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/reorder": {
+            "put": {
+                "description": "Réordonner les catégories de premier niveau, ou les sous-catégories d'une catégorie, en les listant toutes dans leur ordre d'affichage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "description": "Catégorie parente, absente pour le premier niveau, et IDs des catégories dans l'ordre",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategoryReorderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides ou liste incomplète",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie parente non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/{id}": {
+            "get": {
+                "description": "Récupérer une catégorie de produit par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour une catégorie de produit existante",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie de produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategoryUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Supprimer une catégorie de produit, qui peut ensuite être restaurée ou supprimée définitivement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie de produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/{id}/products/reorder": {
+            "put": {
+                "description": "Réordonner les produits d'une catégorie, en les listant tous dans leur ordre d'affichage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie de produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "IDs des produits dans l'ordre",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReorderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides ou liste incomplète",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/{id}/purge": {
+            "delete": {
+                "description": "Supprimer définitivement une catégorie de produit supprimée, avec ses créneaux",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie de produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide ou catégorie ayant des produits ou des sous-catégories, même supprimés",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit supprimée non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/{id}/restore": {
+            "post": {
+                "description": "Restaurer une catégorie de produit supprimée",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie de produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie de produit supprimée non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/categories/{id}/schedule": {
+            "put": {
+                "description": "Définir les créneaux de disponibilité d'une catégorie de produits, appliqués à ses produits (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ProductsCategories"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la catégorie",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Créneaux de disponibilité",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AvailabilityScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Catégorie non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/deleted": {
+            "get": {
+                "description": "Récupérer les produits supprimés, page par page, éventuellement recherchés et triés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, name, price), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans le nom et la description",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Récupérer un produit par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour un produit existant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Supprimer un produit, qui peut ensuite être restauré ou supprimé définitivement",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/availability": {
+            "put": {
+                "description": "Définir la disponibilité d'un produit dans le restaurant courant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Disponibilité",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogAvailabilityInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/price": {
+            "get": {
+                "description": "Récupérer le prix d'un produit en vigueur à une date, changements programmés compris",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date au format RFC 3339 (maintenant par défaut)",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceAtOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé ou aucun prix à cette date",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "description": "Récupérer l'historique des prix d'un produit et ses changements de prix programmés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceHistoryOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Programmer un changement de prix d'un produit, appliqué automatiquement à la date indiquée",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Prix et date d'application",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceChangeInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/prices/{priceChangeID}": {
+            "delete": {
+                "description": "Annuler un changement de prix programmé d'un produit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID du changement de prix",
+                        "name": "priceChangeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé ou changement de prix programmé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/purge": {
+            "delete": {
+                "description": "Supprimer définitivement un produit supprimé, avec ses variantes, ses disponibilités, ses créneaux, son historique de prix, ses changements de prix programmés et sa présence dans les menus supprimés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide ou produit présent dans des commandes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit supprimé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "description": "Restaurer un produit supprimé",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide ou catégorie du produit supprimée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit supprimé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/schedule": {
+            "put": {
+                "description": "Définir les créneaux de disponibilité d'un produit (jours de la semaine et plages horaires, dans le fuseau horaire de chaque restaurant), une liste vide le rendant disponible à toute heure",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Créneaux de disponibilité",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AvailabilityScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/variants": {
+            "post": {
+                "description": "Créer une variante d'un produit (par exemple une taille), avec son prix, sa disponibilité, son SKU et son supplément dans les menus",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de la variante",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariantInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "SKU déjà utilisé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/variants/{variantID}": {
+            "put": {
+                "description": "Mettre à jour une variante d'un produit",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la variante",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariantUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit ou variante non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "SKU déjà utilisé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Supprimer une variante d'un produit",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du produit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la variante",
+                        "name": "variantID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Produit ou variante non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants": {
+            "get": {
+                "description": "Récupérer les restaurants accessibles à l'utilisateur connecté",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Restaurant"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Créer un restaurant, géré ensuite par l'administrateur qui l'a créé",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données du restaurant",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants/{id}": {
+            "get": {
+                "description": "Récupérer un restaurant par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour un restaurant existant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Restaurant"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants/{id}/closures": {
+            "get": {
+                "description": "Récupérer les fermetures exceptionnelles d'un restaurant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Closure"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Ajouter une fermeture exceptionnelle à un restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fermeture exceptionnelle",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ClosureInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Closure"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants/{id}/closures/{closureId}": {
+            "delete": {
+                "description": "Supprimer une fermeture exceptionnelle d'un restaurant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de la fermeture",
+                        "name": "closureId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Fermeture non trouvée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants/{id}/opening-hours": {
+            "get": {
+                "description": "Récupérer les horaires d'ouverture d'un restaurant",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OpeningHour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Remplacer les horaires d'ouverture d'un restaurant (jour de la semaine de 0 pour dimanche à 6 pour samedi, heures au format HH:MM ; une fermeture avant l'ouverture signifie une fermeture après minuit)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Horaires d'ouverture",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OpeningHourInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OpeningHour"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/restaurants/{id}/status": {
+            "get": {
+                "description": "Savoir si un restaurant est ouvert et accepte les commandes (accessible sans authentification, pour les bornes et l'écran client)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restaurants"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du restaurant",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RestaurantStatusOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Restaurant non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Récupérer les utilisateurs, page par page, éventuellement filtrés par rôle, recherchés et triés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, email, role), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans l'email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rôle des utilisateurs",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Filtres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Créer un nouvel utilisateur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données de l'utilisateur",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/deleted": {
+            "get": {
+                "description": "Récupérer les utilisateurs supprimés, page par page, éventuellement filtrés, recherchés et triés",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nombre d'éléments par page (50 par défaut, 100 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curseur de la page suivante, renvoyé par la page précédente",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tri (id, email, role), précédé de - pour un tri décroissant",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Texte recherché dans l'email",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListOutput-models_UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Récupérer un utilisateur par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour un utilisateur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de l'utilisateur à mettre à jour",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données ou ID invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Supprimer un utilisateur, qui ne peut plus se connecter, et qui peut ensuite être restauré ou supprimé définitivement",
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Pas de contenu"
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}/purge": {
+            "delete": {
+                "description": "Supprimer définitivement un utilisateur supprimé, qui n'a pris aucune commande",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide ou utilisateur ayant pris des commandes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur supprimé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "description": "Restaurer un utilisateur supprimé",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur supprimé non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Récupérer tous les webhooks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Enregistrer un webhook abonné à des événements des commandes du restaurant, un secret de signature étant généré s'il n'est pas fourni, et renvoyé uniquement à la création",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "description": "Données du webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInsertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSecretOutput"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/deliveries/{id}/replay": {
+            "post": {
+                "description": "Renvoyer un envoi d'un webhook, remis dans la file d'envoi avec un nouveau nombre de tentatives",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'envoi",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Envoi non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Récupérer un webhook par son ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Mettre à jour un webhook existant, notamment ses abonnements ou son activation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Données de mise à jour",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Supprimer un webhook et son journal des envois",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message de succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Récupérer le journal des envois d'un webhook, du plus récent au plus ancien",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du webhook",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Etat des envois (pending, succeeded, failed)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook non trouvé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur interne",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "models.Allergen": {
+            "type": "string",
+            "enum": [
+                "gluten",
+                "crustaceans",
+                "eggs",
+                "fish",
+                "peanuts",
+                "soybeans",
+                "milk",
+                "nuts",
+                "celery",
+                "mustard",
+                "sesame",
+                "sulphites",
+                "lupin",
+                "molluscs"
+            ],
+            "x-enum-varnames": [
+                "Gluten",
+                "Crustaceans",
+                "Eggs",
+                "Fish",
+                "Peanuts",
+                "Soybeans",
+                "Milk",
+                "Nuts",
+                "Celery",
+                "Mustard",
+                "Sesame",
+                "Sulphites",
+                "Lupin",
+                "Molluscs"
+            ]
+        },
+        "models.AvailabilityScheduleInput": {
+            "type": "object",
+            "properties": {
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AvailabilitySlotInput"
+                    }
+                }
+            }
+        },
+        "models.AvailabilitySlot": {
+            "type": "object",
+            "properties": {
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "productCategoryID": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AvailabilitySlotInput": {
+            "type": "object",
+            "required": [
+                "dayOfWeek",
+                "endsAt",
+                "startsAt"
+            ],
+            "properties": {
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkMode": {
+            "type": "string",
+            "enum": [
+                "atomic",
+                "bestEffort"
+            ],
+            "x-enum-varnames": [
+                "Atomic",
+                "BestEffort"
+            ]
+        },
+        "models.Catalog": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogCategory"
+                    }
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogMenu"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogProduct"
+                    }
+                }
+            }
+        },
+        "models.CatalogAvailabilityInput": {
+            "type": "object",
+            "required": [
+                "isAvailable"
+            ],
+            "properties": {
+                "isAvailable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CatalogCategory": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CatalogImportCount": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CatalogImportReport": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "$ref": "#/definitions/models.CatalogImportCount"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "description": "Nothing is imported when the catalog has errors",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "menus": {
+                    "$ref": "#/definitions/models.CatalogImportCount"
+                },
+                "products": {
+                    "$ref": "#/definitions/models.CatalogImportCount"
+                },
+                "variants": {
+                    "$ref": "#/definitions/models.CatalogImportCount"
+                }
+            }
+        },
+        "models.CatalogMenu": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "description": "URL of the image, kept as is",
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "loyaltyPoints": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productKeys": {
+                    "description": "Products of the catalog, or already in the database",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CatalogProduct": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "categoryKey": {
+                    "description": "Category of the catalog, or already in the database",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "description": "URL of the image, kept as is",
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isVegetarian": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "loyaltyPoints": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.NutritionInput"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "variants": {
+                    "description": "Matched by SKU, the variants missing from the catalog being kept",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogVariant"
+                    }
+                }
+            }
+        },
+        "models.CatalogVariant": {
+            "type": "object",
+            "properties": {
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "menuSupplement": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Closure": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ClosureInsertInput": {
+            "type": "object",
+            "required": [
+                "endsAt",
+                "startsAt"
+            ],
+            "properties": {
+                "endsAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Customer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "loyaltyConsent": {
+                    "type": "boolean"
+                },
+                "loyaltyPoints": {
+                    "type": "integer"
+                },
+                "marketingConsent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CustomerInsertInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "loyaltyConsent": {
+                    "type": "boolean"
+                },
+                "marketingConsent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CustomerUpdateInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "loyaltyConsent": {
+                    "type": "boolean"
+                },
+                "marketingConsent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KitchenStation": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KitchenStationInsertInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KitchenStationUpdateInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.KitchenTicketOutput": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "amendments": {
+                    "description": "Item changes made on the order after its preparation started, such as \"+1 Frites, -1 Coca-Cola\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "orderNotes": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ListOutput-models_Menu": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Menu"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "models.ListOutput-models_Product": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "models.ListOutput-models_ProductCategory": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductCategory"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "models.ListOutput-models_UserOutput": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserOutput"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer",
+                    "format": "int64"
+                }
+            }
+        },
+        "models.LoyaltyTransaction": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.LoyaltyTransactionType"
+                }
+            }
+        },
+        "models.LoyaltyTransactionType": {
+            "type": "string",
+            "enum": [
+                "earned",
+                "reversed",
+                "redeemed",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "Earned",
+                "Reversed",
+                "Redeemed",
+                "Refunded"
+            ]
+        },
+        "models.Menu": {
+            "type": "object",
+            "properties": {
+                "alaCartePrice": {
+                    "description": "Sum of the prices of the products bought separately",
+                    "type": "number"
+                },
+                "allergens": {
+                    "description": "Computed from the products of the menu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "availabilitySlots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AvailabilitySlot"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalKey": {
+                    "description": "Identifies the menu in catalog imports, set by the first export or import",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isAvailableNow": {
+                    "description": "Available, and within its slots",
+                    "type": "boolean"
+                },
+                "isVegetarian": {
+                    "type": "boolean"
+                },
+                "locale": {
+                    "description": "Loaded for the languages of the request",
+                    "type": "string"
+                },
+                "loyaltyPoints": {
+                    "description": "Points needed to get the menu with loyalty points, 0 if it cannot be",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "saving": {
+                    "description": "Saving on the products bought separately, negative if the menu costs more",
+                    "type": "number"
+                },
+                "translations": {
+                    "description": "Name and description in other languages, by locale",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MenuInsertInput": {
+            "type": "object",
+            "required": [
+                "description",
+                "isAvailable",
+                "name",
                 "price",
                 "productsIDs"
             ],
@@ -1599,332 +7065,1776 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
-                "image": {
+                "image": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "loyaltyPoints": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productsIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
+                }
+            }
+        },
+        "models.MenuPricingReportItem": {
+            "type": "object",
+            "properties": {
+                "alaCartePrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "saving": {
+                    "description": "Negative, the extra cost of the menu",
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "models.MenuUpdateInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "loyaltyPoints": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "productsIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "translations": {
+                    "description": "Replaces all the translations",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
+                }
+            }
+        },
+        "models.Nutrition": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "carbohydrates": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "fats": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "proteins": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "salt": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "saturatedFats": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "sugars": {
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "models.NutritionInput": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "carbohydrates": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "fats": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "proteins": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "salt": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "saturatedFats": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "sugars": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.OpeningHour": {
+            "type": "object",
+            "properties": {
+                "closesAt": {
+                    "type": "string"
+                },
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "opensAt": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OpeningHourInput": {
+            "type": "object",
+            "required": [
+                "closesAt",
+                "dayOfWeek",
+                "opensAt"
+            ],
+            "properties": {
+                "closesAt": {
+                    "type": "string"
+                },
+                "dayOfWeek": {
+                    "type": "integer"
+                },
+                "opensAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "required": [
+                "status",
+                "user"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "amendments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAmendment"
+                    }
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "claimedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveryAddress": {
+                    "type": "string"
+                },
+                "deliveryPhone": {
+                    "type": "string"
+                },
+                "driverID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inPreparationAt": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "outForDeliveryAt": {
+                    "type": "string"
+                },
+                "preparedAt": {
+                    "type": "string"
+                },
+                "requestedReadyAt": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderAmendment": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAmendmentLine"
+                    }
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderAmendmentLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderAmendmentID": {
+                    "type": "integer"
+                },
+                "previousQuantity": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "quantityChange": {
+                    "description": "Positive for an added quantity, negative for a removed one",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderAmendmentOutput": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAmendmentLine"
+                    }
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderChannel": {
+            "type": "string",
+            "enum": [
+                "counter",
+                "kiosk",
+                "driveThrough",
+                "clickAndCollect",
+                "delivery"
+            ],
+            "x-enum-varnames": [
+                "Counter",
+                "Kiosk",
+                "DriveThrough",
+                "ClickAndCollect",
+                "Delivery"
+            ]
+        },
+        "models.OrderInsertInput": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "deliveryAddress": {
+                    "type": "string"
+                },
+                "deliveryPhone": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItemInput"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "overrideOpeningHours": {
+                    "type": "boolean"
+                },
+                "requestedReadyAt": {
+                    "type": "string"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderItem": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menuChoices": {
+                    "description": "Upsized products of a menu, such as \"Frites (Large), Coca-Cola (Large)\"",
+                    "type": "string"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "orderContentAllergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "orderContentDescription": {
+                    "type": "string"
+                },
+                "orderContentImage": {
+                    "type": "string"
+                },
+                "orderContentLocale": {
+                    "description": "Language the name and description were taken in, empty for the default language",
+                    "type": "string"
+                },
+                "orderContentName": {
+                    "type": "string"
+                },
+                "orderContentNutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "orderContentPrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "productVariantID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "redeemedPoints": {
+                    "description": "Loyalty points paid instead of the price",
+                    "type": "integer"
+                },
+                "stationItems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderStationItem"
+                    }
+                }
+            }
+        },
+        "models.OrderItemInput": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "menuChoices": {
+                    "description": "Products of the menu upsized with one of their variants",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItemMenuChoiceInput"
+                    }
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "redeemPoints": {
+                    "type": "boolean"
+                },
+                "variantID": {
+                    "description": "Required for a product with variants",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderItemMenuChoiceInput": {
+            "type": "object",
+            "required": [
+                "productID",
+                "variantID"
+            ],
+            "properties": {
+                "productID": {
+                    "type": "integer"
+                },
+                "variantID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderOutput": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "allergenAlert": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "amendments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAmendmentOutput"
+                    }
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "claimedAt": {
+                    "type": "string"
+                },
+                "contentAllergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveryAddress": {
+                    "type": "string"
+                },
+                "deliveryPhone": {
+                    "type": "string"
+                },
+                "driverID": {
+                    "type": "integer"
+                },
+                "estimatedReadyAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inPreparationAt": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "outForDeliveryAt": {
+                    "type": "string"
+                },
+                "preparedAt": {
+                    "type": "string"
+                },
+                "redeemedPoints": {
+                    "type": "integer"
+                },
+                "requestedReadyAt": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                },
+                "totalPrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserOutput"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderQueueOutput": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "allergenAlert": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "amendments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAmendmentOutput"
+                    }
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "claimedAt": {
+                    "type": "string"
+                },
+                "contentAllergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveryAddress": {
+                    "type": "string"
+                },
+                "deliveryPhone": {
+                    "type": "string"
+                },
+                "driverID": {
+                    "type": "integer"
+                },
+                "dueAt": {
+                    "type": "string"
+                },
+                "estimatedReadyAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inPreparationAt": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "outForDeliveryAt": {
+                    "type": "string"
+                },
+                "preparedAt": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "redeemedPoints": {
+                    "type": "integer"
+                },
+                "requestedReadyAt": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                },
+                "totalPrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.UserOutput"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderQuoteLineOutput": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "discount": {
+                    "description": "Saving of a menu on its products bought separately",
+                    "type": "number",
+                    "format": "float64"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "redeemedPoints": {
+                    "type": "integer"
+                },
+                "totalPrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "unitPrice": {
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "models.OrderQuoteOutput": {
+            "type": "object",
+            "properties": {
+                "allergenAlert": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "contentAllergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "earnedPoints": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderQuoteLineOutput"
+                    }
+                },
+                "itemsCount": {
+                    "type": "integer"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "redeemedPoints": {
+                    "type": "integer"
+                },
+                "requestedReadyAt": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "description": "Total price with the menu products bought separately",
+                    "type": "number",
+                    "format": "float64"
+                },
+                "totalExcludingVAT": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "totalPrice": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "vatamount": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "vatrate": {
+                    "description": "In percent",
+                    "type": "number",
+                    "format": "float64"
+                }
+            }
+        },
+        "models.OrderRevertInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderStationItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "orderItemID": {
+                    "type": "integer"
+                },
+                "preparedAt": {
+                    "type": "string"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                }
+            }
+        },
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "inPreparation",
+                "prepared",
+                "claimed",
+                "outForDelivery",
+                "delivered",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "Created",
+                "InPreparation",
+                "Prepared",
+                "Claimed",
+                "OutForDelivery",
+                "Delivered",
+                "Cancelled"
+            ]
+        },
+        "models.OrderStatusBulkInput": {
+            "type": "object",
+            "required": [
+                "orderIDs",
+                "status"
+            ],
+            "properties": {
+                "mode": {
+                    "$ref": "#/definitions/models.BulkMode"
+                },
+                "orderIDs": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                }
+            }
+        },
+        "models.OrderStatusBulkOutput": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "$ref": "#/definitions/models.BulkMode"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderStatusBulkResult"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderStatusBulkResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "order": {
+                    "$ref": "#/definitions/models.OrderOutput"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.OrderStatusHistory": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isRevert": {
+                    "type": "boolean"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "toStatus": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderUpdateInput": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.OrderChannel"
+                },
+                "customerID": {
+                    "type": "integer"
+                },
+                "deliveryAddress": {
+                    "type": "string"
+                },
+                "deliveryPhone": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItemInput"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "overrideOpeningHours": {
+                    "type": "boolean"
+                },
+                "requestedReadyAt": {
+                    "description": "null to prepare the order as soon as possible",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PriceAtOutput": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "priceChangeID": {
+                    "description": "Change setting the price, nil for a price set before the history was recorded",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceChange": {
+            "type": "object",
+            "properties": {
+                "appliedAt": {
+                    "description": "Nil while the change is scheduled",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "effectiveAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "menuID": {
+                    "type": "integer"
+                },
+                "previousPrice": {
+                    "description": "Price replaced by the change, set when the change is applied",
+                    "type": "number",
+                    "format": "float64"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "productID": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceChangeInsertInput": {
+            "type": "object",
+            "required": [
+                "effectiveAt",
+                "price"
+            ],
+            "properties": {
+                "effectiveAt": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PriceHistoryOutput": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "description": "Applied changes, the latest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceChange"
+                    }
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "scheduled": {
+                    "description": "Scheduled changes, in the order they take effect",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceChange"
+                    }
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Allergen"
+                    }
+                },
+                "availabilitySlots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AvailabilitySlot"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/models.ProductCategory"
+                },
+                "categoryID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalKey": {
+                    "description": "Identifies the product in catalog imports, set by the first export or import",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isAvailableNow": {
+                    "description": "Available, and within its slots and the slots of its category",
+                    "type": "boolean"
+                },
+                "isVegetarian": {
+                    "type": "boolean"
+                },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "description": "Loaded for the languages of the request",
+                    "type": "string"
+                },
+                "loyaltyPoints": {
+                    "description": "Points needed to get the product with loyalty points, 0 if it cannot be",
+                    "type": "integer"
+                },
+                "menus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Menu"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.Nutrition"
+                },
+                "position": {
+                    "description": "Display order among the products of the category",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "translations": {
+                    "description": "Name and description in other languages, by locale",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductVariant"
+                    }
+                }
+            }
+        },
+        "models.ProductCategory": {
+            "type": "object",
+            "properties": {
+                "availabilitySlots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AvailabilitySlot"
+                    }
+                },
+                "children": {
+                    "description": "Loaded by the category tree only",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductCategory"
+                    }
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "externalKey": {
+                    "description": "Identifies the category in catalog imports, set by the first export or import",
                     "type": "string"
                 },
-                "isAvailable": {
+                "id": {
+                    "type": "integer"
+                },
+                "isAvailableNow": {
+                    "description": "Within its slots",
                     "type": "boolean"
                 },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "locale": {
+                    "description": "Loaded for the languages of the request",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "parentID": {
+                    "description": "Category it is nested in, nil for a top-level category",
+                    "type": "integer"
                 },
-                "productsIDs": {
+                "position": {
+                    "description": "Display order among the categories of the same parent",
+                    "type": "integer"
+                },
+                "products": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/models.Product"
                     }
+                },
+                "translations": {
+                    "description": "Name and description in other languages, by locale",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Translations"
+                        }
+                    ]
                 }
             }
         },
-        "models.MenuUpdateInput": {
+        "models.ProductCategoryInsertInput": {
             "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "isAvailable": {
-                    "type": "boolean"
+                "kitchenStationID": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "parentID": {
+                    "type": "integer"
                 },
-                "productsIDs": {
+                "position": {
+                    "description": "After the other categories of the parent if not set",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
+                }
+            }
+        },
+        "models.ProductCategoryReorderInput": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
+                },
+                "parentID": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Order": {
+        "models.ProductCategoryUpdateInput": {
             "type": "object",
-            "required": [
-                "status",
-                "user"
-            ],
             "properties": {
-                "createdAt": {
+                "description": {
                     "type": "string"
                 },
-                "deliveredAt": {
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "parentID": {
+                    "description": "0 to move the category to the top level",
                     "type": "integer"
                 },
-                "items": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "translations": {
+                    "description": "Replaces all the translations",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
+                }
+            }
+        },
+        "models.ProductInsertInput": {
+            "type": "object",
+            "required": [
+                "categoryID",
+                "description",
+                "isAvailable",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "allergens": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.OrderItem"
+                        "$ref": "#/definitions/models.Allergen"
                     }
                 },
-                "preparedAt": {
-                    "type": "string"
+                "categoryID": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/models.OrderStatus"
+                "description": {
+                    "type": "string"
                 },
-                "ticketNumber": {
+                "image": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "isAvailable": {
+                    "type": "boolean"
                 },
-                "userID": {
+                "isVegetarian": {
+                    "type": "boolean"
+                },
+                "kitchenStationID": {
                     "type": "integer"
+                },
+                "loyaltyPoints": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.NutritionInput"
+                },
+                "position": {
+                    "description": "After the other products of the category if not set",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "type": "number"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
                 }
             }
         },
-        "models.OrderInsertInput": {
+        "models.ProductUpdateInput": {
             "type": "object",
-            "required": [
-                "items",
-                "ticketNumber"
-            ],
             "properties": {
-                "items": {
+                "allergens": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/models.OrderItemInput"
+                        "$ref": "#/definitions/models.Allergen"
                     }
                 },
-                "ticketNumber": {
+                "categoryID": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "isVegetarian": {
+                    "type": "boolean"
+                },
+                "kitchenStationID": {
+                    "type": "integer"
+                },
+                "loyaltyPoints": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string"
+                },
+                "nutrition": {
+                    "$ref": "#/definitions/models.NutritionInput"
+                },
+                "position": {
+                    "description": "After the other products of the new category if not set when moved",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "type": "number"
+                },
+                "translations": {
+                    "description": "Replaces all the translations",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TranslationInput"
+                    }
                 }
             }
         },
-        "models.OrderItem": {
+        "models.ProductVariant": {
             "type": "object",
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "orderContentDescription": {
-                    "type": "string"
+                "isAvailable": {
+                    "type": "boolean"
                 },
-                "orderContentImage": {
-                    "type": "string"
+                "menuSupplement": {
+                    "description": "Added to the price of a menu when the variant is chosen instead of the product, to upsize it",
+                    "type": "number",
+                    "format": "float64"
                 },
-                "orderContentName": {
+                "name": {
                     "type": "string"
                 },
-                "orderContentPrice": {
+                "price": {
                     "type": "number",
                     "format": "float64"
                 },
-                "orderID": {
+                "productID": {
                     "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer"
+                "sku": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "models.OrderItemInput": {
+        "models.ProductVariantInsertInput": {
             "type": "object",
             "required": [
-                "quantity"
+                "name",
+                "price",
+                "sku"
             ],
             "properties": {
-                "menuID": {
-                    "type": "integer"
+                "isAvailable": {
+                    "description": "Available by default",
+                    "type": "boolean"
                 },
-                "productID": {
-                    "type": "integer"
+                "menuSupplement": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 1
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
                 }
             }
         },
-        "models.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "created",
-                "inPreparation",
-                "prepared",
-                "delivered"
-            ],
-            "x-enum-varnames": [
-                "Created",
-                "InPreparation",
-                "Prepared",
-                "Delivered"
-            ]
-        },
-        "models.OrderUpdateInput": {
+        "models.ProductVariantUpdateInput": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/models.OrderItemInput"
-                    }
+                "isAvailable": {
+                    "type": "boolean"
                 },
-                "ticketNumber": {
+                "menuSupplement": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
                     "type": "string"
                 }
             }
         },
-        "models.Product": {
+        "models.ReorderInput": {
             "type": "object",
+            "required": [
+                "ids"
+            ],
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/models.ProductCategory"
-                },
-                "categoryID": {
-                    "type": "integer"
-                },
-                "createdAt": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Restaurant": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "description": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "isAvailable": {
-                    "type": "boolean"
+                "lastOrderCutoff": {
+                    "description": "Minutes before closing from which orders are refused",
+                    "type": "integer"
                 },
-                "menus": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Menu"
-                    }
+                "lastTicketNumber": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number",
-                    "format": "float64"
+                "timeZone": {
+                    "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "models.ProductCategory": {
+        "models.RestaurantInsertInput": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "description": {
+                "address": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "lastOrderCutoff": {
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "name": {
                     "type": "string"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Product"
-                    }
+                "timeZone": {
+                    "type": "string"
                 }
             }
         },
-        "models.ProductCategoryInsertInput": {
+        "models.RestaurantStatusOutput": {
             "type": "object",
-            "required": [
-                "description",
-                "name"
-            ],
             "properties": {
-                "description": {
+                "acceptsOrders": {
+                    "type": "boolean"
+                },
+                "closesAt": {
                     "type": "string"
                 },
+                "isOpen": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string"
+                },
+                "nextOpeningAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restaurantID": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ProductCategoryUpdateInput": {
+        "models.RestaurantUpdateInput": {
             "type": "object",
             "properties": {
-                "description": {
+                "address": {
                     "type": "string"
                 },
+                "lastOrderCutoff": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "name": {
                     "type": "string"
+                },
+                "timeZone": {
+                    "type": "string"
                 }
             }
         },
-        "models.ProductInsertInput": {
+        "models.Translation": {
             "type": "object",
-            "required": [
-                "categoryID",
-                "description",
-                "isAvailable",
-                "name",
-                "price"
-            ],
             "properties": {
-                "categoryID": {
-                    "type": "integer"
-                },
                 "description": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "isAvailable": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
-                },
-                "price": {
-                    "type": "number"
                 }
             }
         },
-        "models.ProductUpdateInput": {
+        "models.TranslationInput": {
             "type": "object",
             "properties": {
-                "categoryID": {
-                    "type": "integer"
-                },
                 "description": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string"
-                },
-                "isAvailable": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
-                },
-                "price": {
-                    "type": "number"
                 }
             }
         },
+        "models.Translations": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/models.Translation"
+            }
+        },
         "models.User": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1934,6 +8844,16 @@ const docTemplate = `{
                 "password": {
                     "type": "string"
                 },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "restaurants": {
+                    "description": "Other restaurants managed by an administrator",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Restaurant"
+                    }
+                },
                 "role": {
                     "$ref": "#/definitions/models.UserRole"
                 },
@@ -1957,6 +8877,12 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 8
                 },
+                "restaurantIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "role": {
                     "$ref": "#/definitions/models.UserRole"
                 }
@@ -1984,12 +8910,25 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "description": "Set for a deleted user",
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "restaurantIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "role": {
                     "$ref": "#/definitions/models.UserRole"
                 },
@@ -2004,13 +8943,15 @@ const docTemplate = `{
                 "admin",
                 "greeter",
                 "order_picker",
-                "manager"
+                "manager",
+                "driver"
             ],
             "x-enum-varnames": [
                 "Admin",
                 "Greeter",
                 "OrderPicker",
-                "Manager"
+                "Manager",
+                "Driver"
             ]
         },
         "models.UserUpdateInput": {
@@ -2022,10 +8963,209 @@ const docTemplate = `{
                 "password": {
                     "type": "string"
                 },
+                "restaurantIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "role": {
                     "$ref": "#/definitions/models.UserRole"
                 }
             }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/models.WebhookEvent"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastAttemptAt": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "orderID": {
+                    "type": "integer"
+                },
+                "outboxEventID": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.WebhookDeliveryStatus"
+                },
+                "webhookID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DeliveryPending",
+                "DeliverySucceeded",
+                "DeliveryFailed"
+            ]
+        },
+        "models.WebhookEvent": {
+            "type": "string",
+            "enum": [
+                "order.created",
+                "order.updated",
+                "order.statusChanged",
+                "order.cancelled",
+                "order.amended"
+            ],
+            "x-enum-varnames": [
+                "OrderCreatedEvent",
+                "OrderUpdatedEvent",
+                "OrderStatusChangedEvent",
+                "OrderCancelledEvent",
+                "OrderAmendedEvent"
+            ]
+        },
+        "models.WebhookInsertInput": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookEvent"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSecretOutput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "restaurantID": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookUpdateInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookEvent"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,252 @@
+package jobs
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const orderRetentionBatchSize = 500
+
+type OrderRetentionReport struct {
+	Cutoff   time.Time
+	Mode     string
+	Archived int
+	Files    []string
+}
+
+// ScheduleOrderRetention runs the retention job now and then at every interval, in the background.
+func ScheduleOrderRetention(db *gorm.DB) {
+	if config.OrderRetentionDays() == 0 {
+		log.Print("Order retention is disabled.")
+
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(config.OrderRetentionInterval())
+		defer ticker.Stop()
+
+		for {
+			if _, err := PurgeOrders(db, time.Now()); err != nil {
+				log.Print("Unable to purge orders: ", err)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+// PurgeOrders archives then deletes the orders delivered or cancelled before the retention cutoff.
+// Orders are processed by batches, each batch being archived before being deleted,
+// so the job can be run again safely after a failure.
+func PurgeOrders(db *gorm.DB, now time.Time) (OrderRetentionReport, error) {
+	report := OrderRetentionReport{Mode: config.OrderArchiveMode()}
+
+	days := config.OrderRetentionDays()
+	if days == 0 {
+		return report, fmt.Errorf("order retention is disabled, set ORDER_RETENTION_DAYS")
+	}
+
+	report.Cutoff = now.AddDate(0, 0, -days)
+
+	for {
+		var orders []models.Order
+
+		err := db.Preload("User", models.WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").
+			Where("(status = ? AND delivered_at < ?) OR (status = ? AND cancelled_at < ?)", models.Delivered, report.Cutoff, models.Cancelled, report.Cutoff).
+			Order("id").
+			Limit(orderRetentionBatchSize).
+			Find(&orders).Error
+		if err != nil {
+			return report, err
+		}
+
+		if len(orders) == 0 {
+			break
+		}
+
+		payloads, err := transformOrdersToArchivedPayloads(db, orders)
+		if err != nil {
+			return report, err
+		}
+
+		if report.Mode == config.ArchiveModeFile {
+			file, err := archiveOrdersToFile(orders, payloads)
+			if err != nil {
+				return report, err
+			}
+
+			report.Files = append(report.Files, file)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if report.Mode == config.ArchiveModeTable {
+				if err := archiveOrdersToTable(tx, orders, payloads, now); err != nil {
+					return err
+				}
+			}
+
+			return deleteOrders(tx, orders)
+		})
+		if err != nil {
+			return report, err
+		}
+
+		report.Archived += len(orders)
+	}
+
+	log.Printf("Order retention: %d orders delivered or cancelled before %s archived (%s mode) and deleted.", report.Archived, report.Cutoff.Format(time.RFC3339), report.Mode)
+
+	return report, nil
+}
+
+// transformOrdersToArchivedPayloads returns the orders as archived, with their status history.
+func transformOrdersToArchivedPayloads(db *gorm.DB, orders []models.Order) ([]models.ArchivedOrderPayload, error) {
+	var history []models.OrderStatusHistory
+	if err := db.Where("order_id IN ?", orderIDsOf(orders)).Order("id").Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	historyByOrder := make(map[uint][]models.OrderStatusHistory)
+	for _, change := range history {
+		historyByOrder[change.OrderID] = append(historyByOrder[change.OrderID], change)
+	}
+
+	payloads := make([]models.ArchivedOrderPayload, 0, len(orders))
+	for index := range orders {
+		payloads = append(payloads, models.ArchivedOrderPayload{
+			OrderOutput:   models.TransformOrderToOutput(&orders[index]),
+			StatusHistory: historyByOrder[orders[index].ID],
+		})
+	}
+
+	return payloads, nil
+}
+
+func archiveOrdersToTable(tx *gorm.DB, orders []models.Order, payloads []models.ArchivedOrderPayload, now time.Time) error {
+	archivedOrders := make([]models.ArchivedOrder, 0, len(orders))
+
+	for index, order := range orders {
+		payload, err := json.Marshal(payloads[index])
+		if err != nil {
+			return err
+		}
+
+		archivedOrders = append(archivedOrders, models.ArchivedOrder{
+			ID:           order.ID,
+			Status:       order.Status,
+			TicketNumber: order.TicketNumber,
+			RestaurantID: order.RestaurantID,
+			UserID:       order.UserID,
+			TotalPrice:   payloads[index].TotalPrice,
+			CreatedAt:    order.CreatedAt,
+			Payload:      string(payload),
+			ArchivedAt:   now,
+		})
+	}
+
+	// An order already archived by a previous interrupted run is kept as is.
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&archivedOrders).Error
+}
+
+// archiveOrdersToFile writes the batch in a gzip-compressed JSON file named after its ID range,
+// so running the job again on the same orders overwrites the same file.
+func archiveOrdersToFile(orders []models.Order, payloads []models.ArchivedOrderPayload) (string, error) {
+	directory := config.OrderArchiveDirectory()
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return "", err
+	}
+
+	fileName := filepath.Join(directory, fmt.Sprintf("orders-%d-%d.json.gz", orders[0].ID, orders[len(orders)-1].ID))
+
+	temporaryFile, err := os.CreateTemp(directory, "orders-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	writer := gzip.NewWriter(temporaryFile)
+
+	if err := json.NewEncoder(writer).Encode(payloads); err != nil {
+		temporaryFile.Close()
+
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		temporaryFile.Close()
+
+		return "", err
+	}
+
+	if err := temporaryFile.Close(); err != nil {
+		return "", err
+	}
+
+	return fileName, os.Rename(temporaryFile.Name(), fileName)
+}
+
+func orderIDsOf(orders []models.Order) []uint {
+	orderIDs := make([]uint, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	return orderIDs
+}
+
+// deleteOrders deletes the orders with their items, status history and amendments, the events and the webhook deliveries about them.
+// Their loyalty transactions are kept in the ledger of the customers, detached from them.
+func deleteOrders(tx *gorm.DB, orders []models.Order) error {
+	orderIDs := orderIDsOf(orders)
+
+	outboxEventIDs := tx.Model(&models.OutboxEvent{}).Select("id").Where("type IN ? AND aggregate_id IN ?", models.OrderEventTypes, orderIDs)
+
+	if err := tx.Where("order_id IN ? OR outbox_event_id IN (?)", orderIDs, outboxEventIDs).Delete(&models.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("type IN ? AND aggregate_id IN ?", models.OrderEventTypes, orderIDs).Delete(&models.OutboxEvent{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.LoyaltyTransaction{}).Where("order_id IN ?", orderIDs).Update("order_id", nil).Error; err != nil {
+		return err
+	}
+
+	orderItemIDs := tx.Model(&models.OrderItem{}).Select("id").Where("order_id IN ?", orderIDs)
+
+	if err := tx.Where("order_item_id IN (?)", orderItemIDs).Delete(&models.OrderStationItem{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("order_id IN ?", orderIDs).Delete(&models.OrderStatusHistory{}).Error; err != nil {
+		return err
+	}
+
+	orderAmendmentIDs := tx.Model(&models.OrderAmendment{}).Select("id").Where("order_id IN ?", orderIDs)
+
+	if err := tx.Where("order_amendment_id IN (?)", orderAmendmentIDs).Delete(&models.OrderAmendmentLine{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("order_id IN ?", orderIDs).Delete(&models.OrderAmendment{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("order_id IN ?", orderIDs).Delete(&models.OrderItem{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Delete(&models.Order{}, orderIDs).Error
+}
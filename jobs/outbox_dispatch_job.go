@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	outboxDispatchBatchSize = 100
+	outboxMaxRetryDelay     = time.Hour
+)
+
+// RegisterEventSubscribers subscribes the side effects of the application to the domain events.
+func RegisterEventSubscribers() {
+	models.Subscribe("webhooks", models.EnqueueWebhookDeliveries, models.OrderCreated, models.OrderUpdated, models.OrderStatusChanged, models.OrderCancelled, models.OrderAmended)
+}
+
+// ScheduleOutboxDispatch delivers the outbox events to their subscribers at every interval, in the background.
+func ScheduleOutboxDispatch(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(config.OutboxDispatchInterval())
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := DispatchOutboxEvents(db, time.Now()); err != nil {
+				log.Print("Unable to dispatch outbox events: ", err)
+			}
+		}
+	}()
+}
+
+// DispatchOutboxEvents delivers the events due for an attempt to their subscribers, in the order they were emitted,
+// and returns how many were dispatched.
+// An event is marked as dispatched once every subscriber has handled it; when one fails,
+// the event is attempted again later with all its subscribers, which must therefore be idempotent.
+func DispatchOutboxEvents(db *gorm.DB, now time.Time) (int, error) {
+	var events []models.OutboxEvent
+
+	err := db.Where("dispatched_at = ? AND next_attempt_at <= ?", time.Time{}, now).
+		Order("id").
+		Limit(outboxDispatchBatchSize).
+		Find(&events).Error
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+
+	for index := range events {
+		event := &events[index]
+
+		updates := map[string]interface{}{
+			"attempts": event.Attempts + 1,
+		}
+
+		if err := deliverOutboxEvent(db, event); err != nil {
+			log.Printf("Unable to deliver outbox event %d (%s): %s", event.ID, event.Type, err)
+
+			delay := config.OutboxRetryDelay() << event.Attempts
+			if delay <= 0 || delay > outboxMaxRetryDelay {
+				delay = outboxMaxRetryDelay
+			}
+
+			updates["error"] = err.Error()
+			updates["nextAttemptAt"] = now.Add(delay)
+		} else {
+			updates["error"] = ""
+			updates["dispatchedAt"] = now
+			dispatched++
+		}
+
+		if err := db.Model(event).Updates(updates).Error; err != nil {
+			return dispatched, err
+		}
+	}
+
+	return dispatched, nil
+}
+
+// deliverOutboxEvent calls each subscriber in its own transaction, so that a failing subscriber does not undo the others.
+func deliverOutboxEvent(db *gorm.DB, event *models.OutboxEvent) error {
+	for _, subscriber := range models.EventSubscribers(event.Type) {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			return subscriber.Handler(tx, event)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", subscriber.Name, err)
+		}
+	}
+
+	return nil
+}
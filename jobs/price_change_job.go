@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"log"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+
+	"gorm.io/gorm"
+)
+
+// SchedulePriceChanges applies the scheduled price changes taking effect at every interval, in the background.
+func SchedulePriceChanges(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(config.PriceChangeInterval())
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := ApplyPriceChanges(db, time.Now()); err != nil {
+				log.Print("Unable to apply price changes: ", err)
+			}
+		}
+	}()
+}
+
+// ApplyPriceChanges applies the scheduled price changes whose effective date has passed, in the order they take effect,
+// and returns how many were applied. Each change is applied in its own transaction, with the update event of its product or menu.
+func ApplyPriceChanges(db *gorm.DB, now time.Time) (int, error) {
+	var changes []models.PriceChange
+
+	if err := db.Where("applied_at IS NULL AND effective_at <= ?", now).Order("effective_at, id").Find(&changes).Error; err != nil {
+		return 0, err
+	}
+
+	for index := range changes {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			return changes[index].Apply(tx, now)
+		})
+		if err != nil {
+			return index, err
+		}
+	}
+
+	return len(changes), nil
+}
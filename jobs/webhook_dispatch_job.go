@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+
+	"gorm.io/gorm"
+)
+
+const webhookDispatchBatchSize = 100
+
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// ScheduleWebhookDispatch sends the queued webhook deliveries at every interval, in the background.
+func ScheduleWebhookDispatch(db *gorm.DB) {
+	go func() {
+		ticker := time.NewTicker(config.WebhookDispatchInterval())
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := DispatchWebhookDeliveries(db, webhookClient, time.Now()); err != nil {
+				log.Print("Unable to dispatch webhook deliveries: ", err)
+			}
+		}
+	}()
+}
+
+// DispatchWebhookDeliveries sends the pending deliveries due for an attempt, and returns how many were sent.
+// Each delivery is posted with its event, its ID, a timestamp and the HMAC signature of the payload,
+// the webhook having to answer with a 2xx status for the delivery to succeed.
+// A delivery which cannot be sent or recorded is logged and left aside, so that it does not hold up the others.
+func DispatchWebhookDeliveries(db *gorm.DB, client *http.Client, now time.Time) (int, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := db.Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+		Order("next_attempt_at, id").
+		Limit(webhookDispatchBatchSize).
+		Find(&deliveries).Error
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uint]*models.Webhook)
+	sent := 0
+
+	for index := range deliveries {
+		delivery := &deliveries[index]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook = &models.Webhook{}
+			if err := db.First(webhook, delivery.WebhookID).Error; err != nil {
+				log.Printf("Unable to find webhook %d of delivery %d: %s", delivery.WebhookID, delivery.ID, err)
+
+				// A delivery whose webhook is gone cannot be sent anymore, while a failed query is retried later.
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					err = delivery.Fail(db, "Webhook not found.", now)
+				} else {
+					err = delivery.RecordAttempt(db, 0, err, now)
+				}
+
+				if err != nil {
+					log.Printf("Unable to record attempt of webhook delivery %d: %s", delivery.ID, err)
+				}
+
+				continue
+			}
+
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		responseStatus, err := sendWebhookDelivery(client, webhook, delivery, now)
+		sent++
+
+		if err := delivery.RecordAttempt(db, responseStatus, err, now); err != nil {
+			log.Printf("Unable to record attempt of webhook delivery %d: %s", delivery.ID, err)
+		}
+	}
+
+	return sent, nil
+}
+
+func sendWebhookDelivery(client *http.Client, webhook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Wacdo-Event", string(delivery.Event))
+	request.Header.Set("X-Wacdo-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set("X-Wacdo-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	request.Header.Set("X-Wacdo-Signature", models.SignWebhookPayload(webhook.Secret, now.Unix(), body))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("webhook answered with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
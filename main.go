@@ -1,10 +1,18 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 	"wacdo/config"
+	"wacdo/jobs"
 	"wacdo/models"
 	"wacdo/routes"
 
@@ -23,6 +31,32 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	// The "purge-orders" command runs the order retention job once, then exits.
+	if len(os.Args) > 1 && os.Args[1] == "purge-orders" {
+		loadEnv()
+		config.ConnectDB()
+		migrateDB()
+
+		if _, err := jobs.PurgeOrders(config.DB, time.Now()); err != nil {
+			log.Fatal("Unable to purge orders: ", err)
+		}
+
+		return
+	}
+
+	// The "export-catalog" and "import-catalog" commands exchange the catalog with a JSON or CSV file, then exit.
+	if len(os.Args) > 2 && (os.Args[1] == "export-catalog" || os.Args[1] == "import-catalog") {
+		loadEnv()
+		config.ConnectDB()
+		migrateDB()
+
+		if err := runCatalogCommand(os.Args[1], os.Args[2], slices.Contains(os.Args[3:], "--dry-run")); err != nil {
+			log.Fatal("Unable to run ", os.Args[1], ": ", err)
+		}
+
+		return
+	}
+
 	router := gin.Default()
 
 	err := router.SetTrustedProxies(nil)
@@ -34,12 +68,7 @@ func main() {
 	router.Use(config.CORSMiddleware())
 	router.Use(config.RateLimit(100))
 
-	err = godotenv.Load()
-	if err != nil {
-		// If .env file is not found, it is not necessarily an error.
-		// With Render, environment variables are injected; there is no need for .env file.
-		log.Print("Unable to find .env file: ", err)
-	}
+	loadEnv()
 
 	router.GET("/status", func(context *gin.Context) {
 		context.JSON(http.StatusOK, gin.H{"message": "OK"})
@@ -57,26 +86,125 @@ func main() {
 	routes.ProductRoutes(router)
 	routes.MenuRoutes(router)
 	routes.OrderRoutes(router)
+	routes.KitchenStationRoutes(router)
+	routes.RestaurantRoutes(router)
+	routes.CustomerRoutes(router)
+	routes.WebhookRoutes(router)
+	routes.CatalogRoutes(router)
 
 	config.ConnectDB()
 	config.ConnectCloudinary()
 
-	err = config.DB.AutoMigrate(
+	err = models.RegisterRestaurantScope(config.DB)
+	if err != nil {
+		log.Fatal("Unable to register restaurant scope: ", err)
+	}
+
+	migrateDB()
+
+	jobs.ScheduleOrderRetention(config.DB)
+	jobs.RegisterEventSubscribers()
+	jobs.ScheduleOutboxDispatch(config.DB)
+	jobs.ScheduleWebhookDispatch(config.DB)
+	jobs.SchedulePriceChanges(config.DB)
+
+	err = router.Run(":8080")
+	if err != nil {
+		log.Fatal("Unable to start server: ", err)
+	}
+
+	fmt.Println("Server started on http://localhost:8080.")
+}
+
+func loadEnv() {
+	err := godotenv.Load()
+	if err != nil {
+		// If .env file is not found, it is not necessarily an error.
+		// With Render, environment variables are injected; there is no need for .env file.
+		log.Print("Unable to find .env file: ", err)
+	}
+}
+
+func migrateDB() {
+	err := config.DB.AutoMigrate(
+		&models.Restaurant{},
+		&models.OpeningHour{},
+		&models.Closure{},
 		&models.User{},
+		&models.KitchenStation{},
 		&models.ProductCategory{},
 		&models.Product{},
+		&models.ProductVariant{},
 		&models.Menu{},
+		&models.Customer{},
 		&models.Order{},
 		&models.OrderItem{},
+		&models.OrderStationItem{},
+		&models.ArchivedOrder{},
+		&models.OrderStatusHistory{},
+		&models.OrderAmendment{},
+		&models.OrderAmendmentLine{},
+		&models.CatalogAvailability{},
+		&models.AvailabilitySlot{},
+		&models.LoyaltyTransaction{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.PriceChange{},
 	)
 	if err != nil {
 		log.Fatal("Unable to auto migrate: ", err)
 	}
 
-	err = router.Run(":8080")
+	if err = models.AssignDefaultRestaurant(config.DB); err != nil {
+		log.Fatal("Unable to assign default restaurant: ", err)
+	}
+}
+
+// runCatalogCommand exports the catalog to the file, or imports it from the file, in the format given by its extension.
+func runCatalogCommand(command string, path string, dryRun bool) error {
+	format, err := models.ParseCatalogFormat(strings.TrimPrefix(filepath.Ext(path), "."))
 	if err != nil {
-		log.Fatal("Unable to start server: ", err)
+		return err
 	}
 
-	fmt.Println("Server started on http://localhost:8080.")
+	if command == "export-catalog" {
+		catalog, err := models.ExportCatalog(config.DB)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		return models.EncodeCatalog(file, catalog, format)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	catalog, err := models.DecodeCatalog(file, format)
+	if err != nil {
+		return err
+	}
+
+	report, err := models.ImportCatalog(config.DB, catalog, 0, dryRun, time.Now())
+	if err != nil {
+		return err
+	}
+
+	output, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(output))
+
+	if len(report.Errors) > 0 {
+		return errors.New("the catalog has errors, nothing was imported")
+	}
+
+	return nil
 }
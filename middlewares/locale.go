@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale reads the languages accepted by the client from the Accept-Language header, for the catalog content
+// to be translated.
+func Locale() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if locales := models.ParseAcceptLanguage(context.GetHeader("Accept-Language")); len(locales) > 0 {
+			context.Set(models.LocalesContextKey, locales)
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"wacdo/config"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Restaurant resolves the restaurant the request applies to: the user's own restaurant,
+// or, for an administrator, one of the restaurants it manages given in the X-Restaurant-ID header.
+func Restaurant() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		userID := GetUserId(context)
+		if userID == nil {
+			context.Abort()
+
+			return
+		}
+
+		var user models.User
+		if err := config.DB.Preload("Restaurants").First(&user, *userID).Error; err != nil {
+			// The token of a deleted user cannot be used anymore.
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized."})
+
+				return
+			}
+
+			context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Unable to get user."})
+
+			return
+		}
+
+		restaurantIDs := user.RestaurantIDs()
+		restaurantID := user.RestaurantID
+
+		if restaurantHeader := context.GetHeader(models.RestaurantHeader); restaurantHeader != "" {
+			id, err := strconv.Atoi(restaurantHeader)
+			if err != nil || id <= 0 {
+				context.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid restaurant ID."})
+
+				return
+			}
+
+			restaurantID = uint(id)
+		}
+
+		if !slices.Contains(restaurantIDs, restaurantID) {
+			context.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access to this restaurant not allowed."})
+
+			return
+		}
+
+		context.Set(models.RestaurantIdContextKey, restaurantID)
+		context.Set(models.RestaurantIdsContextKey, restaurantIDs)
+
+		context.Next()
+	}
+}
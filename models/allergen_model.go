@@ -0,0 +1,119 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Allergen is one of the 14 major allergens that must be declared to customers.
+type Allergen string
+
+const (
+	Gluten      Allergen = "gluten"
+	Crustaceans Allergen = "crustaceans"
+	Eggs        Allergen = "eggs"
+	Fish        Allergen = "fish"
+	Peanuts     Allergen = "peanuts"
+	Soybeans    Allergen = "soybeans"
+	Milk        Allergen = "milk"
+	Nuts        Allergen = "nuts"
+	Celery      Allergen = "celery"
+	Mustard     Allergen = "mustard"
+	Sesame      Allergen = "sesame"
+	Sulphites   Allergen = "sulphites"
+	Lupin       Allergen = "lupin"
+	Molluscs    Allergen = "molluscs"
+)
+
+func (allergen Allergen) IsValid() bool {
+	switch allergen {
+	case Gluten, Crustaceans, Eggs, Fish, Peanuts, Soybeans, Milk, Nuts, Celery, Mustard, Sesame, Sulphites, Lupin, Molluscs:
+		return true
+	}
+
+	return false
+}
+
+// Allergens is stored as a comma-delimited string (",gluten,milk,"),
+// so that a single allergen can be searched with a portable LIKE condition.
+type Allergens []Allergen
+
+func (allergens Allergens) IsValid() bool {
+	for _, allergen := range allergens {
+		if !allergen.IsValid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MergeAllergens returns the sorted union of several allergen lists.
+func MergeAllergens(lists ...Allergens) Allergens {
+	merged := Allergens{}
+
+	for _, list := range lists {
+		for _, allergen := range list {
+			if !slices.Contains(merged, allergen) {
+				merged = append(merged, allergen)
+			}
+		}
+	}
+
+	slices.Sort(merged)
+
+	return merged
+}
+
+func (allergens Allergens) GormDataType() string {
+	return "string"
+}
+
+func (allergens Allergens) Value() (driver.Value, error) {
+	if len(allergens) == 0 {
+		return "", nil
+	}
+
+	values := make([]string, 0, len(allergens))
+	for _, allergen := range allergens {
+		values = append(values, string(allergen))
+	}
+
+	return "," + strings.Join(values, ",") + ",", nil
+}
+
+func (allergens *Allergens) Scan(value interface{}) error {
+	var stored string
+
+	switch typedValue := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = typedValue
+	case []byte:
+		stored = string(typedValue)
+	default:
+		return fmt.Errorf("unable to scan allergens from %T", value)
+	}
+
+	*allergens = Allergens{}
+
+	for _, allergen := range strings.Split(stored, ",") {
+		if allergen != "" {
+			*allergens = append(*allergens, Allergen(allergen))
+		}
+	}
+
+	return nil
+}
+
+func (allergens Allergens) MarshalJSON() ([]byte, error) {
+	if allergens == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]Allergen(allergens))
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ArchivedOrder keeps a purged order: the main columns for searching,
+// and the whole order with its items, its amendments and its status history as JSON.
+type ArchivedOrder struct {
+	ID           uint `gorm:"primaryKey;autoIncrement:false"`
+	Status       OrderStatus
+	TicketNumber string
+	RestaurantID uint `gorm:"index"`
+	UserID       uint
+	TotalPrice   float64
+	CreatedAt    time.Time
+	Payload      string
+	ArchivedAt   time.Time
+}
+
+// ArchivedOrderPayload is the archived JSON of an order.
+type ArchivedOrderPayload struct {
+	OrderOutput
+	StatusHistory []OrderStatusHistory
+}
+
+func (ArchivedOrder) RestaurantScope(table string, restaurantID uint) clause.Expression {
+	return restaurantColumnScope(table, restaurantID)
+}
@@ -0,0 +1,281 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AvailabilitySlot is a time range of a day of the week (0 for Sunday) during which a product, a menu,
+// or the products of a category can be ordered. An item without slots can be ordered at any time.
+type AvailabilitySlot struct {
+	ID                uint  `gorm:"primaryKey"`
+	ProductID         *uint `gorm:"index"`
+	MenuID            *uint `gorm:"index"`
+	ProductCategoryID *uint `gorm:"index"`
+	DayOfWeek         int
+	StartsAt          string
+	EndsAt            string
+}
+
+const availabilitySlotsKey = "availabilitySlots"
+
+type AvailabilitySlotInput struct {
+	DayOfWeek *int   `json:"dayOfWeek" binding:"required"`
+	StartsAt  string `json:"startsAt" binding:"required"`
+	EndsAt    string `json:"endsAt" binding:"required"`
+}
+
+type AvailabilityScheduleInput struct {
+	Slots []AvailabilitySlotInput `json:"slots" binding:"dive"`
+}
+
+// ToAvailabilitySlot validates the slot input.
+func (input AvailabilitySlotInput) ToAvailabilitySlot() (AvailabilitySlot, error) {
+	if *input.DayOfWeek < 0 || *input.DayOfWeek > 6 {
+		return AvailabilitySlot{}, errors.New("Invalid day of week.")
+	}
+
+	startsAt, err := time.Parse(openingHourLayout, input.StartsAt)
+	if err != nil {
+		return AvailabilitySlot{}, errors.New("Invalid start time.")
+	}
+
+	endsAt, err := time.Parse(openingHourLayout, input.EndsAt)
+	if err != nil {
+		return AvailabilitySlot{}, errors.New("Invalid end time.")
+	}
+
+	if startsAt.Equal(endsAt) {
+		return AvailabilitySlot{}, errors.New("Start and end times must be different.")
+	}
+
+	return AvailabilitySlot{
+		DayOfWeek: *input.DayOfWeek,
+		StartsAt:  startsAt.Format(openingHourLayout),
+		EndsAt:    endsAt.Format(openingHourLayout),
+	}, nil
+}
+
+// ReplaceAvailabilitySchedule replaces the slots of the product, menu or category whose ID is in the given column.
+func ReplaceAvailabilitySchedule(context *gin.Context, column string, id uint, input AvailabilityScheduleInput) ([]AvailabilitySlot, bool) {
+	slots := make([]AvailabilitySlot, 0, len(input.Slots))
+
+	for _, slotInput := range input.Slots {
+		slot, err := slotInput.ToAvailabilitySlot()
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return nil, false
+		}
+
+		switch column {
+		case "product_id":
+			slot.ProductID = &id
+		case "menu_id":
+			slot.MenuID = &id
+		default:
+			slot.ProductCategoryID = &id
+		}
+
+		slots = append(slots, slot)
+	}
+
+	err := config.DB.WithContext(context).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(column+" = ?", id).Delete(&AvailabilitySlot{}).Error; err != nil {
+			return err
+		}
+
+		if len(slots) == 0 {
+			return nil
+		}
+
+		return tx.Create(&slots).Error
+	})
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update availability schedule."})
+
+		return nil, false
+	}
+
+	return slots, true
+}
+
+func (slot *AvailabilitySlot) includes(local time.Time) bool {
+	startsAt, errStart := time.Parse(openingHourLayout, slot.StartsAt)
+	endsAt, errEnd := time.Parse(openingHourLayout, slot.EndsAt)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		if slot.DayOfWeek != int(day.Weekday()) {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), startsAt.Hour(), startsAt.Minute(), 0, 0, day.Location())
+		end := time.Date(day.Year(), day.Month(), day.Day(), endsAt.Hour(), endsAt.Minute(), 0, 0, day.Location())
+
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+
+		if !local.Before(start) && local.Before(end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsScheduledAt tells whether the slots allow ordering at the given moment, in the given time zone.
+func IsScheduledAt(slots []AvailabilitySlot, moment time.Time, location *time.Location) bool {
+	if len(slots) == 0 {
+		return true
+	}
+
+	local := moment.In(location)
+
+	for index := range slots {
+		if slots[index].includes(local) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findAvailabilitySlots loads the slots of all the rows found by a query of a request at once.
+func findAvailabilitySlots(tx *gorm.DB, query string, fields ...string) ([]AvailabilitySlot, bool, error) {
+	if _, ok := GetRestaurantId(tx.Statement.Context); !ok {
+		return nil, false, nil
+	}
+
+	if slots, ok := tx.Statement.Settings.Load(availabilitySlotsKey); ok {
+		return slots.([]AvailabilitySlot), true, nil
+	}
+
+	args := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		args = append(args, foundValues(tx, field))
+	}
+
+	var slots []AvailabilitySlot
+	if err := tx.Session(&gorm.Session{NewDB: true}).Where(query, args...).Order("day_of_week, starts_at").Find(&slots).Error; err != nil {
+		return nil, false, err
+	}
+
+	tx.Statement.Settings.Store(availabilitySlotsKey, slots)
+
+	return slots, true, nil
+}
+
+func resetFoundAvailabilities(db *gorm.DB) {
+	db.Statement.Settings.Delete(availabilitySlotsKey)
+	db.Statement.Settings.Delete(catalogAvailabilitiesKey)
+}
+
+// foundValues returns the values of a field of the rows found by the query.
+func foundValues(tx *gorm.DB, name string) []interface{} {
+	values := make([]interface{}, 0)
+
+	field := tx.Statement.Schema.LookUpField(name)
+	if field == nil {
+		return values
+	}
+
+	collect := func(row reflect.Value) {
+		if value, isZero := field.ValueOf(tx.Statement.Context, row); !isZero {
+			values = append(values, value)
+		}
+	}
+
+	switch tx.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < tx.Statement.ReflectValue.Len(); index++ {
+			collect(reflect.Indirect(tx.Statement.ReflectValue.Index(index)))
+		}
+	case reflect.Struct:
+		collect(tx.Statement.ReflectValue)
+	}
+
+	return values
+}
+
+func (product *Product) applyAvailabilitySchedule(tx *gorm.DB) error {
+	slots, ok, err := findAvailabilitySlots(tx, "product_id IN ? OR product_category_id IN ?", "ID", "CategoryID")
+	if !ok {
+		return err
+	}
+
+	product.AvailabilitySlots = make([]AvailabilitySlot, 0)
+	product.categorySlots = make([]AvailabilitySlot, 0)
+
+	for _, slot := range slots {
+		if slot.ProductID != nil && *slot.ProductID == product.ID {
+			product.AvailabilitySlots = append(product.AvailabilitySlots, slot)
+		} else if slot.ProductCategoryID != nil && *slot.ProductCategoryID == product.CategoryID {
+			product.categorySlots = append(product.categorySlots, slot)
+		}
+	}
+
+	product.IsAvailableNow = product.IsAvailableAt(time.Now(), RestaurantLocation(tx.Statement.Context))
+
+	return nil
+}
+
+// IsAvailableAt tells whether the product can be ordered at the given moment, its category slots applying too.
+func (product *Product) IsAvailableAt(moment time.Time, location *time.Location) bool {
+	return product.IsAvailable && IsScheduledAt(product.AvailabilitySlots, moment, location) && IsScheduledAt(product.categorySlots, moment, location)
+}
+
+func (menu *Menu) applyAvailabilitySchedule(tx *gorm.DB) error {
+	slots, ok, err := findAvailabilitySlots(tx, "menu_id IN ?", "ID")
+	if !ok {
+		return err
+	}
+
+	menu.AvailabilitySlots = make([]AvailabilitySlot, 0)
+
+	for _, slot := range slots {
+		if slot.MenuID != nil && *slot.MenuID == menu.ID {
+			menu.AvailabilitySlots = append(menu.AvailabilitySlots, slot)
+		}
+	}
+
+	menu.IsAvailableNow = menu.IsAvailableAt(time.Now(), RestaurantLocation(tx.Statement.Context))
+
+	return nil
+}
+
+// IsAvailableAt tells whether the menu can be ordered at the given moment.
+func (menu *Menu) IsAvailableAt(moment time.Time, location *time.Location) bool {
+	return menu.IsAvailable && IsScheduledAt(menu.AvailabilitySlots, moment, location)
+}
+
+func (productCategory *ProductCategory) AfterFind(tx *gorm.DB) error {
+	productCategory.defaultName = productCategory.Name
+	productCategory.Locale = localize(tx.Statement.Context, productCategory.Translations, &productCategory.Name, &productCategory.Description)
+
+	slots, ok, err := findAvailabilitySlots(tx, "product_category_id IN ?", "ID")
+	if !ok {
+		return err
+	}
+
+	productCategory.AvailabilitySlots = make([]AvailabilitySlot, 0)
+
+	for _, slot := range slots {
+		if slot.ProductCategoryID != nil && *slot.ProductCategoryID == productCategory.ID {
+			productCategory.AvailabilitySlots = append(productCategory.AvailabilitySlots, slot)
+		}
+	}
+
+	productCategory.IsAvailableNow = IsScheduledAt(productCategory.AvailabilitySlots, time.Now(), RestaurantLocation(tx.Statement.Context))
+
+	return nil
+}
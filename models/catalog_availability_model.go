@@ -0,0 +1,83 @@
+package models
+
+import (
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CatalogAvailability overrides, for one restaurant, the availability of a product or of a menu.
+type CatalogAvailability struct {
+	ID           uint `gorm:"primaryKey"`
+	RestaurantID uint `gorm:"index"`
+	ProductID    *uint
+	MenuID       *uint
+	IsAvailable  bool
+}
+
+type CatalogAvailabilityInput struct {
+	IsAvailable *bool `json:"isAvailable" binding:"required"`
+}
+
+func (CatalogAvailability) RestaurantScope(table string, restaurantID uint) clause.Expression {
+	return restaurantColumnScope(table, restaurantID)
+}
+
+const catalogAvailabilitiesKey = "catalogAvailabilities"
+
+// applyCatalogAvailability replaces the availability of a catalog item
+// by the one defined for the restaurant of the query, if any.
+func applyCatalogAvailability(tx *gorm.DB, column string, id uint, isAvailable *bool) error {
+	if _, ok := GetRestaurantId(tx.Statement.Context); !ok {
+		return nil
+	}
+
+	cached, ok := tx.Statement.Settings.Load(catalogAvailabilitiesKey)
+	if !ok {
+		var availabilities []CatalogAvailability
+		if err := tx.Session(&gorm.Session{NewDB: true}).Where(column+" IN ?", foundValues(tx, "ID")).Find(&availabilities).Error; err != nil {
+			return err
+		}
+
+		cached = availabilities
+		tx.Statement.Settings.Store(catalogAvailabilitiesKey, cached)
+	}
+
+	for _, availability := range cached.([]CatalogAvailability) {
+		itemID := availability.MenuID
+		if column == "product_id" {
+			itemID = availability.ProductID
+		}
+
+		if itemID != nil && *itemID == id {
+			*isAvailable = availability.IsAvailable
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// SaveCatalogAvailability defines the availability of a product or of a menu in the restaurant of the request.
+func SaveCatalogAvailability(context *gin.Context, column string, id uint, isAvailable bool) error {
+	var availability CatalogAvailability
+	if err := config.DB.WithContext(context).Where(column+" = ?", id).Limit(1).Find(&availability).Error; err != nil {
+		return err
+	}
+
+	if availability.ID != 0 {
+		return config.DB.WithContext(context).Model(&availability).Update("is_available", isAvailable).Error
+	}
+
+	availability.IsAvailable = isAvailable
+	if column == "product_id" {
+		availability.ProductID = &id
+	} else {
+		availability.MenuID = &id
+	}
+
+	return config.DB.WithContext(context).Create(&availability).Error
+}
@@ -0,0 +1,371 @@
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The CSV catalog has a row per category, product, variant and menu, told apart by the "type" column.
+// A variant row follows the key of its product, and lists are separated by "|".
+var catalogCSVColumns = []string{
+	"type", "key", "categoryKey", "name", "description", "image", "price", "loyaltyPoints", "isAvailable", "kitchenStationID",
+	"allergens", "isVegetarian", "calories", "proteins", "carbohydrates", "sugars", "fats", "saturatedFats", "salt",
+	"sku", "menuSupplement", "productKeys",
+}
+
+const catalogCSVListSeparator = "|"
+
+func writeCatalogCSV(writer io.Writer, catalog *Catalog) error {
+	csvWriter := csv.NewWriter(writer)
+
+	rows := [][]string{catalogCSVColumns}
+
+	for _, category := range catalog.Categories {
+		rows = append(rows, catalogCSVRow(map[string]string{
+			"type":             "category",
+			"key":              category.Key,
+			"name":             category.Name,
+			"description":      category.Description,
+			"kitchenStationID": formatCatalogID(category.KitchenStationID),
+		}))
+	}
+
+	for _, product := range catalog.Products {
+		allergens := make([]string, 0, len(product.Allergens))
+		for _, allergen := range product.Allergens {
+			allergens = append(allergens, string(allergen))
+		}
+
+		rows = append(rows, catalogCSVRow(map[string]string{
+			"type":             "product",
+			"key":              product.Key,
+			"categoryKey":      product.CategoryKey,
+			"name":             product.Name,
+			"description":      product.Description,
+			"image":            product.Image,
+			"price":            formatCatalogFloat(product.Price),
+			"loyaltyPoints":    strconv.Itoa(product.LoyaltyPoints),
+			"isAvailable":      strconv.FormatBool(product.IsAvailable),
+			"kitchenStationID": formatCatalogID(product.KitchenStationID),
+			"allergens":        strings.Join(allergens, catalogCSVListSeparator),
+			"isVegetarian":     strconv.FormatBool(product.IsVegetarian),
+			"calories":         formatCatalogFloat(product.Nutrition.Calories),
+			"proteins":         formatCatalogFloat(product.Nutrition.Proteins),
+			"carbohydrates":    formatCatalogFloat(product.Nutrition.Carbohydrates),
+			"sugars":           formatCatalogFloat(product.Nutrition.Sugars),
+			"fats":             formatCatalogFloat(product.Nutrition.Fats),
+			"saturatedFats":    formatCatalogFloat(product.Nutrition.SaturatedFats),
+			"salt":             formatCatalogFloat(product.Nutrition.Salt),
+		}))
+
+		for _, variant := range product.Variants {
+			rows = append(rows, catalogCSVRow(map[string]string{
+				"type":           "variant",
+				"key":            product.Key,
+				"name":           variant.Name,
+				"price":          formatCatalogFloat(variant.Price),
+				"isAvailable":    strconv.FormatBool(variant.IsAvailable),
+				"sku":            variant.SKU,
+				"menuSupplement": formatCatalogFloat(variant.MenuSupplement),
+			}))
+		}
+	}
+
+	for _, menu := range catalog.Menus {
+		rows = append(rows, catalogCSVRow(map[string]string{
+			"type":          "menu",
+			"key":           menu.Key,
+			"name":          menu.Name,
+			"description":   menu.Description,
+			"image":         menu.Image,
+			"price":         formatCatalogFloat(menu.Price),
+			"loyaltyPoints": strconv.Itoa(menu.LoyaltyPoints),
+			"isAvailable":   strconv.FormatBool(menu.IsAvailable),
+			"productKeys":   strings.Join(menu.ProductKeys, catalogCSVListSeparator),
+		}))
+	}
+
+	if err := csvWriter.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return csvWriter.Error()
+}
+
+func catalogCSVRow(values map[string]string) []string {
+	row := make([]string, len(catalogCSVColumns))
+	for index, column := range catalogCSVColumns {
+		row[index] = values[column]
+	}
+
+	return row
+}
+
+func formatCatalogFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatCatalogID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// catalogCSVRecord reads the values of a row by column name, the columns being in any order and optional but "type".
+type catalogCSVRecord struct {
+	line    int
+	columns map[string]int
+	values  []string
+}
+
+func (record catalogCSVRecord) get(column string) string {
+	index, ok := record.columns[column]
+	if !ok || index >= len(record.values) {
+		return ""
+	}
+
+	return strings.TrimSpace(record.values[index])
+}
+
+func (record catalogCSVRecord) list(column string) []string {
+	values := []string{}
+
+	for _, value := range strings.Split(record.get(column), catalogCSVListSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func (record catalogCSVRecord) float(column string) (float64, error) {
+	value := record.get(column)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Line %d: invalid %s.", record.line, column)
+	}
+
+	return number, nil
+}
+
+func (record catalogCSVRecord) int(column string) (int, error) {
+	value := record.get(column)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("Line %d: invalid %s.", record.line, column)
+	}
+
+	return number, nil
+}
+
+func (record catalogCSVRecord) bool(column string) (bool, error) {
+	value := record.get(column)
+	if value == "" {
+		return false, nil
+	}
+
+	boolean, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("Line %d: invalid %s.", record.line, column)
+	}
+
+	return boolean, nil
+}
+
+func (record catalogCSVRecord) id(column string) (*uint, error) {
+	value := record.get(column)
+	if value == "" {
+		return nil, nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("Line %d: invalid %s.", record.line, column)
+	}
+
+	id := uint(number)
+
+	return &id, nil
+}
+
+func readCatalogCSV(reader io.Reader) (*Catalog, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	rows, err := csvReader.ReadAll()
+	if err != nil || len(rows) == 0 {
+		return nil, errors.New("Invalid CSV.")
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for index, column := range rows[0] {
+		columns[strings.TrimSpace(column)] = index
+	}
+
+	if _, ok := columns["type"]; !ok {
+		return nil, errors.New("Missing type column.")
+	}
+
+	catalog := &Catalog{Categories: []CatalogCategory{}, Products: []CatalogProduct{}, Menus: []CatalogMenu{}}
+	products := map[string]int{} // Position of the products by key, to attach their variants
+
+	for index, values := range rows[1:] {
+		record := catalogCSVRecord{line: index + 2, columns: columns, values: values}
+
+		var err error
+		switch record.get("type") {
+		case "category":
+			err = readCatalogCSVCategory(catalog, record)
+		case "product":
+			products[record.get("key")] = len(catalog.Products)
+			err = readCatalogCSVProduct(catalog, record)
+		case "variant":
+			position, ok := products[record.get("key")]
+			if !ok {
+				return nil, fmt.Errorf("Line %d: product %s not found.", record.line, record.get("key"))
+			}
+
+			err = readCatalogCSVVariant(&catalog.Products[position], record)
+		case "menu":
+			err = readCatalogCSVMenu(catalog, record)
+		default:
+			err = fmt.Errorf("Line %d: invalid type.", record.line)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return catalog, nil
+}
+
+func readCatalogCSVCategory(catalog *Catalog, record catalogCSVRecord) error {
+	kitchenStationID, err := record.id("kitchenStationID")
+	if err != nil {
+		return err
+	}
+
+	catalog.Categories = append(catalog.Categories, CatalogCategory{
+		Key:              record.get("key"),
+		Name:             record.get("name"),
+		Description:      record.get("description"),
+		KitchenStationID: kitchenStationID,
+	})
+
+	return nil
+}
+
+func readCatalogCSVProduct(catalog *Catalog, record catalogCSVRecord) error {
+	product := CatalogProduct{
+		Key:         record.get("key"),
+		CategoryKey: record.get("categoryKey"),
+		Name:        record.get("name"),
+		Description: record.get("description"),
+		Image:       record.get("image"),
+		Allergens:   Allergens{},
+		Variants:    []CatalogVariant{},
+	}
+
+	for _, allergen := range record.list("allergens") {
+		product.Allergens = append(product.Allergens, Allergen(allergen))
+	}
+
+	var err error
+	if product.Price, err = record.float("price"); err != nil {
+		return err
+	}
+
+	if product.LoyaltyPoints, err = record.int("loyaltyPoints"); err != nil {
+		return err
+	}
+
+	if product.IsAvailable, err = record.bool("isAvailable"); err != nil {
+		return err
+	}
+
+	if product.KitchenStationID, err = record.id("kitchenStationID"); err != nil {
+		return err
+	}
+
+	if product.IsVegetarian, err = record.bool("isVegetarian"); err != nil {
+		return err
+	}
+
+	nutrition := []*float64{
+		&product.Nutrition.Calories, &product.Nutrition.Proteins, &product.Nutrition.Carbohydrates, &product.Nutrition.Sugars,
+		&product.Nutrition.Fats, &product.Nutrition.SaturatedFats, &product.Nutrition.Salt,
+	}
+	for index, column := range []string{"calories", "proteins", "carbohydrates", "sugars", "fats", "saturatedFats", "salt"} {
+		if *nutrition[index], err = record.float(column); err != nil {
+			return err
+		}
+	}
+
+	catalog.Products = append(catalog.Products, product)
+
+	return nil
+}
+
+func readCatalogCSVVariant(product *CatalogProduct, record catalogCSVRecord) error {
+	variant := CatalogVariant{SKU: record.get("sku"), Name: record.get("name")}
+
+	var err error
+	if variant.Price, err = record.float("price"); err != nil {
+		return err
+	}
+
+	if variant.MenuSupplement, err = record.float("menuSupplement"); err != nil {
+		return err
+	}
+
+	if variant.IsAvailable, err = record.bool("isAvailable"); err != nil {
+		return err
+	}
+
+	product.Variants = append(product.Variants, variant)
+
+	return nil
+}
+
+func readCatalogCSVMenu(catalog *Catalog, record catalogCSVRecord) error {
+	menu := CatalogMenu{
+		Key:         record.get("key"),
+		Name:        record.get("name"),
+		Description: record.get("description"),
+		Image:       record.get("image"),
+		ProductKeys: record.list("productKeys"),
+	}
+
+	var err error
+	if menu.Price, err = record.float("price"); err != nil {
+		return err
+	}
+
+	if menu.LoyaltyPoints, err = record.int("loyaltyPoints"); err != nil {
+		return err
+	}
+
+	if menu.IsAvailable, err = record.bool("isAvailable"); err != nil {
+		return err
+	}
+
+	catalog.Menus = append(catalog.Menus, menu)
+
+	return nil
+}
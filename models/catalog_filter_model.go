@@ -0,0 +1,96 @@
+package models
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CatalogFilters are the filters of the product and menu lists, besides the dietary ones.
+type CatalogFilters struct {
+	CategoryID *uint
+	Available  *bool
+	MinPrice   *float64
+	MaxPrice   *float64
+}
+
+// ParseCatalogFilters reads the categoryID, available, minPrice and maxPrice query parameters.
+func ParseCatalogFilters(context *gin.Context) (filters *CatalogFilters, ok bool) {
+	filters = &CatalogFilters{}
+
+	if categoryID := context.Query("categoryID"); categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 0)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid categoryID filter."})
+
+			return nil, false
+		}
+
+		value := uint(id)
+		filters.CategoryID = &value
+	}
+
+	if available := context.Query("available"); available != "" {
+		value, err := strconv.ParseBool(available)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid available filter."})
+
+			return nil, false
+		}
+
+		filters.Available = &value
+	}
+
+	for name, price := range map[string]**float64{"minPrice": &filters.MinPrice, "maxPrice": &filters.MaxPrice} {
+		if param := context.Query(name); param != "" {
+			value, err := strconv.ParseFloat(param, 64)
+			if err != nil || value < 0 {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " filter."})
+
+				return nil, false
+			}
+
+			*price = &value
+		}
+	}
+
+	return filters, true
+}
+
+// ApplyToProducts filters the products by category, price and availability.
+func (filters *CatalogFilters) ApplyToProducts(context *gin.Context, query *gorm.DB) *gorm.DB {
+	if filters.CategoryID != nil {
+		query = query.Where("products.category_id = ?", *filters.CategoryID)
+	}
+
+	return filters.apply(context, query, "products", "product_id")
+}
+
+// ApplyToMenus filters the menus, the category filter not applying to them.
+func (filters *CatalogFilters) ApplyToMenus(context *gin.Context, query *gorm.DB) *gorm.DB {
+	return filters.apply(context, query, "menus", "menu_id")
+}
+
+// apply filters the price and the availability, as overridden for the restaurant of the request.
+func (filters *CatalogFilters) apply(context *gin.Context, query *gorm.DB, table string, availabilityColumn string) *gorm.DB {
+	if filters.MinPrice != nil {
+		query = query.Where(table+".price >= ?", *filters.MinPrice)
+	}
+
+	if filters.MaxPrice != nil {
+		query = query.Where(table+".price <= ?", *filters.MaxPrice)
+	}
+
+	if filters.Available != nil {
+		restaurantID, _ := GetRestaurantId(context)
+
+		query = query.Where(
+			"COALESCE((SELECT catalog_availabilities.is_available FROM catalog_availabilities WHERE catalog_availabilities."+availabilityColumn+" = "+table+".id AND catalog_availabilities.restaurant_id = ? LIMIT 1), "+table+".is_available) = ?",
+			restaurantID, *filters.Available,
+		)
+	}
+
+	return query
+}
@@ -0,0 +1,627 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CatalogJSON = "json"
+	CatalogCSV  = "csv"
+)
+
+// Catalog is the whole catalog exchanged by the export and the import: the categories, the products with their variants,
+// and the menus, each of them being identified by its external key.
+type Catalog struct {
+	Categories []CatalogCategory `json:"categories"`
+	Products   []CatalogProduct  `json:"products"`
+	Menus      []CatalogMenu     `json:"menus"`
+}
+
+type CatalogCategory struct {
+	Key              string `json:"key"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	KitchenStationID *uint  `json:"kitchenStationID"`
+}
+
+type CatalogProduct struct {
+	Key              string           `json:"key"`
+	CategoryKey      string           `json:"categoryKey"` // Category of the catalog, or already in the database
+	Name             string           `json:"name"`
+	Description      string           `json:"description"`
+	Image            string           `json:"image"` // URL of the image, kept as is
+	Price            float64          `json:"price"`
+	LoyaltyPoints    int              `json:"loyaltyPoints"`
+	IsAvailable      bool             `json:"isAvailable"`
+	KitchenStationID *uint            `json:"kitchenStationID"`
+	Allergens        Allergens        `json:"allergens"`
+	IsVegetarian     bool             `json:"isVegetarian"`
+	Nutrition        NutritionInput   `json:"nutrition"`
+	Variants         []CatalogVariant `json:"variants"` // Matched by SKU, the variants missing from the catalog being kept
+}
+
+type CatalogVariant struct {
+	SKU            string  `json:"sku"`
+	Name           string  `json:"name"`
+	Price          float64 `json:"price"`
+	MenuSupplement float64 `json:"menuSupplement"`
+	IsAvailable    bool    `json:"isAvailable"`
+}
+
+type CatalogMenu struct {
+	Key           string   `json:"key"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Image         string   `json:"image"` // URL of the image, kept as is
+	Price         float64  `json:"price"`
+	LoyaltyPoints int      `json:"loyaltyPoints"`
+	IsAvailable   bool     `json:"isAvailable"`
+	ProductKeys   []string `json:"productKeys"` // Products of the catalog, or already in the database
+}
+
+type CatalogImportCount struct {
+	Created int
+	Updated int
+}
+
+type CatalogImportReport struct {
+	DryRun     bool
+	Categories CatalogImportCount
+	Products   CatalogImportCount
+	Variants   CatalogImportCount
+	Menus      CatalogImportCount
+	Errors     []string // Nothing is imported when the catalog has errors
+}
+
+// errCatalogRollback rolls back the import transaction of a dry run, or of a catalog with errors.
+var errCatalogRollback = errors.New("catalog import rolled back")
+
+// ParseCatalogFormat returns the format matching the given name, JSON by default.
+func ParseCatalogFormat(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "", CatalogJSON:
+		return CatalogJSON, nil
+	case CatalogCSV:
+		return CatalogCSV, nil
+	}
+
+	return "", errors.New("Invalid format.")
+}
+
+func EncodeCatalog(writer io.Writer, catalog *Catalog, format string) error {
+	if format == CatalogCSV {
+		return writeCatalogCSV(writer, catalog)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(catalog)
+}
+
+func DecodeCatalog(reader io.Reader, format string) (*Catalog, error) {
+	if format == CatalogCSV {
+		return readCatalogCSV(reader)
+	}
+
+	var catalog Catalog
+	if err := json.NewDecoder(reader).Decode(&catalog); err != nil {
+		return nil, errors.New("Invalid data.")
+	}
+
+	return &catalog, nil
+}
+
+// ExportCatalog returns the whole catalog, ordered by ID.
+// The items without an external key are given one first, such as "product-12", so that the export can be imported back.
+func ExportCatalog(db *gorm.DB) (*Catalog, error) {
+	catalog := &Catalog{Categories: []CatalogCategory{}, Products: []CatalogProduct{}, Menus: []CatalogMenu{}}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for table, prefix := range map[string]string{"product_categories": "category-", "products": "product-", "menus": "menu-"} {
+			if err := tx.Table(table).Where("external_key IS NULL").UpdateColumn("external_key", gorm.Expr("'"+prefix+"' || id")).Error; err != nil {
+				return err
+			}
+		}
+
+		var categories []ProductCategory
+		if err := tx.Order("id").Find(&categories).Error; err != nil {
+			return err
+		}
+
+		var products []Product
+		if err := tx.Preload("Category").Preload("Variants", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Order("id").Find(&products).Error; err != nil {
+			return err
+		}
+
+		var menus []Menu
+		if err := tx.Preload("Products", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Order("id").Find(&menus).Error; err != nil {
+			return err
+		}
+
+		for _, category := range categories {
+			catalog.Categories = append(catalog.Categories, CatalogCategory{
+				Key:              *category.ExternalKey,
+				Name:             category.Name,
+				Description:      category.Description,
+				KitchenStationID: category.KitchenStationID,
+			})
+		}
+
+		for _, product := range products {
+			exported := CatalogProduct{
+				Key:              *product.ExternalKey,
+				Name:             product.Name,
+				Description:      product.Description,
+				Image:            product.Image,
+				Price:            product.Price,
+				LoyaltyPoints:    product.LoyaltyPoints,
+				IsAvailable:      product.IsAvailable,
+				KitchenStationID: product.KitchenStationID,
+				Allergens:        product.Allergens,
+				IsVegetarian:     product.IsVegetarian,
+				Nutrition:        NutritionInput(product.Nutrition),
+				Variants:         []CatalogVariant{},
+			}
+
+			if product.Category.ExternalKey != nil {
+				exported.CategoryKey = *product.Category.ExternalKey
+			}
+
+			for _, variant := range product.Variants {
+				exported.Variants = append(exported.Variants, CatalogVariant{
+					SKU:            variant.SKU,
+					Name:           variant.Name,
+					Price:          variant.Price,
+					MenuSupplement: variant.MenuSupplement,
+					IsAvailable:    variant.IsAvailable,
+				})
+			}
+
+			catalog.Products = append(catalog.Products, exported)
+		}
+
+		for _, menu := range menus {
+			exported := CatalogMenu{
+				Key:           *menu.ExternalKey,
+				Name:          menu.Name,
+				Description:   menu.Description,
+				Image:         menu.Image,
+				Price:         menu.Price,
+				LoyaltyPoints: menu.LoyaltyPoints,
+				IsAvailable:   menu.IsAvailable,
+				ProductKeys:   []string{},
+			}
+
+			for _, product := range menu.Products {
+				exported.ProductKeys = append(exported.ProductKeys, *product.ExternalKey)
+			}
+
+			catalog.Menus = append(catalog.Menus, exported)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// ImportCatalog creates or updates the items of the catalog, matched by their external key, in a single transaction.
+// A deleted item is restored when it is imported again.
+// Every error of the catalog is reported, and nothing is imported when there is any.
+// A dry run validates the catalog and reports what would be imported, then rolls the transaction back.
+func ImportCatalog(db *gorm.DB, catalog *Catalog, userID uint, dryRun bool, now time.Time) (*CatalogImportReport, error) {
+	importer := catalogImporter{
+		report:      &CatalogImportReport{DryRun: dryRun, Errors: []string{}},
+		userID:      userID,
+		now:         now,
+		categoryIDs: map[string]uint{},
+		productIDs:  map[string]uint{},
+		skus:        map[string]bool{},
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		importer.tx = tx
+
+		if err := importer.importCategories(catalog.Categories); err != nil {
+			return err
+		}
+
+		if err := importer.importProducts(catalog.Products); err != nil {
+			return err
+		}
+
+		if err := importer.importMenus(catalog.Menus); err != nil {
+			return err
+		}
+
+		if dryRun || len(importer.report.Errors) > 0 {
+			return errCatalogRollback
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errCatalogRollback) {
+		return nil, err
+	}
+
+	return importer.report, nil
+}
+
+type catalogImporter struct {
+	tx          *gorm.DB
+	report      *CatalogImportReport
+	userID      uint
+	now         time.Time
+	categoryIDs map[string]uint // Imported categories by key
+	productIDs  map[string]uint // Imported products by key
+	skus        map[string]bool // Imported variant SKUs
+}
+
+func (importer *catalogImporter) fail(format string, args ...interface{}) {
+	importer.report.Errors = append(importer.report.Errors, fmt.Sprintf(format, args...))
+}
+
+// checkKey validates the key of the item at the given position, which must be unique among the items of its kind.
+func (importer *catalogImporter) checkKey(kind string, index int, key string, seen map[string]bool) (string, bool) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		importer.fail("%s %d: key is required.", kind, index+1)
+
+		return "", false
+	}
+
+	if seen[key] {
+		importer.fail("%s %s: key is used more than once.", kind, key)
+
+		return "", false
+	}
+
+	seen[key] = true
+
+	return key, true
+}
+
+func (importer *catalogImporter) checkKitchenStation(label string, id *uint) (*uint, error) {
+	if id == nil || *id == 0 {
+		return nil, nil
+	}
+
+	var count int64
+	if err := importer.tx.Model(&KitchenStation{}).Where("id = ?", *id).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		importer.fail("%s: kitchen station %d not found.", label, *id)
+	}
+
+	return id, nil
+}
+
+// findID returns the ID of the item of the given model with the given key, imported before or already in the database.
+func (importer *catalogImporter) findID(model interface{}, imported map[string]uint, key string) (uint, error) {
+	if id, ok := imported[key]; ok {
+		return id, nil
+	}
+
+	var ids []uint
+	if err := importer.tx.Model(model).Where("external_key = ?", key).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return ids[0], nil
+}
+
+// save creates the item, or updates it when it was found by its key, restoring it if it was deleted, and counts it.
+func (importer *catalogImporter) save(item interface{}, found bool, count *CatalogImportCount) error {
+	if found {
+		count.Updated++
+
+		return importer.tx.Unscoped().Omit(clause.Associations).Save(item).Error
+	}
+
+	count.Created++
+
+	return importer.tx.Omit(clause.Associations).Create(item).Error
+}
+
+func (importer *catalogImporter) importCategories(categories []CatalogCategory) error {
+	seen := map[string]bool{}
+
+	for index, input := range categories {
+		key, ok := importer.checkKey("Category", index, input.Key, seen)
+		if !ok {
+			continue
+		}
+
+		label := "Category " + key
+		errorCount := len(importer.report.Errors)
+
+		if strings.TrimSpace(input.Name) == "" {
+			importer.fail("%s: name is required.", label)
+		}
+
+		kitchenStationID, err := importer.checkKitchenStation(label, input.KitchenStationID)
+		if err != nil {
+			return err
+		}
+
+		if len(importer.report.Errors) > errorCount {
+			continue
+		}
+
+		var category ProductCategory
+		if err := importer.tx.Unscoped().Where("external_key = ?", key).Limit(1).Find(&category).Error; err != nil {
+			return err
+		}
+
+		found := category.ID != 0
+		category.DeletedAt = gorm.DeletedAt{}
+		category.ExternalKey = &key
+		category.Name = input.Name
+		category.Description = input.Description
+		category.KitchenStationID = kitchenStationID
+
+		if err := importer.save(&category, found, &importer.report.Categories); err != nil {
+			return err
+		}
+
+		importer.categoryIDs[key] = category.ID
+	}
+
+	return nil
+}
+
+func (importer *catalogImporter) importProducts(products []CatalogProduct) error {
+	seen := map[string]bool{}
+
+	for index, input := range products {
+		key, ok := importer.checkKey("Product", index, input.Key, seen)
+		if !ok {
+			continue
+		}
+
+		label := "Product " + key
+		errorCount := len(importer.report.Errors)
+
+		if strings.TrimSpace(input.Name) == "" {
+			importer.fail("%s: name is required.", label)
+		}
+
+		if input.Price <= 0 {
+			importer.fail("%s: price must be positive.", label)
+		}
+
+		if input.LoyaltyPoints < 0 {
+			importer.fail("%s: loyalty points must be positive.", label)
+		}
+
+		if !input.Allergens.IsValid() {
+			importer.fail("%s: invalid allergen.", label)
+		}
+
+		if input.Nutrition.hasNegativeValue() {
+			importer.fail("%s: nutrition values must be positive.", label)
+		}
+
+		kitchenStationID, err := importer.checkKitchenStation(label, input.KitchenStationID)
+		if err != nil {
+			return err
+		}
+
+		categoryID, err := importer.findID(&ProductCategory{}, importer.categoryIDs, strings.TrimSpace(input.CategoryKey))
+		if err != nil {
+			return err
+		}
+
+		if categoryID == 0 {
+			importer.fail("%s: category %s not found.", label, input.CategoryKey)
+		}
+
+		for _, variant := range input.Variants {
+			importer.checkVariant(label, variant)
+		}
+
+		if len(importer.report.Errors) > errorCount {
+			continue
+		}
+
+		var product Product
+		if err := importer.tx.Unscoped().Where("external_key = ?", key).Limit(1).Find(&product).Error; err != nil {
+			return err
+		}
+
+		found := product.ID != 0
+		product.DeletedAt = gorm.DeletedAt{}
+		previousPrice := product.Price
+
+		product.ExternalKey = &key
+		product.Name = input.Name
+		product.Description = input.Description
+		product.Image = input.Image
+		product.Price = input.Price
+		product.LoyaltyPoints = input.LoyaltyPoints
+		product.IsAvailable = input.IsAvailable
+		product.CategoryID = categoryID
+		product.KitchenStationID = kitchenStationID
+		product.Allergens = input.Allergens
+		product.IsVegetarian = input.IsVegetarian
+		product.Nutrition = input.Nutrition.ToNutrition()
+
+		if err := importer.save(&product, found, &importer.report.Products); err != nil {
+			return err
+		}
+
+		if product.Price != previousPrice {
+			if err := RecordPriceChange(importer.tx, &product, previousPrice, product.Price, importer.userID, importer.now); err != nil {
+				return err
+			}
+		}
+
+		for _, variant := range input.Variants {
+			if err := importer.importVariant(label, &product, variant); err != nil {
+				return err
+			}
+		}
+
+		importer.productIDs[key] = product.ID
+	}
+
+	return nil
+}
+
+func (importer *catalogImporter) checkVariant(label string, input CatalogVariant) {
+	sku := strings.TrimSpace(input.SKU)
+	if sku == "" {
+		importer.fail("%s: variant SKU is required.", label)
+
+		return
+	}
+
+	if importer.skus[sku] {
+		importer.fail("%s: SKU %s is used more than once.", label, sku)
+
+		return
+	}
+
+	importer.skus[sku] = true
+
+	if strings.TrimSpace(input.Name) == "" {
+		importer.fail("%s: variant %s: name is required.", label, sku)
+	}
+
+	if input.Price <= 0 {
+		importer.fail("%s: variant %s: price must be positive.", label, sku)
+	}
+
+	if input.MenuSupplement < 0 {
+		importer.fail("%s: variant %s: menu supplement must be positive.", label, sku)
+	}
+}
+
+func (importer *catalogImporter) importVariant(label string, product *Product, input CatalogVariant) error {
+	sku := strings.TrimSpace(input.SKU)
+
+	var variant ProductVariant
+	if err := importer.tx.Where("sku = ?", sku).Limit(1).Find(&variant).Error; err != nil {
+		return err
+	}
+
+	found := variant.ID != 0
+	if found && variant.ProductID != product.ID {
+		importer.fail("%s: SKU %s is already used by another product.", label, sku)
+
+		return nil
+	}
+
+	variant.ProductID = product.ID
+	variant.SKU = sku
+	variant.Name = input.Name
+	variant.Price = input.Price
+	variant.MenuSupplement = input.MenuSupplement
+	variant.IsAvailable = input.IsAvailable
+
+	return importer.save(&variant, found, &importer.report.Variants)
+}
+
+func (importer *catalogImporter) importMenus(menus []CatalogMenu) error {
+	seen := map[string]bool{}
+
+	for index, input := range menus {
+		key, ok := importer.checkKey("Menu", index, input.Key, seen)
+		if !ok {
+			continue
+		}
+
+		label := "Menu " + key
+		errorCount := len(importer.report.Errors)
+
+		if strings.TrimSpace(input.Name) == "" {
+			importer.fail("%s: name is required.", label)
+		}
+
+		if input.Price <= 0 {
+			importer.fail("%s: price must be positive.", label)
+		}
+
+		if input.LoyaltyPoints < 0 {
+			importer.fail("%s: loyalty points must be positive.", label)
+		}
+
+		if len(input.ProductKeys) == 0 {
+			importer.fail("%s: at least one product is required.", label)
+		}
+
+		productIDs := make([]uint, 0, len(input.ProductKeys))
+		for _, productKey := range input.ProductKeys {
+			productID, err := importer.findID(&Product{}, importer.productIDs, strings.TrimSpace(productKey))
+			if err != nil {
+				return err
+			}
+
+			if productID == 0 {
+				importer.fail("%s: product %s not found.", label, productKey)
+			}
+
+			productIDs = append(productIDs, productID)
+		}
+
+		if len(importer.report.Errors) > errorCount {
+			continue
+		}
+
+		var menu Menu
+		if err := importer.tx.Unscoped().Where("external_key = ?", key).Limit(1).Find(&menu).Error; err != nil {
+			return err
+		}
+
+		found := menu.ID != 0
+		menu.DeletedAt = gorm.DeletedAt{}
+		previousPrice := menu.Price
+
+		menu.ExternalKey = &key
+		menu.Name = input.Name
+		menu.Description = input.Description
+		menu.Image = input.Image
+		menu.Price = input.Price
+		menu.LoyaltyPoints = input.LoyaltyPoints
+		menu.IsAvailable = input.IsAvailable
+
+		if err := importer.save(&menu, found, &importer.report.Menus); err != nil {
+			return err
+		}
+
+		if menu.Price != previousPrice {
+			if err := RecordPriceChange(importer.tx, &menu, previousPrice, menu.Price, importer.userID, importer.now); err != nil {
+				return err
+			}
+		}
+
+		var products []Product
+		if err := importer.tx.Find(&products, productIDs).Error; err != nil {
+			return err
+		}
+
+		if err := importer.tx.Model(&menu).Association("Products").Replace(products); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
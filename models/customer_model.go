@@ -0,0 +1,154 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Customer is a customer known by their email or phone number, shared by every restaurant.
+type Customer struct {
+	ID               uint `gorm:"primaryKey"`
+	Name             string
+	Email            *string `gorm:"unique"`
+	Phone            *string `gorm:"unique"`
+	LoyaltyConsent   bool
+	MarketingConsent bool
+	LoyaltyPoints    int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type CustomerInsertInput struct {
+	Name             string  `json:"name"`
+	Email            *string `json:"email" binding:"omitempty,email"`
+	Phone            *string `json:"phone"`
+	LoyaltyConsent   bool    `json:"loyaltyConsent"`
+	MarketingConsent bool    `json:"marketingConsent"`
+}
+
+type CustomerUpdateInput struct {
+	Name             *string `json:"name"`
+	Email            *string `json:"email" binding:"omitempty,email"`
+	Phone            *string `json:"phone"`
+	LoyaltyConsent   *bool   `json:"loyaltyConsent"`
+	MarketingConsent *bool   `json:"marketingConsent"`
+}
+
+func FindCustomerByContext(context *gin.Context) (customer *Customer, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	return FindCustomerById(context, uint(id))
+}
+
+func FindCustomerById(context *gin.Context, id uint) (customer *Customer, err error) {
+	if err = config.DB.WithContext(context).First(&customer, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Customer not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch customer."})
+
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// NormalizePhone keeps the digits of a phone number and its leading "+".
+func NormalizePhone(phone string) (string, error) {
+	var normalized strings.Builder
+	digits := 0
+
+	for index, character := range strings.TrimSpace(phone) {
+		switch {
+		case unicode.IsDigit(character):
+			normalized.WriteRune(character)
+			digits++
+		case character == '+' && index == 0:
+			normalized.WriteRune(character)
+		case character == ' ' || character == '.' || character == '-':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	if digits < 6 || digits > 15 {
+		return "", ErrInvalidPhone
+	}
+
+	return normalized.String(), nil
+}
+
+// ValidateCustomerContact normalizes the email and the phone number of a customer,
+// at least one of them being required, and checks that no other customer uses them.
+func ValidateCustomerContact(context *gin.Context, customer *Customer) bool {
+	if customer.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*customer.Email))
+		customer.Email = &email
+
+		if email == "" {
+			customer.Email = nil
+		}
+	}
+
+	if customer.Phone != nil {
+		if strings.TrimSpace(*customer.Phone) == "" {
+			customer.Phone = nil
+		} else {
+			phone, err := NormalizePhone(*customer.Phone)
+			if err != nil {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number."})
+
+				return false
+			}
+
+			customer.Phone = &phone
+		}
+	}
+
+	if customer.Email == nil && customer.Phone == nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Email or phone is required."})
+
+		return false
+	}
+
+	query := config.DB.Model(&Customer{}).Where("id <> ?", customer.ID)
+
+	if customer.Email != nil && customer.Phone != nil {
+		query = query.Where("email = ? OR phone = ?", *customer.Email, *customer.Phone)
+	} else if customer.Email != nil {
+		query = query.Where("email = ?", *customer.Email)
+	} else {
+		query = query.Where("phone = ?", *customer.Phone)
+	}
+
+	var count int64
+	query.Count(&count)
+
+	if count > 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Customer already exists."})
+
+		return false
+	}
+
+	return true
+}
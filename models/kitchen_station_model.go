@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type KitchenStation struct {
+	ID          uint `gorm:"primaryKey"`
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type KitchenStationInsertInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type KitchenStationUpdateInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+func FindKitchenStationByContext(context *gin.Context) (kitchenStation *KitchenStation, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	return FindKitchenStationById(context, uint(id))
+}
+
+func FindKitchenStationById(context *gin.Context, id uint) (kitchenStation *KitchenStation, err error) {
+	if err = config.DB.WithContext(context).First(&kitchenStation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Kitchen station not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch kitchen station."})
+
+		return nil, err
+	}
+
+	return kitchenStation, nil
+}
+
+// FindOptionalKitchenStationId validates the kitchen station given in a catalog input,
+// 0 meaning that the item is not routed to any station.
+func FindOptionalKitchenStationId(context *gin.Context, id *uint) (kitchenStationID *uint, ok bool) {
+	if id == nil || *id == 0 {
+		return nil, true
+	}
+
+	if _, err := FindKitchenStationById(context, *id); err != nil {
+		return nil, false
+	}
+
+	return id, true
+}
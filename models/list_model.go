@@ -0,0 +1,193 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 100
+)
+
+// ListOutput is the envelope of the list endpoints: a page of items, the number of items matching the filters,
+// and the cursor to pass to get the next page, empty on the last page.
+type ListOutput[T any] struct {
+	Items      []T
+	Total      int64
+	Limit      int
+	NextCursor string
+}
+
+// SortField is a field a list can be sorted by: the column to sort on, and the struct field holding its value.
+type SortField struct {
+	Column string
+	Field  string
+}
+
+// ListOptions describes how a list endpoint can be sorted and searched.
+type ListOptions struct {
+	IDColumn     string
+	SortFields   map[string]SortField // Sort query parameter values
+	DefaultSort  string               // Sort used without a sort query parameter, by ID if empty
+	SearchFields []string             // Columns searched, case-insensitively, by the search query parameter
+	Preloads     []string             // Associations loaded with the items of the page
+}
+
+// storedSortValuer is implemented by the items whose fields can differ from their columns, such as translated names,
+// for the cursor to hold the value stored in the database, which the next page is compared with.
+type storedSortValuer interface {
+	storedSortValue(field string) (interface{}, bool)
+}
+
+// listCursor is the position of the last item of a page: its sort value and its ID, to break ties.
+type listCursor struct {
+	Value interface{}
+	ID    uint
+}
+
+// FindList runs a list query with the limit, cursor, sort and search query parameters of the request,
+// the filters of the endpoint being already applied to the query.
+// Pages are built with keyset pagination, so an item added or removed meanwhile does not shift the next pages.
+func FindList[T any](context *gin.Context, query *gorm.DB, options ListOptions) (*ListOutput[T], bool) {
+	limit := DefaultListLimit
+	if limitParam := context.Query("limit"); limitParam != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit <= 0 || limit > MaxListLimit {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit."})
+
+			return nil, false
+		}
+	}
+
+	sortField := SortField{Column: options.IDColumn, Field: "ID"}
+	descending := false
+
+	sort := context.Query("sort")
+	if sort == "" {
+		sort = options.DefaultSort
+	}
+
+	if sort != "" {
+		name := strings.TrimPrefix(sort, "-")
+		descending = name != sort
+
+		var ok bool
+		if name != "id" {
+			if sortField, ok = options.SortFields[name]; !ok {
+				context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort."})
+
+				return nil, false
+			}
+		}
+	}
+
+	if search := strings.ToLower(strings.TrimSpace(context.Query("search"))); search != "" && len(options.SearchFields) > 0 {
+		conditions := make([]clause.Expression, 0, len(options.SearchFields))
+
+		for _, column := range options.SearchFields {
+			conditions = append(conditions, clause.Expr{SQL: "LOWER(COALESCE(" + column + ", '')) LIKE ?", Vars: []interface{}{"%" + search + "%"}})
+		}
+
+		query = query.Where(clause.Or(conditions...))
+	}
+
+	output := &ListOutput[T]{Items: make([]T, 0), Limit: limit}
+
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&output.Total).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch list."})
+
+		return nil, false
+	}
+
+	comparison, direction := ">", "ASC"
+	if descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if cursorParam := context.Query("cursor"); cursorParam != "" {
+		cursor, err := decodeListCursor(cursorParam)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor."})
+
+			return nil, false
+		}
+
+		if sortField.Field == "ID" {
+			query = query.Where(options.IDColumn+" "+comparison+" ?", cursor.ID)
+		} else {
+			query = query.Where(
+				"("+sortField.Column+" "+comparison+" ? OR ("+sortField.Column+" = ? AND "+options.IDColumn+" "+comparison+" ?))",
+				cursor.Value, cursor.Value, cursor.ID,
+			)
+		}
+	}
+
+	if sortField.Field != "ID" {
+		query = query.Order(sortField.Column + " " + direction)
+	}
+
+	for _, preload := range options.Preloads {
+		query = query.Preload(preload)
+	}
+
+	if err := query.Order(options.IDColumn + " " + direction).Limit(limit + 1).Find(&output.Items).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch list."})
+
+		return nil, false
+	}
+
+	if len(output.Items) > limit {
+		output.Items = output.Items[:limit]
+
+		last := reflect.ValueOf(output.Items[limit-1])
+		value := last.FieldByName(sortField.Field).Interface()
+		if valuer, ok := any(&output.Items[limit-1]).(storedSortValuer); ok {
+			if stored, ok := valuer.storedSortValue(sortField.Field); ok {
+				value = stored
+			}
+		}
+
+		output.NextCursor = encodeListCursor(listCursor{
+			Value: value,
+			ID:    uint(last.FieldByName("ID").Uint()),
+		})
+	}
+
+	return output, true
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string) (cursor listCursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(data, &cursor)
+
+	return cursor, err
+}
+
+// TransformList converts the items of a list, keeping its envelope.
+func TransformList[T any, U any](list *ListOutput[T], transform func(items []T) []U) ListOutput[U] {
+	return ListOutput[U]{
+		Items:      transform(list.Items),
+		Total:      list.Total,
+		Limit:      list.Limit,
+		NextCursor: list.NextCursor,
+	}
+}
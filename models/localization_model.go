@@ -0,0 +1,212 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocalesContextKey holds the languages accepted by the request, in order of preference.
+const LocalesContextKey = "locales"
+
+// maxAcceptedLocales bounds the languages read from the Accept-Language header.
+const maxAcceptedLocales = 10
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Translation is the name and the description of a product, a menu or a product category in another language.
+type Translation struct {
+	Name        string
+	Description string
+}
+
+// Translations is stored as a JSON object by locale, such as {"en": {"Name": "Fries", "Description": "..."}}.
+type Translations map[string]Translation
+
+type TranslationInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (translations Translations) GormDataType() string {
+	return "string"
+}
+
+func (translations Translations) Value() (driver.Value, error) {
+	if len(translations) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(translations)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (translations *Translations) Scan(value interface{}) error {
+	var stored string
+
+	switch typedValue := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = typedValue
+	case []byte:
+		stored = string(typedValue)
+	default:
+		return fmt.Errorf("unable to scan translations from %T", value)
+	}
+
+	*translations = Translations{}
+
+	if stored == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(stored), translations)
+}
+
+// ValidateTranslations checks the locales and the names of the translations given in an input, the locales being
+// lowercased.
+func ValidateTranslations(context *gin.Context, input map[string]TranslationInput) (Translations, bool) {
+	translations := make(Translations, len(input))
+
+	for locale, translation := range input {
+		normalized := strings.ToLower(strings.TrimSpace(locale))
+		if !localePattern.MatchString(normalized) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid locale %s.", locale)})
+
+			return nil, false
+		}
+
+		if strings.TrimSpace(translation.Name) == "" {
+			context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Translation %s: name is required.", locale)})
+
+			return nil, false
+		}
+
+		translations[normalized] = Translation{Name: translation.Name, Description: translation.Description}
+	}
+
+	return translations, true
+}
+
+// ParseAcceptLanguage returns the languages to look translations up in, from an Accept-Language header such as
+// "fr-CA,en;q=0.8": each language in order of preference, followed by its base language. The chain stops at the
+// default language of the catalog, whose content needs no translation.
+func ParseAcceptLanguage(header string) []string {
+	type acceptedLocale struct {
+		tag     string
+		quality float64
+	}
+
+	var accepted []acceptedLocale
+
+	for _, part := range strings.Split(header, ",") {
+		if len(accepted) == maxAcceptedLocales {
+			break
+		}
+
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if !localePattern.MatchString(tag) {
+			continue
+		}
+
+		quality := 1.0
+		for _, field := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(field), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+
+		if quality > 0 {
+			accepted = append(accepted, acceptedLocale{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool { return accepted[i].quality > accepted[j].quality })
+
+	defaultLocale := config.CatalogDefaultLocale()
+	locales := []string{}
+	seen := map[string]bool{}
+
+	for _, locale := range accepted {
+		candidates := []string{locale.tag}
+		if base, _, found := strings.Cut(locale.tag, "-"); found {
+			candidates = append(candidates, base)
+		}
+
+		for _, candidate := range candidates {
+			if candidate == defaultLocale {
+				return locales
+			}
+
+			if !seen[candidate] {
+				seen[candidate] = true
+				locales = append(locales, candidate)
+			}
+		}
+	}
+
+	return locales
+}
+
+// GetLocales returns the languages accepted by the current request, as set by the Locale middleware.
+func GetLocales(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+
+	locales, _ := ctx.Value(LocalesContextKey).([]string)
+
+	return locales
+}
+
+// localize replaces the name and the description with their translation in the first language of the request having one,
+// and returns that language, empty when the default content is kept.
+func localize(ctx context.Context, translations Translations, name *string, description *string) string {
+	for _, locale := range GetLocales(ctx) {
+		if translation, ok := translations[locale]; ok {
+			*name = translation.Name
+			if translation.Description != "" {
+				*description = translation.Description
+			}
+
+			return locale
+		}
+	}
+
+	return ""
+}
+
+// The lists sorted by name page through the names stored in the default language, whatever the language of the items.
+
+func (product *Product) storedSortValue(field string) (interface{}, bool) {
+	return storedName(field, product.defaultName)
+}
+
+func (menu *Menu) storedSortValue(field string) (interface{}, bool) {
+	return storedName(field, menu.defaultName)
+}
+
+func (productCategory *ProductCategory) storedSortValue(field string) (interface{}, bool) {
+	return storedName(field, productCategory.defaultName)
+}
+
+func storedName(field string, defaultName string) (interface{}, bool) {
+	return defaultName, field == "Name" && defaultName != ""
+}
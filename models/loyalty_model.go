@@ -0,0 +1,193 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type LoyaltyTransactionType string
+
+const (
+	Earned   LoyaltyTransactionType = "earned"
+	Reversed LoyaltyTransactionType = "reversed"
+	Redeemed LoyaltyTransactionType = "redeemed"
+	Refunded LoyaltyTransactionType = "refunded"
+)
+
+var ErrNotEnoughLoyaltyPoints = errors.New("not enough loyalty points")
+
+// LoyaltyTransaction is an entry of the loyalty points ledger of a customer,
+// with the balance of the customer once the points applied.
+type LoyaltyTransaction struct {
+	ID         uint `gorm:"primaryKey"`
+	CustomerID uint `gorm:"index"`
+	OrderID    *uint
+	Type       LoyaltyTransactionType
+	Points     int
+	Balance    int
+	CreatedAt  time.Time
+}
+
+func FindCustomerLoyaltyTransactions(context *gin.Context, customer *Customer) (transactions []LoyaltyTransaction, err error) {
+	if err = config.DB.WithContext(context).Where("customer_id = ?", customer.ID).Order("id").Find(&transactions).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch loyalty transactions."})
+
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// RedeemedPoints returns the loyalty points spent on the items of the order.
+func (order *Order) RedeemedPoints() int {
+	points := 0
+
+	for _, item := range order.Items {
+		points += item.RedeemedPoints
+	}
+
+	return points
+}
+
+// EarnedPoints returns the loyalty points the customer earns with the order once delivered,
+// for the amount actually paid.
+func (order *Order) EarnedPoints(customer *Customer) int {
+	if customer == nil || !customer.LoyaltyConsent {
+		return 0
+	}
+
+	return int(math.Floor(calculateOrderTotalPrice(order)*config.LoyaltyPointsPerEuro() + 1e-9))
+}
+
+// ValidateOrderCustomer checks the customer of an order, and that they can afford the items they redeem
+// with their loyalty points, given the points already redeemed for the order.
+func ValidateOrderCustomer(context *gin.Context, customerID *uint, order *Order, alreadyRedeemedPoints int) bool {
+	if customerID != nil {
+		customer, err := FindCustomerById(context, *customerID)
+		if err != nil {
+			return false
+		}
+
+		order.CustomerID = &customer.ID
+		order.Customer = customer
+	}
+
+	redeemedPoints := order.RedeemedPoints()
+	if redeemedPoints == 0 {
+		return true
+	}
+
+	if order.Customer == nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "A customer is required to redeem loyalty points."})
+
+		return false
+	}
+
+	if !order.Customer.LoyaltyConsent {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Customer has not joined the loyalty program."})
+
+		return false
+	}
+
+	if order.Customer.LoyaltyPoints+alreadyRedeemedPoints < redeemedPoints {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Not enough loyalty points."})
+
+		return false
+	}
+
+	return true
+}
+
+// SettleLoyaltyPoints brings the loyalty ledger of the order customer in line with the order:
+// points are redeemed for the items paid with points unless the order is cancelled,
+// and earned once the order is delivered. Only the difference with the ledger is recorded,
+// so it can be called after any change of the order.
+func SettleLoyaltyPoints(db *gorm.DB, order *Order, now time.Time) error {
+	if order.CustomerID == nil {
+		return nil
+	}
+
+	var customer Customer
+	if err := db.First(&customer, *order.CustomerID).Error; err != nil {
+		return err
+	}
+
+	var transactions []LoyaltyTransaction
+	if err := db.Where("order_id = ? AND customer_id = ?", order.ID, customer.ID).Find(&transactions).Error; err != nil {
+		return err
+	}
+
+	earnedPoints, redeemedPoints := 0, 0
+	for _, transaction := range transactions {
+		switch transaction.Type {
+		case Earned, Reversed:
+			earnedPoints += transaction.Points
+		case Redeemed, Refunded:
+			redeemedPoints -= transaction.Points
+		}
+	}
+
+	expectedRedeemedPoints, expectedEarnedPoints := 0, 0
+	if order.Status != Cancelled {
+		expectedRedeemedPoints = order.RedeemedPoints()
+	}
+
+	if order.Status == Delivered {
+		expectedEarnedPoints = order.EarnedPoints(&customer)
+	}
+
+	if difference := expectedRedeemedPoints - redeemedPoints; difference > 0 {
+		if err := addLoyaltyTransaction(db, &customer, order.ID, Redeemed, -difference, now); err != nil {
+			return err
+		}
+	} else if difference < 0 {
+		if err := addLoyaltyTransaction(db, &customer, order.ID, Refunded, -difference, now); err != nil {
+			return err
+		}
+	}
+
+	if difference := expectedEarnedPoints - earnedPoints; difference > 0 {
+		return addLoyaltyTransaction(db, &customer, order.ID, Earned, difference, now)
+	} else if difference < 0 {
+		return addLoyaltyTransaction(db, &customer, order.ID, Reversed, difference, now)
+	}
+
+	return nil
+}
+
+func addLoyaltyTransaction(db *gorm.DB, customer *Customer, orderID uint, transactionType LoyaltyTransactionType, points int, now time.Time) error {
+	query := db.Model(&Customer{}).Where("id = ?", customer.ID)
+
+	// Points cannot be spent twice, whereas reversed points may leave a negative balance.
+	if transactionType == Redeemed {
+		query = query.Where("loyalty_points >= ?", -points)
+	}
+
+	result := query.Update("loyalty_points", gorm.Expr("loyalty_points + ?", points))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotEnoughLoyaltyPoints
+	}
+
+	if err := db.First(customer, customer.ID).Error; err != nil {
+		return err
+	}
+
+	return db.Create(&LoyaltyTransaction{
+		CustomerID: customer.ID,
+		OrderID:    &orderID,
+		Type:       transactionType,
+		Points:     points,
+		Balance:    customer.LoyaltyPoints,
+		CreatedAt:  now,
+	}).Error
+}
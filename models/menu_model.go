@@ -13,33 +13,66 @@ import (
 )
 
 type Menu struct {
-	ID          uint      `gorm:"primaryKey"`
-	Products    []Product `gorm:"many2many:menu_products"`
-	Name        string
-	Description string
-	Image       string
-	Price       float64
-	IsAvailable bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID            uint      `gorm:"primaryKey"`
+	ExternalKey   *string   `gorm:"uniqueIndex"` // Identifies the menu in catalog imports, set by the first export or import
+	Products      []Product `gorm:"many2many:menu_products"`
+	Name          string
+	Description   string
+	Translations  Translations // Name and description in other languages, by locale
+	Image         string
+	Price         float64
+	LoyaltyPoints int // Points needed to get the menu with loyalty points, 0 if it cannot be
+	IsAvailable   bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
+
+	// Computed from the products of the menu
+	Allergens     Allergens `gorm:"-"`
+	IsVegetarian  bool      `gorm:"-"`
+	Nutrition     Nutrition `gorm:"-"`
+	ALaCartePrice float64   `gorm:"-"` // Sum of the prices of the products bought separately
+	Saving        float64   `gorm:"-"` // Saving on the products bought separately, negative if the menu costs more
+
+	AvailabilitySlots []AvailabilitySlot `gorm:"-"`
+	IsAvailableNow    bool               `gorm:"-"` // Available, and within its slots
+
+	// Loaded for the languages of the request
+	Locale      string `gorm:"-"` // Language of the name and description, empty for the default language
+	defaultName string // Name in the default language, as stored
 }
 
 type MenuInsertInput struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description" binding:"required"`
-	Price       float64 `json:"price" binding:"required"`
-	IsAvailable bool    `json:"isAvailable" binding:"required"`
-	ProductsIDs []uint  `json:"productsIDs" binding:"required"`
-	Image       string  `json:"image"`
+	Name          string                      `json:"name" binding:"required"`
+	Description   string                      `json:"description" binding:"required"`
+	Price         float64                     `json:"price" binding:"required"`
+	LoyaltyPoints int                         `json:"loyaltyPoints" binding:"min=0"`
+	IsAvailable   bool                        `json:"isAvailable" binding:"required"`
+	ProductsIDs   []uint                      `json:"productsIDs" binding:"required"`
+	Image         string                      `json:"image"`
+	Translations  map[string]TranslationInput `json:"translations"`
 }
 
 type MenuUpdateInput struct {
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Price       *float64 `json:"price"`
-	IsAvailable *bool    `json:"isAvailable"`
-	ProductsIDs *[]uint  `json:"productsIDs"`
-	Image       *string  `json:"image"`
+	Name          *string                      `json:"name"`
+	Description   *string                      `json:"description"`
+	Price         *float64                     `json:"price"`
+	LoyaltyPoints *int                         `json:"loyaltyPoints" binding:"omitempty,min=0"`
+	IsAvailable   *bool                        `json:"isAvailable"`
+	ProductsIDs   *[]uint                      `json:"productsIDs"`
+	Image         *string                      `json:"image"`
+	Translations  *map[string]TranslationInput `json:"translations"` // Replaces all the translations
+}
+
+// MenuListOptions describes how the menu list can be sorted and searched.
+var MenuListOptions = ListOptions{
+	IDColumn: "menus.id",
+	SortFields: map[string]SortField{
+		"name":  {Column: "menus.name", Field: "Name"},
+		"price": {Column: "menus.price", Field: "Price"},
+	},
+	SearchFields: []string{"menus.name", "menus.description"},
+	Preloads:     []string{"Products.Variants"},
 }
 
 func FindMenuByContext(context *gin.Context) (menu *Menu, err error) {
@@ -56,7 +89,7 @@ func FindMenuByContext(context *gin.Context) (menu *Menu, err error) {
 }
 
 func FindMenuById(context *gin.Context, id uint) (menu *Menu, err error) {
-	if err = config.DB.Preload("Products").First(&menu, id).Error; err != nil {
+	if err = config.DB.WithContext(context).Preload("Products.Variants").First(&menu, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			context.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Menu %d: item not found.", id)})
 
@@ -70,3 +103,37 @@ func FindMenuById(context *gin.Context, id uint) (menu *Menu, err error) {
 
 	return menu, nil
 }
+
+func (menu *Menu) AfterFind(tx *gorm.DB) error {
+	menu.defaultName = menu.Name
+	menu.Locale = localize(tx.Statement.Context, menu.Translations, &menu.Name, &menu.Description)
+
+	menu.ComputeDietaryInformation()
+	menu.ComputePricing()
+
+	if err := applyCatalogAvailability(tx, "menu_id", menu.ID, &menu.IsAvailable); err != nil {
+		return err
+	}
+
+	return menu.applyAvailabilitySchedule(tx)
+}
+
+// ComputeDietaryInformation aggregates the allergens, the vegetarian flag and the nutrition values of the menu products.
+// A menu without products is not vegetarian.
+func (menu *Menu) ComputeDietaryInformation() {
+	if len(menu.Products) == 0 {
+		return
+	}
+
+	allergens := make([]Allergens, 0, len(menu.Products))
+	menu.IsVegetarian = true
+	menu.Nutrition = Nutrition{}
+
+	for _, product := range menu.Products {
+		allergens = append(allergens, product.Allergens)
+		menu.IsVegetarian = menu.IsVegetarian && product.IsVegetarian
+		menu.Nutrition = menu.Nutrition.Add(product.Nutrition, 1)
+	}
+
+	menu.Allergens = MergeAllergens(allergens...)
+}
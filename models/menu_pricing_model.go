@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MenuPricingReportItem is a menu priced higher than its products bought separately.
+type MenuPricingReportItem struct {
+	MenuID        uint
+	Name          string
+	Price         float64
+	ALaCartePrice float64
+	Saving        float64 // Negative, the extra cost of the menu
+}
+
+// ComputePricing sums the prices of the menu products, bought separately, and the saving the menu gives on them.
+func (menu *Menu) ComputePricing() {
+	if len(menu.Products) == 0 {
+		return
+	}
+
+	menu.ALaCartePrice = 0
+	for _, product := range menu.Products {
+		menu.ALaCartePrice += product.Price
+	}
+
+	menu.ALaCartePrice = roundPrice(menu.ALaCartePrice)
+	menu.Saving = roundPrice(menu.ALaCartePrice - menu.Price)
+}
+
+// IsOverpriced tells whether the menu costs more than its products bought separately.
+func (menu *Menu) IsOverpriced() bool {
+	return len(menu.Products) > 0 && menu.Saving < 0
+}
+
+// CheckMenuPricing rejects an overpriced menu, or sets a warning header on the response, depending on the pricing policy.
+func CheckMenuPricing(context *gin.Context, menu *Menu) bool {
+	if !menu.IsOverpriced() {
+		return true
+	}
+
+	message := fmt.Sprintf("Menu price is higher than its products bought separately (%.2f).", menu.ALaCartePrice)
+
+	if config.MenuPricingPolicy() == config.MenuPricingReject {
+		context.JSON(http.StatusBadRequest, gin.H{"error": message})
+
+		return false
+	}
+
+	context.Header("Warning", fmt.Sprintf(`199 - "%s"`, message))
+
+	return true
+}
+
+// FindOverpricedMenus returns the menus priced higher than their products bought separately, by ID.
+func FindOverpricedMenus(context *gin.Context) ([]MenuPricingReportItem, error) {
+	var menus []Menu
+	if err := config.DB.WithContext(context).Preload("Products").Order("id").Find(&menus).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]MenuPricingReportItem, 0)
+	for _, menu := range menus {
+		if menu.IsOverpriced() {
+			items = append(items, MenuPricingReportItem{
+				MenuID:        menu.ID,
+				Name:          menu.Name,
+				Price:         menu.Price,
+				ALaCartePrice: menu.ALaCartePrice,
+				Saving:        menu.Saving,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+func roundPrice(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Nutrition holds the nutrition values of a serving: energy in kcal, everything else in grams.
+type Nutrition struct {
+	Calories      float64
+	Proteins      float64
+	Carbohydrates float64
+	Sugars        float64
+	Fats          float64
+	SaturatedFats float64
+	Salt          float64
+}
+
+type NutritionInput struct {
+	Calories      float64 `json:"calories" binding:"min=0"`
+	Proteins      float64 `json:"proteins" binding:"min=0"`
+	Carbohydrates float64 `json:"carbohydrates" binding:"min=0"`
+	Sugars        float64 `json:"sugars" binding:"min=0"`
+	Fats          float64 `json:"fats" binding:"min=0"`
+	SaturatedFats float64 `json:"saturatedFats" binding:"min=0"`
+	Salt          float64 `json:"salt" binding:"min=0"`
+}
+
+func (input NutritionInput) ToNutrition() Nutrition {
+	return Nutrition(input)
+}
+
+// hasNegativeValue tells whether a value is negative, for the inputs which are not validated by binding.
+func (input NutritionInput) hasNegativeValue() bool {
+	return input.Calories < 0 || input.Proteins < 0 || input.Carbohydrates < 0 || input.Sugars < 0 ||
+		input.Fats < 0 || input.SaturatedFats < 0 || input.Salt < 0
+}
+
+// Add returns the nutrition values increased by the given quantity of another serving.
+func (nutrition Nutrition) Add(other Nutrition, quantity int) Nutrition {
+	factor := float64(quantity)
+
+	return Nutrition{
+		Calories:      roundNutritionValue(nutrition.Calories + other.Calories*factor),
+		Proteins:      roundNutritionValue(nutrition.Proteins + other.Proteins*factor),
+		Carbohydrates: roundNutritionValue(nutrition.Carbohydrates + other.Carbohydrates*factor),
+		Sugars:        roundNutritionValue(nutrition.Sugars + other.Sugars*factor),
+		Fats:          roundNutritionValue(nutrition.Fats + other.Fats*factor),
+		SaturatedFats: roundNutritionValue(nutrition.SaturatedFats + other.SaturatedFats*factor),
+		Salt:          roundNutritionValue(nutrition.Salt + other.Salt*factor),
+	}
+}
+
+// Columns returns the nutrition values keyed by the columns of a Nutrition field embedded with the given prefix.
+func (nutrition Nutrition) Columns(prefix string) map[string]interface{} {
+	return map[string]interface{}{
+		prefix + "calories":       nutrition.Calories,
+		prefix + "proteins":       nutrition.Proteins,
+		prefix + "carbohydrates":  nutrition.Carbohydrates,
+		prefix + "sugars":         nutrition.Sugars,
+		prefix + "fats":           nutrition.Fats,
+		prefix + "saturated_fats": nutrition.SaturatedFats,
+		prefix + "salt":           nutrition.Salt,
+	}
+}
+
+func roundNutritionValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// DietaryFilters are the catalog filters given in the query string of the products and menus lists,
+// such as ?excludeAllergens=gluten,milk&vegetarian=true.
+type DietaryFilters struct {
+	ExcludedAllergens Allergens
+	VegetarianOnly    bool
+}
+
+func ParseDietaryFilters(context *gin.Context) (filters *DietaryFilters, ok bool) {
+	filters = &DietaryFilters{}
+
+	if excludedAllergens := context.Query("excludeAllergens"); excludedAllergens != "" {
+		for _, allergen := range strings.Split(excludedAllergens, ",") {
+			filters.ExcludedAllergens = append(filters.ExcludedAllergens, Allergen(strings.TrimSpace(allergen)))
+		}
+
+		if !ValidateAllergens(context, filters.ExcludedAllergens) {
+			return nil, false
+		}
+	}
+
+	if vegetarian := context.Query("vegetarian"); vegetarian != "" {
+		vegetarianOnly, err := strconv.ParseBool(vegetarian)
+		if err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vegetarian filter."})
+
+			return nil, false
+		}
+
+		filters.VegetarianOnly = vegetarianOnly
+	}
+
+	return filters, true
+}
+
+func (filters *DietaryFilters) ApplyToProducts(query *gorm.DB) *gorm.DB {
+	for _, allergen := range filters.ExcludedAllergens {
+		query = query.Where("COALESCE(products.allergens, '') NOT LIKE ?", "%,"+string(allergen)+",%")
+	}
+
+	if filters.VegetarianOnly {
+		query = query.Where("products.is_vegetarian = ?", true)
+	}
+
+	return query
+}
+
+// ApplyToMenus keeps the menus whose products all match the filters, a menu without products not being vegetarian.
+func (filters *DietaryFilters) ApplyToMenus(query *gorm.DB, db *gorm.DB) *gorm.DB {
+	menuProducts := func() *gorm.DB {
+		return db.Table("menu_products").
+			Select("menu_products.menu_id").
+			Joins("JOIN products ON products.id = menu_products.product_id")
+	}
+
+	for _, allergen := range filters.ExcludedAllergens {
+		query = query.Where("menus.id NOT IN (?)", menuProducts().Where("COALESCE(products.allergens, '') LIKE ?", "%,"+string(allergen)+",%"))
+	}
+
+	if filters.VegetarianOnly {
+		query = query.Where("menus.id NOT IN (?)", menuProducts().Where("products.is_vegetarian = ?", false)).
+			Where("menus.id IN (?)", menuProducts())
+	}
+
+	return query
+}
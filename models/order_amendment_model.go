@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderAmendment records the items changed on an order the kitchen has already started to prepare,
+// so the pickers are told what changed instead of finding a different ticket.
+type OrderAmendment struct {
+	ID        uint `gorm:"primaryKey"`
+	OrderID   uint `gorm:"index"`
+	UserID    uint
+	Lines     []OrderAmendmentLine `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+}
+
+// OrderAmendmentLine is the change of quantity of a product or a menu of the order.
+type OrderAmendmentLine struct {
+	ID               uint `gorm:"primaryKey"`
+	OrderAmendmentID uint `gorm:"index"`
+	ProductID        *uint
+	MenuID           *uint
+	Name             string
+	PreviousQuantity int
+	Quantity         int
+	QuantityChange   int // Positive for an added quantity, negative for a removed one
+}
+
+type OrderAmendmentOutput struct {
+	ID        uint
+	UserID    uint
+	Summary   string
+	Lines     []OrderAmendmentLine
+	CreatedAt time.Time
+}
+
+// Summary describes the amendment for the kitchen, such as "+1 Frites, -1 Coca-Cola".
+func (amendment *OrderAmendment) Summary() string {
+	changes := make([]string, 0, len(amendment.Lines))
+
+	for _, line := range amendment.Lines {
+		changes = append(changes, fmt.Sprintf("%+d %s", line.QuantityChange, line.Name))
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+func TransformOrderAmendmentsToOutput(amendments []OrderAmendment) []OrderAmendmentOutput {
+	outputs := make([]OrderAmendmentOutput, 0, len(amendments))
+
+	for _, amendment := range amendments {
+		outputs = append(outputs, OrderAmendmentOutput{
+			ID:        amendment.ID,
+			UserID:    amendment.UserID,
+			Summary:   amendment.Summary(),
+			Lines:     amendment.Lines,
+			CreatedAt: amendment.CreatedAt,
+		})
+	}
+
+	return outputs
+}
+
+type orderItemContentKey struct {
+	productID   uint
+	variantID   uint
+	menuID      uint
+	menuChoices string
+}
+
+func contentKeyOf(item *OrderItem) orderItemContentKey {
+	key := orderItemContentKey{}
+
+	if item.ProductID != nil {
+		key.productID = *item.ProductID
+	}
+
+	if item.ProductVariantID != nil {
+		key.variantID = *item.ProductVariantID
+	}
+
+	if item.MenuID != nil {
+		key.menuID = *item.MenuID
+		key.menuChoices = item.MenuChoices
+	}
+
+	return key
+}
+
+// DiffOrderItems compares the quantities of each product and menu before and after the items of an order are replaced,
+// the items of the same product variant, or of the same menu with the same choices, being added up.
+// It returns one line per added, removed or changed quantity, in the order the products and menus appear in the order.
+func DiffOrderItems(previousItems []OrderItem, items []OrderItem) []OrderAmendmentLine {
+	lines := make([]OrderAmendmentLine, 0)
+	indexes := make(map[orderItemContentKey]int)
+
+	lineOf := func(item *OrderItem) *OrderAmendmentLine {
+		key := contentKeyOf(item)
+
+		index, ok := indexes[key]
+		if !ok {
+			index = len(lines)
+			indexes[key] = index
+			lines = append(lines, OrderAmendmentLine{ProductID: item.ProductID, MenuID: item.MenuID, Name: item.OrderContentName})
+		}
+
+		return &lines[index]
+	}
+
+	for index := range previousItems {
+		lineOf(&previousItems[index]).PreviousQuantity += previousItems[index].Quantity
+	}
+
+	for index := range items {
+		line := lineOf(&items[index])
+		line.Quantity += items[index].Quantity
+		line.Name = items[index].OrderContentName
+	}
+
+	changedLines := make([]OrderAmendmentLine, 0, len(lines))
+
+	for _, line := range lines {
+		line.QuantityChange = line.Quantity - line.PreviousQuantity
+		if line.QuantityChange != 0 {
+			changedLines = append(changedLines, line)
+		}
+	}
+
+	return changedLines
+}
+
+// RecordAmendment saves the difference between the previous items of the order and its current ones,
+// and emits an order.amended event for the webhooks subscribed to it. Nothing is recorded when no quantity changed.
+func (order *Order) RecordAmendment(tx *gorm.DB, previousItems []OrderItem, userID uint, now time.Time) error {
+	lines := DiffOrderItems(previousItems, order.Items)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	amendment := OrderAmendment{
+		OrderID:   order.ID,
+		UserID:    userID,
+		Lines:     lines,
+		CreatedAt: now,
+	}
+
+	if err := tx.Create(&amendment).Error; err != nil {
+		return err
+	}
+
+	order.Amendments = append(order.Amendments, amendment)
+
+	return EmitOrderEvent(tx, OrderAmended, order, "", now)
+}
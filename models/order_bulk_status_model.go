@@ -0,0 +1,40 @@
+package models
+
+type BulkMode string
+
+const (
+	// Atomic updates every order, or none of them if one of them cannot be updated.
+	Atomic BulkMode = "atomic"
+	// BestEffort updates every order that can be updated, and reports the others.
+	BestEffort BulkMode = "bestEffort"
+)
+
+func (mode BulkMode) IsValid() bool {
+	switch mode {
+	case Atomic, BestEffort:
+		return true
+	}
+
+	return false
+}
+
+type OrderStatusBulkInput struct {
+	OrderIDs []uint      `json:"orderIDs" binding:"required,min=1,max=100"`
+	Status   OrderStatus `json:"status" binding:"required"`
+	Mode     BulkMode    `json:"mode"`
+}
+
+type OrderStatusBulkResult struct {
+	OrderID uint
+	Success bool
+	Error   string
+	Order   *OrderOutput
+}
+
+type OrderStatusBulkOutput struct {
+	Status    OrderStatus
+	Mode      BulkMode
+	Succeeded int
+	Failed    int
+	Results   []OrderStatusBulkResult
+}
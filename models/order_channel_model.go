@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type OrderChannel string
+
+const (
+	Counter         OrderChannel = "counter"
+	Kiosk           OrderChannel = "kiosk"
+	DriveThrough    OrderChannel = "driveThrough"
+	ClickAndCollect OrderChannel = "clickAndCollect"
+	Delivery        OrderChannel = "delivery"
+)
+
+func (channel OrderChannel) IsValid() bool {
+	switch channel {
+	case Counter, Kiosk, DriveThrough, ClickAndCollect, Delivery:
+		return true
+	}
+
+	return false
+}
+
+// TargetPreparationTime returns how long an order taken on this channel
+// should take to be ready, customers waiting in their car being served first.
+func (channel OrderChannel) TargetPreparationTime() time.Duration {
+	switch channel {
+	case DriveThrough:
+		return 3 * time.Minute
+	case ClickAndCollect, Delivery:
+		return 10 * time.Minute
+	}
+
+	return 5 * time.Minute
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// preparationStatsSampleSize is how many of the last prepared orders are used to measure preparation durations.
+const preparationStatsSampleSize = 50
+
+// PreparationStats is the average time an item takes to prepare, measured on the last prepared orders.
+type PreparationStats struct {
+	ItemDuration time.Duration
+	Samples      int
+}
+
+// LoadPreparationStats measures the preparation time of an item on the last prepared orders,
+// from the start of their preparation, or from their creation when it was not recorded, until they were prepared.
+// The configured item preparation time is used as long as no order has been prepared.
+func LoadPreparationStats(db *gorm.DB) (PreparationStats, error) {
+	stats := PreparationStats{ItemDuration: config.OrderItemPreparationTime()}
+
+	var orders []Order
+	err := db.Preload("Items").
+		Where("prepared_at > ?", time.Time{}).
+		Order("prepared_at DESC").
+		Limit(preparationStatsSampleSize).
+		Find(&orders).Error
+	if err != nil {
+		return stats, err
+	}
+
+	var totalDuration time.Duration
+	totalItems := 0
+
+	for _, order := range orders {
+		startedAt := order.CreatedAt
+		if !order.InPreparationAt.IsZero() {
+			startedAt = order.InPreparationAt
+		}
+
+		duration := order.PreparedAt.Sub(startedAt)
+		items := order.ItemsCount()
+
+		if duration <= 0 || items == 0 {
+			continue
+		}
+
+		totalDuration += duration
+		totalItems += items
+		stats.Samples++
+	}
+
+	if totalItems > 0 {
+		stats.ItemDuration = totalDuration / time.Duration(totalItems)
+	}
+
+	return stats, nil
+}
+
+// ItemsCount returns how many items the order contains, a menu counting as one item.
+func (order *Order) ItemsCount() int {
+	count := 0
+
+	for _, item := range order.Items {
+		count += item.Quantity
+	}
+
+	return count
+}
+
+// EstimateReadyTimes estimates when each order of the kitchen queue will be ready,
+// the orders being prepared one after the other following their priority.
+// An order already in preparation only needs the remaining part of its preparation time,
+// and a scheduled order is not ready before its requested ready time.
+func EstimateReadyTimes(orders []Order, stats PreparationStats, now time.Time) map[uint]time.Time {
+	estimates := make(map[uint]time.Time, len(orders))
+	readyAt := now
+
+	for _, order := range sortOrdersByPriority(orders, now) {
+		duration := time.Duration(order.ItemsCount()) * stats.ItemDuration
+
+		if order.Status == InPreparation && !order.InPreparationAt.IsZero() {
+			duration -= now.Sub(order.InPreparationAt)
+		}
+
+		if duration > 0 {
+			readyAt = readyAt.Add(duration)
+		}
+
+		estimate := readyAt
+		if order.RequestedReadyAt != nil && order.RequestedReadyAt.After(estimate) {
+			estimate = *order.RequestedReadyAt
+		}
+
+		estimates[order.ID] = estimate
+	}
+
+	return estimates
+}
+
+// EstimateOrderReadyAt estimates when an order waiting in the kitchen queue will be ready,
+// nothing being returned for the other orders or when the estimate cannot be computed.
+// A scheduled order not in the queue yet is expected at its requested ready time.
+func EstimateOrderReadyAt(context *gin.Context, order *Order, now time.Time) *time.Time {
+	if order.Status != Created && order.Status != InPreparation {
+		return nil
+	}
+
+	if order.IsHeld(now) {
+		return order.RequestedReadyAt
+	}
+
+	stats, err := LoadPreparationStats(config.DB.WithContext(context))
+	if err != nil {
+		return nil
+	}
+
+	var queue []Order
+	if err := config.DB.WithContext(context).Preload("Items").Where("status IN ?", []OrderStatus{Created, InPreparation}).Find(&queue).Error; err != nil {
+		return nil
+	}
+
+	estimate, ok := EstimateReadyTimes(queue, stats, now)[order.ID]
+	if !ok {
+		return nil
+	}
+
+	return &estimate
+}
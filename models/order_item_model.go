@@ -3,8 +3,12 @@ package models
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderItem struct {
@@ -13,14 +17,170 @@ type OrderItem struct {
 	Quantity                int
 	OrderContentName        string
 	OrderContentDescription string
+	OrderContentLocale      string // Language the name and description were taken in, empty for the default language
 	OrderContentImage       string
 	OrderContentPrice       float64
+	RedeemedPoints          int // Loyalty points paid instead of the price
+	OrderContentAllergens   Allergens
+	OrderContentNutrition   Nutrition `gorm:"embedded;embeddedPrefix:order_content_nutrition_"`
+	Notes                   string
+	Allergens               Allergens
+	ProductID               *uint
+	ProductVariantID        *uint
+	MenuID                  *uint
+	MenuChoices             string             // Upsized products of a menu, such as "Frites (Large), Coca-Cola (Large)"
+	StationItems            []OrderStationItem `gorm:"constraint:OnDelete:CASCADE"`
+	ALaCartePrice           float64            `gorm:"-" json:"-"` // Price of the products of a menu bought separately, set when the item is built
 }
 
-func TransformOrderItemInputsToOrderItems(context *gin.Context, items []OrderItemInput) *[]OrderItem {
+// Discount returns the saving of a menu on its products bought separately, multiplied by its quantity,
+// nothing if it costs more or is paid with loyalty points.
+func (item *OrderItem) Discount() float64 {
+	if item.RedeemedPoints > 0 || item.ALaCartePrice <= item.OrderContentPrice {
+		return 0
+	}
+
+	return roundPrice((item.ALaCartePrice - item.OrderContentPrice) * float64(item.Quantity))
+}
+
+// TotalPrice returns the price of the item multiplied by its quantity, nothing if it is paid with loyalty points.
+func (item *OrderItem) TotalPrice() float64 {
+	if item.RedeemedPoints > 0 {
+		return 0
+	}
+
+	return item.OrderContentPrice * float64(item.Quantity)
+}
+
+// ReplaceItems replaces the items of the order by the given ones. A new item with the same content as a previous one,
+// the same product variant or the same menu with the same choices, takes its place and keeps its station items,
+// so the kitchen stations keep their progress: only their quantity is adjusted.
+// The other previous items are deleted along with their station items, and the other new items are created with theirs.
+func (order *Order) ReplaceItems(tx *gorm.DB, items []OrderItem) error {
+	previousItems := make(map[orderItemContentKey][]OrderItem)
+	for _, item := range order.Items {
+		key := contentKeyOf(&item)
+		previousItems[key] = append(previousItems[key], item)
+	}
+
+	for index := range items {
+		item := &items[index]
+		item.OrderID = order.ID
+
+		key := contentKeyOf(item)
+		if len(previousItems[key]) == 0 {
+			continue
+		}
+
+		previousItem := previousItems[key][0]
+		previousItems[key] = previousItems[key][1:]
+
+		item.ID = previousItem.ID
+		item.StationItems = append([]OrderStationItem(nil), previousItem.StationItems...)
+
+		if err := item.adjustStationItems(tx, previousItem.Quantity); err != nil {
+			return err
+		}
+	}
+
+	removedItemIDs := make([]uint, 0)
+	for _, removedItems := range previousItems {
+		for _, item := range removedItems {
+			removedItemIDs = append(removedItemIDs, item.ID)
+		}
+	}
+
+	if len(removedItemIDs) > 0 {
+		if err := tx.Delete(&OrderItem{}, removedItemIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	for index := range items {
+		item := &items[index]
+
+		if item.ID == 0 {
+			if err := tx.Create(item).Error; err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := tx.Omit(clause.Associations).Save(item).Error; err != nil {
+			return err
+		}
+	}
+
+	order.Items = items
+
+	return nil
+}
+
+// adjustStationItems gives the quantity of the item to the station items it kept.
+// A prepared station item goes back to preparation when its quantity grows, the added quantity remaining to prepare.
+func (item *OrderItem) adjustStationItems(tx *gorm.DB, previousQuantity int) error {
+	if item.Quantity == previousQuantity {
+		return nil
+	}
+
+	for index := range item.StationItems {
+		stationItem := &item.StationItems[index]
+
+		updates := map[string]interface{}{
+			"quantity": item.Quantity,
+		}
+
+		if item.Quantity > previousQuantity && stationItem.Status == Prepared {
+			updates["status"] = InPreparation
+			updates["preparedAt"] = time.Time{}
+		}
+
+		if err := tx.Model(stationItem).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redeemItemPoints returns the loyalty points paid for an item the customer redeems with their points.
+func redeemItemPoints(context *gin.Context, item OrderItemInput, loyaltyPoints int, label string) (int, bool) {
+	if !item.RedeemPoints {
+		return 0, true
+	}
+
+	if loyaltyPoints == 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": label + ": item cannot be redeemed with loyalty points."})
+
+		return 0, false
+	}
+
+	return loyaltyPoints * item.Quantity, true
+}
+
+// TransformOrderItemInputsToOrderItems checks the ordered items, and snapshots them into order items.
+// The items must be available at the requested ready time of a scheduled order, now otherwise.
+func TransformOrderItemInputsToOrderItems(context *gin.Context, items []OrderItemInput, requestedReadyAt *time.Time) *[]OrderItem {
 	var orderItems []OrderItem
 
+	moment := time.Now()
+	if requestedReadyAt != nil {
+		moment = *requestedReadyAt
+	}
+
+	location := RestaurantLocation(context)
+
 	for _, item := range items {
+		notes, ok := SanitizeNotes(context, item.Notes, OrderItemNotesMaxLength)
+		if !ok {
+			return nil
+		}
+
+		if !ValidateAllergens(context, item.Allergens) {
+			return nil
+		}
+
 		if item.ProductID != 0 {
 			product, _ := FindProductById(context, item.ProductID)
 			if product == nil {
@@ -33,19 +193,51 @@ func TransformOrderItemInputsToOrderItems(context *gin.Context, items []OrderIte
 				return nil
 			}
 
+			if !product.IsAvailableAt(moment, location) {
+				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Product %d: item is not available at this time.", item.ProductID)})
+
+				return nil
+			}
+
 			if item.Quantity <= 0 {
 				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Product %d: item quantity should be superior than 0.", item.ProductID)})
 
 				return nil
 			}
 
-			orderItems = append(orderItems, OrderItem{
+			variant, ok := orderableVariant(context, product, item.VariantID, fmt.Sprintf("Product %d", item.ProductID))
+			if !ok {
+				return nil
+			}
+
+			redeemedPoints, ok := redeemItemPoints(context, item, product.LoyaltyPoints, fmt.Sprintf("Product %d", item.ProductID))
+			if !ok {
+				return nil
+			}
+
+			orderItem := OrderItem{
 				Quantity:                item.Quantity,
 				OrderContentName:        product.Name,
 				OrderContentDescription: product.Description,
+				OrderContentLocale:      product.Locale,
 				OrderContentImage:       product.Image,
 				OrderContentPrice:       product.Price,
-			})
+				RedeemedPoints:          redeemedPoints,
+				OrderContentAllergens:   product.Allergens,
+				OrderContentNutrition:   product.Nutrition,
+				Notes:                   notes,
+				Allergens:               item.Allergens,
+				ProductID:               &product.ID,
+				StationItems:            buildOrderStationItems(product, variant, item.Quantity),
+			}
+
+			if variant != nil {
+				orderItem.OrderContentName = variant.DisplayName(product)
+				orderItem.OrderContentPrice = variant.Price
+				orderItem.ProductVariantID = &variant.ID
+			}
+
+			orderItems = append(orderItems, orderItem)
 		} else if item.MenuID != 0 {
 			menu, _ := FindMenuById(context, item.MenuID)
 			if menu == nil {
@@ -58,18 +250,62 @@ func TransformOrderItemInputsToOrderItems(context *gin.Context, items []OrderIte
 				return nil
 			}
 
+			if !menu.IsAvailableAt(moment, location) {
+				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Menu %d: item is not available at this time.", item.MenuID)})
+
+				return nil
+			}
+
 			if item.Quantity <= 0 {
 				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Menu %d: item quantity should be superior than 0.", item.MenuID)})
 
 				return nil
 			}
 
+			redeemedPoints, ok := redeemItemPoints(context, item, menu.LoyaltyPoints, fmt.Sprintf("Menu %d", item.MenuID))
+			if !ok {
+				return nil
+			}
+
+			variants, ok := menuChoices(context, menu, item.MenuChoices)
+			if !ok {
+				return nil
+			}
+
+			price := menu.Price
+			var aLaCartePrice float64
+			var choices []string
+			var stationItems []OrderStationItem
+
+			for _, product := range menu.Products {
+				variant := variants[product.ID]
+				if variant != nil {
+					price += variant.MenuSupplement
+					aLaCartePrice += variant.Price
+					choices = append(choices, variant.DisplayName(&product))
+				} else {
+					aLaCartePrice += product.Price
+				}
+
+				stationItems = append(stationItems, buildOrderStationItems(&product, variant, item.Quantity)...)
+			}
+
 			orderItems = append(orderItems, OrderItem{
 				Quantity:                item.Quantity,
 				OrderContentName:        menu.Name,
 				OrderContentDescription: menu.Description,
+				OrderContentLocale:      menu.Locale,
 				OrderContentImage:       menu.Image,
-				OrderContentPrice:       menu.Price,
+				OrderContentPrice:       price,
+				RedeemedPoints:          redeemedPoints,
+				OrderContentAllergens:   menu.Allergens,
+				OrderContentNutrition:   menu.Nutrition,
+				Notes:                   notes,
+				Allergens:               item.Allergens,
+				MenuID:                  &menu.ID,
+				MenuChoices:             strings.Join(choices, ", "),
+				StationItems:            stationItems,
+				ALaCartePrice:           roundPrice(aLaCartePrice),
 			})
 		}
 	}
@@ -82,3 +318,42 @@ func TransformOrderItemInputsToOrderItems(context *gin.Context, items []OrderIte
 
 	return &orderItems
 }
+
+// ValidateOrderItemsAvailableAt checks that the products and menus of the order items are available
+// at the requested ready time of a rescheduled order, now if it is no longer scheduled.
+func ValidateOrderItemsAvailableAt(context *gin.Context, items []OrderItem, requestedReadyAt *time.Time) bool {
+	moment := time.Now()
+	if requestedReadyAt != nil {
+		moment = *requestedReadyAt
+	}
+
+	location := RestaurantLocation(context)
+
+	for _, item := range items {
+		if item.ProductID != nil {
+			product, _ := FindProductById(context, *item.ProductID)
+			if product == nil {
+				return false
+			}
+
+			if !product.IsAvailableAt(moment, location) {
+				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Product %d: item is not available at this time.", product.ID)})
+
+				return false
+			}
+		} else if item.MenuID != nil {
+			menu, _ := FindMenuById(context, *item.MenuID)
+			if menu == nil {
+				return false
+			}
+
+			if !menu.IsAvailableAt(moment, location) {
+				context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Menu %d: item is not available at this time.", menu.ID)})
+
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -1,55 +1,137 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 	"wacdo/config"
+	"wacdo/utils"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	OrderNotesMaxLength     = 255
+	OrderItemNotesMaxLength = 140
 )
 
 type Order struct {
-	ID           uint        `gorm:"primaryKey"`
-	Status       OrderStatus `binding:"required"`
-	TicketNumber string
-	Items        []OrderItem
-	UserID       uint
-	User         User `binding:"required"`
-	CreatedAt    time.Time
-	PreparedAt   time.Time
-	DeliveredAt  time.Time
+	ID               uint        `gorm:"primaryKey"`
+	Status           OrderStatus `binding:"required"`
+	Channel          OrderChannel
+	TicketNumber     string
+	Notes            string
+	Allergens        Allergens
+	Items            []OrderItem
+	Amendments       []OrderAmendment
+	RestaurantID     uint `gorm:"index"`
+	UserID           uint
+	User             User `binding:"required"`
+	CustomerID       *uint
+	Customer         *Customer
+	DeliveryAddress  string
+	DeliveryPhone    string
+	DriverID         *uint
+	RequestedReadyAt *time.Time
+	CreatedAt        time.Time
+	InPreparationAt  time.Time
+	PreparedAt       time.Time
+	ClaimedAt        time.Time
+	OutForDeliveryAt time.Time
+	DeliveredAt      time.Time
+	CancelledAt      time.Time
 }
 
 type OrderOutput struct {
-	ID           uint
-	Status       OrderStatus
-	TicketNumber string
-	Items        []OrderItem
-	UserID       uint
-	User         UserOutput `binding:"required"`
-	CreatedAt    time.Time
-	PreparedAt   time.Time
-	DeliveredAt  time.Time
-	TotalPrice   float64
+	ID               uint
+	Status           OrderStatus
+	Channel          OrderChannel
+	TicketNumber     string
+	Notes            string
+	Allergens        Allergens
+	AllergenAlert    Allergens
+	ContentAllergens Allergens
+	Nutrition        Nutrition
+	Items            []OrderItem
+	Amendments       []OrderAmendmentOutput
+	RestaurantID     uint
+	UserID           uint
+	User             UserOutput `binding:"required"`
+	CustomerID       *uint
+	DeliveryAddress  string
+	DeliveryPhone    string
+	DriverID         *uint
+	RequestedReadyAt *time.Time
+	CreatedAt        time.Time
+	InPreparationAt  time.Time
+	PreparedAt       time.Time
+	ClaimedAt        time.Time
+	OutForDeliveryAt time.Time
+	DeliveredAt      time.Time
+	CancelledAt      time.Time
+	EstimatedReadyAt *time.Time
+	TotalPrice       float64
+	RedeemedPoints   int
 }
 
 type OrderItemInput struct {
-	Quantity  int  `json:"quantity" binding:"required,min=1"`
-	ProductID uint `json:"productID"`
-	MenuID    uint `json:"menuID"`
+	Quantity     int                        `json:"quantity" binding:"required,min=1"`
+	ProductID    uint                       `json:"productID"`
+	VariantID    uint                       `json:"variantID"` // Required for a product with variants
+	MenuID       uint                       `json:"menuID"`
+	MenuChoices  []OrderItemMenuChoiceInput `json:"menuChoices" binding:"dive"` // Products of the menu upsized with one of their variants
+	Notes        string                     `json:"notes"`
+	Allergens    Allergens                  `json:"allergens"`
+	RedeemPoints bool                       `json:"redeemPoints"`
 }
 
 type OrderInsertInput struct {
-	TicketNumber string           `json:"ticketNumber" binding:"required"`
-	Items        []OrderItemInput `json:"items" binding:"required,min=1"`
+	TicketNumber         string           `json:"ticketNumber"`
+	Channel              OrderChannel     `json:"channel"`
+	RequestedReadyAt     *time.Time       `json:"requestedReadyAt"`
+	Notes                string           `json:"notes"`
+	Allergens            Allergens        `json:"allergens"`
+	Items                []OrderItemInput `json:"items" binding:"required,min=1"`
+	OverrideOpeningHours bool             `json:"overrideOpeningHours"`
+	CustomerID           *uint            `json:"customerID"`
+	DeliveryAddress      string           `json:"deliveryAddress"`
+	DeliveryPhone        string           `json:"deliveryPhone"`
 }
 
 type OrderUpdateInput struct {
-	TicketNumber *string           `json:"ticketNumber"`
-	Items        *[]OrderItemInput `json:"items" binding:"min=1"`
+	TicketNumber         *string           `json:"ticketNumber"`
+	Channel              *OrderChannel     `json:"channel"`
+	RequestedReadyAt     NullableTime      `json:"requestedReadyAt" swaggertype:"string" format:"date-time"` // null to prepare the order as soon as possible
+	Notes                *string           `json:"notes"`
+	Allergens            *Allergens        `json:"allergens"`
+	Items                *[]OrderItemInput `json:"items" binding:"omitempty,min=1"`
+	CustomerID           *uint             `json:"customerID"`
+	DeliveryAddress      *string           `json:"deliveryAddress"`
+	DeliveryPhone        *string           `json:"deliveryPhone"`
+	OverrideOpeningHours bool              `json:"overrideOpeningHours"`
+}
+
+// NullableTime is a time of an update input, which an explicit null clears.
+type NullableTime struct {
+	IsSet bool
+	Value *time.Time
+}
+
+func (nullable *NullableTime) UnmarshalJSON(data []byte) error {
+	nullable.IsSet = true
+
+	return json.Unmarshal(data, &nullable.Value)
+}
+
+func (Order) RestaurantScope(table string, restaurantID uint) clause.Expression {
+	return restaurantColumnScope(table, restaurantID)
 }
 
 func FindOrderByContext(context *gin.Context) (order *Order, err error) {
@@ -66,7 +148,7 @@ func FindOrderByContext(context *gin.Context) (order *Order, err error) {
 }
 
 func FindOrderById(context *gin.Context, id uint) (order *Order, err error) {
-	if err = config.DB.Preload("User").Preload("Items").First(&order, id).Error; err != nil {
+	if err = config.DB.WithContext(context).Preload("User", WithDeleted).Preload("Items.StationItems").Preload("Amendments.Lines").First(&order, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			context.JSON(http.StatusNotFound, gin.H{"error": "Order not found."})
 
@@ -81,6 +163,39 @@ func FindOrderById(context *gin.Context, id uint) (order *Order, err error) {
 	return order, nil
 }
 
+func ValidateRequestedReadyAt(context *gin.Context, requestedReadyAt *time.Time) bool {
+	if requestedReadyAt != nil && requestedReadyAt.Before(time.Now()) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Requested ready time must be in the future."})
+
+		return false
+	}
+
+	return true
+}
+
+// SanitizeNotes cleans a free text note and checks its length once cleaned.
+func SanitizeNotes(context *gin.Context, notes string, maxLength int) (string, bool) {
+	notes = utils.SanitizeText(notes)
+
+	if utf8.RuneCountInString(notes) > maxLength {
+		context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Notes must not exceed %d characters.", maxLength)})
+
+		return "", false
+	}
+
+	return notes, true
+}
+
+func ValidateAllergens(context *gin.Context, allergens Allergens) bool {
+	if !allergens.IsValid() {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid allergen."})
+
+		return false
+	}
+
+	return true
+}
+
 func TransformOrdersToOutput(orders []Order) []OrderOutput {
 	var outputOrders []OrderOutput
 
@@ -91,18 +206,129 @@ func TransformOrdersToOutput(orders []Order) []OrderOutput {
 	return outputOrders
 }
 
+// NewOrderFromInput validates the order input and builds the order with its priced items, without saving it.
+// The ticket number is left to the caller.
+func NewOrderFromInput(context *gin.Context, input *OrderInsertInput, user *User) *Order {
+	if input.Channel == "" {
+		input.Channel = Counter
+	}
+
+	if !input.Channel.IsValid() {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid channel."})
+
+		return nil
+	}
+
+	if !ValidateRequestedReadyAt(context, input.RequestedReadyAt) {
+		return nil
+	}
+
+	notes, ok := SanitizeNotes(context, input.Notes, OrderNotesMaxLength)
+	if !ok {
+		return nil
+	}
+
+	if !ValidateAllergens(context, input.Allergens) {
+		return nil
+	}
+
+	if !ValidateOrderAcceptance(context, user, input.RequestedReadyAt, input.OverrideOpeningHours) {
+		return nil
+	}
+
+	orderItems := TransformOrderItemInputsToOrderItems(context, input.Items, input.RequestedReadyAt)
+	if orderItems == nil {
+		return nil
+	}
+
+	order := &Order{
+		TicketNumber:     input.TicketNumber,
+		Channel:          input.Channel,
+		RequestedReadyAt: input.RequestedReadyAt,
+		Notes:            notes,
+		Allergens:        input.Allergens,
+		User:             *user,
+		Status:           Created,
+		Items:            *orderItems,
+	}
+
+	if !ValidateOrderCustomer(context, input.CustomerID, order, 0) {
+		return nil
+	}
+
+	deliveryPhone := input.DeliveryPhone
+	if deliveryPhone == "" && order.Customer != nil && order.Customer.Phone != nil {
+		deliveryPhone = *order.Customer.Phone
+	}
+
+	order.DeliveryAddress, order.DeliveryPhone, ok = ValidateDeliveryContact(context, order.Channel, input.DeliveryAddress, deliveryPhone)
+	if !ok {
+		return nil
+	}
+
+	return order
+}
+
+// ValidateDeliveryContact checks that a delivery order has an address and a valid phone number,
+// the contact being dropped for the other channels.
+func ValidateDeliveryContact(context *gin.Context, channel OrderChannel, address string, phone string) (string, string, bool) {
+	if channel != Delivery {
+		return "", "", true
+	}
+
+	address = strings.TrimSpace(address)
+	if address == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Delivery address is required."})
+
+		return "", "", false
+	}
+
+	if strings.TrimSpace(phone) == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Delivery phone is required."})
+
+		return "", "", false
+	}
+
+	phone, err := NormalizePhone(phone)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number."})
+
+		return "", "", false
+	}
+
+	return address, phone, true
+}
+
 func TransformOrderToOutput(order *Order) OrderOutput {
 	return OrderOutput{
-		ID:           order.ID,
-		Status:       order.Status,
-		TicketNumber: order.TicketNumber,
-		Items:        order.Items,
-		UserID:       order.UserID,
-		User:         TransformUserToOutput(&order.User),
-		CreatedAt:    order.CreatedAt,
-		PreparedAt:   order.PreparedAt,
-		DeliveredAt:  order.DeliveredAt,
-		TotalPrice:   calculateOrderTotalPrice(order),
+		ID:               order.ID,
+		Status:           order.Status,
+		Channel:          order.Channel,
+		TicketNumber:     order.TicketNumber,
+		Notes:            order.Notes,
+		Allergens:        order.Allergens,
+		AllergenAlert:    order.AllergenAlert(),
+		ContentAllergens: order.ContentAllergens(),
+		Nutrition:        order.Nutrition(),
+		Items:            order.Items,
+		Amendments:       TransformOrderAmendmentsToOutput(order.Amendments),
+		RestaurantID:     order.RestaurantID,
+		UserID:           order.UserID,
+		User:             TransformUserToOutput(&order.User),
+		CustomerID:       order.CustomerID,
+		DeliveryAddress:  order.DeliveryAddress,
+		DeliveryPhone:    order.DeliveryPhone,
+		DriverID:         order.DriverID,
+		RequestedReadyAt: order.RequestedReadyAt,
+		CreatedAt:        order.CreatedAt,
+		InPreparationAt:  order.InPreparationAt,
+		PreparedAt:       order.PreparedAt,
+		ClaimedAt:        order.ClaimedAt,
+		OutForDeliveryAt: order.OutForDeliveryAt,
+		DeliveredAt:      order.DeliveredAt,
+		CancelledAt:      order.CancelledAt,
+		TotalPrice:       calculateOrderTotalPrice(order),
+		RedeemedPoints:   order.RedeemedPoints(),
 	}
 }
 
@@ -110,8 +336,41 @@ func calculateOrderTotalPrice(order *Order) float64 {
 	var totalPrice float64
 
 	for _, item := range order.Items {
-		totalPrice += item.OrderContentPrice * float64(item.Quantity)
+		totalPrice += item.TotalPrice()
 	}
 
 	return totalPrice
 }
+
+// AllergenAlert returns every allergen flagged on the order or on one of its items.
+func (order *Order) AllergenAlert() Allergens {
+	lists := []Allergens{order.Allergens}
+
+	for _, item := range order.Items {
+		lists = append(lists, item.Allergens)
+	}
+
+	return MergeAllergens(lists...)
+}
+
+// ContentAllergens returns every allergen contained in the products and menus of the order.
+func (order *Order) ContentAllergens() Allergens {
+	lists := make([]Allergens, 0, len(order.Items))
+
+	for _, item := range order.Items {
+		lists = append(lists, item.OrderContentAllergens)
+	}
+
+	return MergeAllergens(lists...)
+}
+
+// Nutrition returns the total nutrition values of the order.
+func (order *Order) Nutrition() Nutrition {
+	var nutrition Nutrition
+
+	for _, item := range order.Items {
+		nutrition = nutrition.Add(item.OrderContentNutrition, item.Quantity)
+	}
+
+	return nutrition
+}
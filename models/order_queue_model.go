@@ -0,0 +1,75 @@
+package models
+
+import (
+	"sort"
+	"time"
+	"wacdo/config"
+)
+
+type OrderQueueOutput struct {
+	OrderOutput
+	Priority int
+	DueAt    time.Time
+}
+
+// IsHeld reports whether a scheduled order must stay out of the kitchen queue
+// because its preparation lead time has not arrived yet, and the kitchen has not started it anyway.
+func (order *Order) IsHeld(now time.Time) bool {
+	if order.Status != Created || order.RequestedReadyAt == nil {
+		return false
+	}
+
+	return now.Before(order.RequestedReadyAt.Add(-config.OrderPreparationLeadTime()))
+}
+
+// DueAt returns the time at which the order is expected to be ready.
+func (order *Order) DueAt() time.Time {
+	if order.RequestedReadyAt != nil {
+		return *order.RequestedReadyAt
+	}
+
+	return order.CreatedAt.Add(order.Channel.TargetPreparationTime())
+}
+
+// PrioritizeOrders drops held orders and sorts the remaining ones by due time,
+// the oldest order coming first when two orders are due at the same time.
+func PrioritizeOrders(orders []Order, now time.Time) []OrderQueueOutput {
+	queue := sortOrdersByPriority(orders, now)
+
+	outputQueue := make([]OrderQueueOutput, 0, len(queue))
+
+	for index, order := range queue {
+		outputQueue = append(outputQueue, OrderQueueOutput{
+			OrderOutput: TransformOrderToOutput(&order),
+			Priority:    index + 1,
+			DueAt:       order.DueAt(),
+		})
+	}
+
+	return outputQueue
+}
+
+func sortOrdersByPriority(orders []Order, now time.Time) []Order {
+	queue := make([]Order, 0, len(orders))
+
+	for _, order := range orders {
+		if !order.IsHeld(now) {
+			queue = append(queue, order)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		dueI, dueJ := queue[i].DueAt(), queue[j].DueAt()
+		if !dueI.Equal(dueJ) {
+			return dueI.Before(dueJ)
+		}
+
+		if !queue[i].CreatedAt.Equal(queue[j].CreatedAt) {
+			return queue[i].CreatedAt.Before(queue[j].CreatedAt)
+		}
+
+		return queue[i].ID < queue[j].ID
+	})
+
+	return queue
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+	"wacdo/config"
+)
+
+type OrderQuoteLineOutput struct {
+	ProductID      *uint
+	MenuID         *uint
+	Name           string
+	Quantity       int
+	UnitPrice      float64
+	Discount       float64 // Saving of a menu on its products bought separately
+	TotalPrice     float64
+	RedeemedPoints int
+	Allergens      Allergens
+	Nutrition      Nutrition
+}
+
+// OrderQuoteOutput is an order priced as it would be created, with the total of each line,
+// the savings of its menus and the VAT included in its total.
+type OrderQuoteOutput struct {
+	Channel           OrderChannel
+	RequestedReadyAt  *time.Time
+	CustomerID        *uint
+	Items             []OrderQuoteLineOutput
+	AllergenAlert     Allergens
+	ContentAllergens  Allergens
+	Nutrition         Nutrition
+	ItemsCount        int
+	Subtotal          float64 // Total price with the menu products bought separately
+	Discount          float64
+	TotalPrice        float64
+	VATRate           float64 // In percent
+	VATAmount         float64
+	TotalExcludingVAT float64
+	RedeemedPoints    int
+	EarnedPoints      int
+}
+
+func TransformOrderToQuoteOutput(order *Order) OrderQuoteOutput {
+	output := OrderQuoteOutput{
+		Channel:          order.Channel,
+		RequestedReadyAt: order.RequestedReadyAt,
+		CustomerID:       order.CustomerID,
+		Items:            make([]OrderQuoteLineOutput, 0, len(order.Items)),
+		AllergenAlert:    order.AllergenAlert(),
+		ContentAllergens: order.ContentAllergens(),
+		Nutrition:        order.Nutrition(),
+		TotalPrice:       calculateOrderTotalPrice(order),
+		RedeemedPoints:   order.RedeemedPoints(),
+		EarnedPoints:     order.EarnedPoints(order.Customer),
+	}
+
+	for _, item := range order.Items {
+		output.Items = append(output.Items, OrderQuoteLineOutput{
+			ProductID:      item.ProductID,
+			MenuID:         item.MenuID,
+			Name:           item.OrderContentName,
+			Quantity:       item.Quantity,
+			UnitPrice:      item.OrderContentPrice,
+			Discount:       item.Discount(),
+			TotalPrice:     item.TotalPrice(),
+			RedeemedPoints: item.RedeemedPoints,
+			Allergens:      item.OrderContentAllergens,
+			Nutrition:      item.OrderContentNutrition,
+		})
+
+		output.ItemsCount += item.Quantity
+		output.Discount += item.Discount()
+	}
+
+	output.Discount = roundPrice(output.Discount)
+	output.Subtotal = roundPrice(output.TotalPrice + output.Discount)
+	output.VATRate = config.VATRate()
+	output.VATAmount = roundPrice(output.TotalPrice * output.VATRate / (100 + output.VATRate))
+	output.TotalExcludingVAT = roundPrice(output.TotalPrice - output.VATAmount)
+
+	return output
+}
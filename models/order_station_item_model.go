@@ -0,0 +1,183 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// OrderStationItem is the part of an order item that a kitchen station has to prepare:
+// a menu is split into one station item per product.
+type OrderStationItem struct {
+	ID               uint `gorm:"primaryKey"`
+	OrderItemID      uint `gorm:"index"`
+	KitchenStationID uint `gorm:"index"`
+	ProductID        uint
+	ProductName      string
+	Quantity         int
+	Status           OrderStatus
+	PreparedAt       time.Time
+}
+
+type KitchenTicketOutput struct {
+	ID           uint
+	OrderID      uint
+	TicketNumber string
+	Channel      OrderChannel
+	Priority     int
+	DueAt        time.Time
+	ProductName  string
+	Quantity     int
+	Status       OrderStatus
+	Notes        string
+	OrderNotes   string
+	Allergens    Allergens
+	Amendments   []string // Item changes made on the order after its preparation started, such as "+1 Frites, -1 Coca-Cola"
+}
+
+func FindOrderStationItemByContext(context *gin.Context) (stationItem *OrderStationItem, order *Order, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, nil, err
+	}
+
+	if err = config.DB.WithContext(context).First(&stationItem, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Order item not found."})
+
+			return nil, nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch order item."})
+
+		return nil, nil, err
+	}
+
+	var orderItem OrderItem
+	if err = config.DB.WithContext(context).First(&orderItem, stationItem.OrderItemID).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch order item."})
+
+		return nil, nil, err
+	}
+
+	order, err = FindOrderById(context, orderItem.OrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stationItem, order, nil
+}
+
+// BuildKitchenTickets returns the items a kitchen station still has to prepare, following the priority of their orders.
+func BuildKitchenTickets(kitchenStationID uint, orders []Order, now time.Time) []KitchenTicketOutput {
+	tickets := make([]KitchenTicketOutput, 0)
+
+	for _, queuedOrder := range PrioritizeOrders(orders, now) {
+		amendments := make([]string, 0, len(queuedOrder.Amendments))
+		for _, amendment := range queuedOrder.Amendments {
+			amendments = append(amendments, amendment.Summary)
+		}
+
+		for _, item := range queuedOrder.Items {
+			for _, stationItem := range item.StationItems {
+				if stationItem.KitchenStationID != kitchenStationID || stationItem.Status == Prepared {
+					continue
+				}
+
+				tickets = append(tickets, KitchenTicketOutput{
+					ID:           stationItem.ID,
+					OrderID:      queuedOrder.ID,
+					TicketNumber: queuedOrder.TicketNumber,
+					Channel:      queuedOrder.Channel,
+					Priority:     queuedOrder.Priority,
+					DueAt:        queuedOrder.DueAt,
+					ProductName:  stationItem.ProductName,
+					Quantity:     stationItem.Quantity,
+					Status:       stationItem.Status,
+					Notes:        item.Notes,
+					OrderNotes:   queuedOrder.Notes,
+					Allergens:    MergeAllergens(queuedOrder.Allergens, item.Allergens),
+					Amendments:   amendments,
+				})
+			}
+		}
+	}
+
+	return tickets
+}
+
+// CompleteOrderStationItems marks every station item of an order as prepared,
+// when the order itself has been declared prepared.
+func CompleteOrderStationItems(db *gorm.DB, order *Order) error {
+	for itemIndex := range order.Items {
+		for stationItemIndex := range order.Items[itemIndex].StationItems {
+			stationItem := &order.Items[itemIndex].StationItems[stationItemIndex]
+			if stationItem.Status == Prepared {
+				continue
+			}
+
+			updates := map[string]interface{}{
+				"status":     Prepared,
+				"preparedAt": order.PreparedAt,
+			}
+
+			if err := db.Model(stationItem).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CountPendingOrderStationItems returns how many station items of an order are not prepared yet.
+func CountPendingOrderStationItems(db *gorm.DB, order *Order) (count int64, err error) {
+	err = db.Model(&OrderStationItem{}).
+		Joins("JOIN order_items ON order_items.id = order_station_items.order_item_id").
+		Where("order_items.order_id = ? AND order_station_items.status <> ?", order.ID, Prepared).
+		Count(&count).Error
+
+	return count, err
+}
+
+func buildOrderStationItems(product *Product, variant *ProductVariant, quantity int) []OrderStationItem {
+	kitchenStationID := product.KitchenStationID
+
+	if kitchenStationID == nil {
+		var productCategory ProductCategory
+		if err := config.DB.First(&productCategory, product.CategoryID).Error; err == nil {
+			kitchenStationID = productCategory.KitchenStationID
+		}
+	}
+
+	if kitchenStationID == nil {
+		return nil
+	}
+
+	// The kitchen reads the products in the default language, whatever the language of the order.
+	productName := product.Name
+	if product.defaultName != "" {
+		productName = product.defaultName
+	}
+
+	if variant != nil {
+		productName = variant.nameWith(productName)
+	}
+
+	return []OrderStationItem{{
+		KitchenStationID: *kitchenStationID,
+		ProductID:        product.ID,
+		ProductName:      productName,
+		Quantity:         quantity,
+		Status:           Created,
+	}}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"net/http"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderStatusHistory records a status change of an order, and who made it.
+type OrderStatusHistory struct {
+	ID         uint `gorm:"primaryKey"`
+	OrderID    uint `gorm:"index"`
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	UserID     uint
+	IsRevert   bool
+	Reason     string
+	CreatedAt  time.Time
+}
+
+type OrderRevertInput struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func FindOrderStatusHistory(context *gin.Context, order *Order) (history []OrderStatusHistory, err error) {
+	if err = config.DB.WithContext(context).Where("order_id = ?", order.ID).Order("id").Find(&history).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch order history."})
+
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -3,8 +3,11 @@ package models
 type OrderStatus string
 
 const (
-	Created       OrderStatus = "created"
-	InPreparation OrderStatus = "inPreparation"
-	Prepared      OrderStatus = "prepared"
-	Delivered     OrderStatus = "delivered"
+	Created        OrderStatus = "created"
+	InPreparation  OrderStatus = "inPreparation"
+	Prepared       OrderStatus = "prepared"
+	Claimed        OrderStatus = "claimed"
+	OutForDelivery OrderStatus = "outForDelivery"
+	Delivered      OrderStatus = "delivered"
+	Cancelled      OrderStatus = "cancelled"
 )
@@ -0,0 +1,329 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Errors returned when an order cannot be moved to a status.
+var (
+	ErrOrderAlreadyInPreparation   = errors.New("order is already in preparation")
+	ErrOrderAlreadyPrepared        = errors.New("order is already prepared")
+	ErrOrderAlreadyClaimed         = errors.New("order is already claimed")
+	ErrOrderAlreadyOutForDelivery  = errors.New("order is already out for delivery")
+	ErrOrderAlreadyDelivered       = errors.New("order is already delivered")
+	ErrOrderAlreadyCancelled       = errors.New("order is already cancelled")
+	ErrOrderCancelled              = errors.New("order is cancelled")
+	ErrOrderHeld                   = errors.New("order is scheduled for later and cannot be prepared yet")
+	ErrOrderNotInPreparation       = errors.New("order must be in preparation before it can be prepared")
+	ErrOrderNotDelivery            = errors.New("order is not a delivery order")
+	ErrOrderNotPreparedForClaim    = errors.New("order must be prepared before it can be claimed")
+	ErrOrderNotClaimed             = errors.New("order must be claimed before it can be out for delivery")
+	ErrOrderNotOutForDelivery      = errors.New("order must be out for delivery before it can be delivered")
+	ErrOrderNotPreparedForDelivery = errors.New("order must be prepared before it can be delivered")
+	ErrInvalidOrderStatus          = errors.New("invalid status")
+	ErrOrderClaimedByOtherDriver   = errors.New("order is not claimed by this driver")
+	ErrOrderNoPreviousStatus       = errors.New("order has no previous status")
+	ErrOrderUnknownPreviousStatus  = errors.New("previous status of the order is unknown")
+)
+
+// OrderStatusRoles lists, for each status an order can be moved to, the roles allowed to move it there.
+var OrderStatusRoles = map[OrderStatus][]UserRole{
+	InPreparation:  {Admin, OrderPicker},
+	Prepared:       {Admin, OrderPicker},
+	Claimed:        {Admin, Driver},
+	OutForDelivery: {Admin, Driver},
+	Delivered:      {Admin, Manager, Greeter, Driver},
+	Cancelled:      {Admin, Manager},
+}
+
+// ValidateStatusTransition checks that the order can be moved to the given status,
+// and returns the reason why it cannot otherwise.
+// Delivery orders are claimed by a driver and taken out for delivery between being prepared and delivered.
+func (order *Order) ValidateStatusTransition(status OrderStatus, now time.Time) error {
+	switch status {
+	case InPreparation:
+		switch order.Status {
+		case InPreparation:
+			return ErrOrderAlreadyInPreparation
+		case Prepared, Claimed, OutForDelivery:
+			return ErrOrderAlreadyPrepared
+		case Delivered:
+			return ErrOrderAlreadyDelivered
+		case Cancelled:
+			return ErrOrderCancelled
+		}
+
+		if order.IsHeld(now) {
+			return ErrOrderHeld
+		}
+	case Prepared:
+		switch order.Status {
+		case Prepared, Claimed, OutForDelivery:
+			return ErrOrderAlreadyPrepared
+		case Created:
+			return ErrOrderNotInPreparation
+		case Delivered:
+			return ErrOrderAlreadyDelivered
+		case Cancelled:
+			return ErrOrderCancelled
+		}
+	case Claimed, OutForDelivery:
+		if order.Channel != Delivery {
+			return ErrOrderNotDelivery
+		}
+
+		switch order.Status {
+		case Claimed:
+			if status == Claimed {
+				return ErrOrderAlreadyClaimed
+			}
+		case OutForDelivery:
+			return ErrOrderAlreadyOutForDelivery
+		case Delivered:
+			return ErrOrderAlreadyDelivered
+		case Cancelled:
+			return ErrOrderCancelled
+		case Prepared:
+			if status == OutForDelivery {
+				return ErrOrderNotClaimed
+			}
+		default:
+			if status == OutForDelivery {
+				return ErrOrderNotClaimed
+			}
+
+			return ErrOrderNotPreparedForClaim
+		}
+	case Delivered:
+		switch order.Status {
+		case Delivered:
+			return ErrOrderAlreadyDelivered
+		case Cancelled:
+			return ErrOrderCancelled
+		}
+
+		if order.Channel == Delivery && order.Status != OutForDelivery {
+			return ErrOrderNotOutForDelivery
+		}
+
+		if order.Channel != Delivery && order.Status != Prepared {
+			return ErrOrderNotPreparedForDelivery
+		}
+	case Cancelled:
+		switch order.Status {
+		case Cancelled:
+			return ErrOrderAlreadyCancelled
+		case Delivered:
+			return ErrOrderAlreadyDelivered
+		}
+	default:
+		return ErrInvalidOrderStatus
+	}
+
+	return nil
+}
+
+// ValidateDriver checks that a driver only takes out and delivers the orders they claimed.
+func (order *Order) ValidateDriver(status OrderStatus, user *User) error {
+	if user.Role != Driver || status == Claimed {
+		return nil
+	}
+
+	if order.DriverID == nil || *order.DriverID != user.ID {
+		return ErrOrderClaimedByOtherDriver
+	}
+
+	return nil
+}
+
+// statusPredecessors gives the previous status of an order that has no history,
+// a cancelled order having no single previous status.
+var statusPredecessors = map[OrderStatus]OrderStatus{
+	InPreparation:  Created,
+	Prepared:       InPreparation,
+	Claimed:        Prepared,
+	OutForDelivery: Claimed,
+	Delivered:      Prepared,
+}
+
+// LockStatus locks the order until the end of the transaction, and reloads its status,
+// which another request may have changed since the order was loaded.
+func (order *Order) LockStatus(tx *gorm.DB) error {
+	var current Order
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("status").First(&current, order.ID).Error; err != nil {
+		return err
+	}
+
+	order.Status = current.Status
+
+	return nil
+}
+
+// ApplyStatusTransition moves the order to the given status, stamps the matching date and records the change in the order history.
+// It does not validate the transition, see ValidateStatusTransition.
+func (order *Order) ApplyStatusTransition(db *gorm.DB, status OrderStatus, userID uint, now time.Time) error {
+	history := OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		UserID:     userID,
+		CreatedAt:  now,
+	}
+
+	updates := map[string]interface{}{
+		"status": status,
+	}
+
+	switch status {
+	case InPreparation:
+		updates["inPreparationAt"] = now
+	case Prepared:
+		updates["preparedAt"] = now
+	case Claimed:
+		updates["driverID"] = userID
+		updates["claimedAt"] = now
+	case OutForDelivery:
+		updates["outForDeliveryAt"] = now
+	case Delivered:
+		updates["deliveredAt"] = now
+	case Cancelled:
+		updates["cancelledAt"] = now
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(order).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		if err := EmitOrderStatusEvents(tx, order, history.FromStatus, now); err != nil {
+			return err
+		}
+
+		if status == Prepared {
+			return CompleteOrderStationItems(tx, order)
+		}
+
+		return SettleLoyaltyPoints(tx, order, now)
+	})
+}
+
+// PreviousStatus returns the status the order was in before its current one,
+// as recorded in its history, or deduced from its current status for older orders.
+func (order *Order) PreviousStatus(db *gorm.DB) (OrderStatus, error) {
+	var history OrderStatusHistory
+
+	err := db.Where("order_id = ? AND to_status = ? AND is_revert = ?", order.ID, order.Status, false).Order("id DESC").Limit(1).Find(&history).Error
+	if err != nil {
+		return "", err
+	}
+
+	if history.ID != 0 {
+		return history.FromStatus, nil
+	}
+
+	if order.Status == Created {
+		return "", ErrOrderNoPreviousStatus
+	}
+
+	previousStatus, ok := statusPredecessors[order.Status]
+	if order.Status == Delivered && order.Channel == Delivery {
+		previousStatus = OutForDelivery
+	}
+
+	if !ok {
+		return "", ErrOrderUnknownPreviousStatus
+	}
+
+	return previousStatus, nil
+}
+
+// RevertStatusTransition moves the order back to the given previous status, clears the date of the reverted status
+// and records the revert with its reason in the order history.
+// The station items completed along with the order are sent back to the kitchen.
+func (order *Order) RevertStatusTransition(db *gorm.DB, previousStatus OrderStatus, userID uint, reason string, now time.Time) error {
+	history := OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: order.Status,
+		ToStatus:   previousStatus,
+		UserID:     userID,
+		IsRevert:   true,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+
+	updates := map[string]interface{}{
+		"status": previousStatus,
+	}
+
+	switch order.Status {
+	case InPreparation:
+		updates["inPreparationAt"] = time.Time{}
+	case Prepared:
+		updates["preparedAt"] = time.Time{}
+	case Claimed:
+		updates["driverID"] = nil
+		updates["claimedAt"] = time.Time{}
+	case OutForDelivery:
+		updates["outForDeliveryAt"] = time.Time{}
+	case Delivered:
+		updates["deliveredAt"] = time.Time{}
+	case Cancelled:
+		updates["cancelledAt"] = time.Time{}
+	}
+
+	revertedStatus := order.Status
+	preparedAt := order.PreparedAt
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if revertedStatus == Prepared {
+			if err := reopenOrderStationItems(tx, order, preparedAt); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(order).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		if err := EmitOrderStatusEvents(tx, order, history.FromStatus, now); err != nil {
+			return err
+		}
+
+		return SettleLoyaltyPoints(tx, order, now)
+	})
+}
+
+// reopenOrderStationItems sends back to preparation the station items that were completed when the order was declared prepared.
+func reopenOrderStationItems(db *gorm.DB, order *Order, preparedAt time.Time) error {
+	for itemIndex := range order.Items {
+		for stationItemIndex := range order.Items[itemIndex].StationItems {
+			stationItem := &order.Items[itemIndex].StationItems[stationItemIndex]
+			if stationItem.Status != Prepared || !stationItem.PreparedAt.Equal(preparedAt) {
+				continue
+			}
+
+			updates := map[string]interface{}{
+				"status":     InPreparation,
+				"preparedAt": time.Time{},
+			}
+
+			if err := db.Model(stationItem).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
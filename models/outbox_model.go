@@ -0,0 +1,231 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DomainEventType is a change of the orders, the catalog or the users that can be subscribed to.
+type DomainEventType string
+
+const (
+	OrderCreated           DomainEventType = "order.created"
+	OrderUpdated           DomainEventType = "order.updated"
+	OrderStatusChanged     DomainEventType = "order.statusChanged"
+	OrderCancelled         DomainEventType = "order.cancelled"
+	OrderAmended           DomainEventType = "order.amended"
+	ProductCreated         DomainEventType = "product.created"
+	ProductUpdated         DomainEventType = "product.updated"
+	ProductDeleted         DomainEventType = "product.deleted"
+	MenuCreated            DomainEventType = "menu.created"
+	MenuUpdated            DomainEventType = "menu.updated"
+	MenuDeleted            DomainEventType = "menu.deleted"
+	ProductCategoryCreated DomainEventType = "productCategory.created"
+	ProductCategoryUpdated DomainEventType = "productCategory.updated"
+	ProductCategoryDeleted DomainEventType = "productCategory.deleted"
+	UserCreated            DomainEventType = "user.created"
+	UserUpdated            DomainEventType = "user.updated"
+	UserDeleted            DomainEventType = "user.deleted"
+)
+
+// OrderEventTypes are the event types whose aggregate is an order.
+var OrderEventTypes = []DomainEventType{OrderCreated, OrderUpdated, OrderStatusChanged, OrderCancelled, OrderAmended}
+
+// OrderEvent is the payload of the order events.
+type OrderEvent struct {
+	Order          OrderOutput
+	PreviousStatus OrderStatus
+}
+
+// ProductEvent is the payload of the product events.
+type ProductEvent struct {
+	Product Product
+}
+
+// MenuEvent is the payload of the menu events.
+type MenuEvent struct {
+	Menu Menu
+}
+
+// ProductCategoryEvent is the payload of the product category events.
+type ProductCategoryEvent struct {
+	ProductCategory ProductCategory
+}
+
+// UserEvent is the payload of the user events.
+type UserEvent struct {
+	User UserOutput
+}
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes,
+// then delivered to the subscribers by the outbox dispatch job.
+// An event is delivered at least once: it is attempted again, with all its subscribers, until every subscriber succeeds.
+type OutboxEvent struct {
+	ID            uint `gorm:"primaryKey"`
+	Type          DomainEventType
+	AggregateID   uint
+	Payload       string
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"index"`
+	DispatchedAt  time.Time
+	Error         string
+	CreatedAt     time.Time
+}
+
+// Decode reads the payload of the event into the payload type of its domain, such as OrderEvent.
+func (event *OutboxEvent) Decode(payload interface{}) error {
+	return json.Unmarshal([]byte(event.Payload), payload)
+}
+
+// EventHandler handles an event delivered by the outbox, in a transaction committed only if it succeeds.
+// As an event may be delivered more than once, a handler must be idempotent.
+type EventHandler func(tx *gorm.DB, event *OutboxEvent) error
+
+// EventSubscriber is a handler registered for some event types.
+type EventSubscriber struct {
+	Name    string
+	Handler EventHandler
+	Types   []DomainEventType
+}
+
+var (
+	subscribersMutex sync.RWMutex
+	subscribers      = map[string]EventSubscriber{}
+)
+
+// Subscribe registers a handler for the given event types, replacing the handler already registered with the same name.
+func Subscribe(name string, handler EventHandler, types ...DomainEventType) {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+
+	subscribers[name] = EventSubscriber{Name: name, Handler: handler, Types: types}
+}
+
+// Unsubscribe removes the handler registered with the given name.
+func Unsubscribe(name string) {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+
+	delete(subscribers, name)
+}
+
+// EventSubscribers returns the subscribers of an event type, sorted by name so they are always called in the same order.
+func EventSubscribers(eventType DomainEventType) []EventSubscriber {
+	subscribersMutex.RLock()
+	defer subscribersMutex.RUnlock()
+
+	eventSubscribers := make([]EventSubscriber, 0, len(subscribers))
+
+	for _, subscriber := range subscribers {
+		if slices.Contains(subscriber.Types, eventType) {
+			eventSubscribers = append(eventSubscribers, subscriber)
+		}
+	}
+
+	sort.Slice(eventSubscribers, func(i, j int) bool {
+		return eventSubscribers[i].Name < eventSubscribers[j].Name
+	})
+
+	return eventSubscribers
+}
+
+// EmitEvent writes an event in the outbox. It must be called with the transaction of the change,
+// so that the event exists if and only if the change is saved.
+func EmitEvent(tx *gorm.DB, eventType DomainEventType, aggregateID uint, payload interface{}, now time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	event := OutboxEvent{
+		Type:          eventType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+
+	return tx.Create(&event).Error
+}
+
+// EmitOrderEvent writes an order event in the outbox.
+func EmitOrderEvent(tx *gorm.DB, eventType DomainEventType, order *Order, previousStatus OrderStatus, now time.Time) error {
+	return EmitEvent(tx, eventType, order.ID, OrderEvent{Order: TransformOrderToOutput(order), PreviousStatus: previousStatus}, now)
+}
+
+// EmitOrderStatusEvents writes the events of a status change, a cancellation being also emitted as such.
+func EmitOrderStatusEvents(tx *gorm.DB, order *Order, previousStatus OrderStatus, now time.Time) error {
+	if err := EmitOrderEvent(tx, OrderStatusChanged, order, previousStatus, now); err != nil {
+		return err
+	}
+
+	if order.Status == Cancelled {
+		return EmitOrderEvent(tx, OrderCancelled, order, previousStatus, now)
+	}
+
+	return nil
+}
+
+// The catalog and the users emit their events from GORM hooks, which run in the transaction of the change.
+// Bulk changes, which are not made on a loaded row, do not emit any event.
+
+func (product *Product) AfterCreate(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductCreated, product.ID, ProductEvent{Product: *product})
+}
+
+func (product *Product) AfterUpdate(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductUpdated, product.ID, ProductEvent{Product: *product})
+}
+
+func (product *Product) AfterDelete(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductDeleted, product.ID, ProductEvent{Product: *product})
+}
+
+func (menu *Menu) AfterCreate(tx *gorm.DB) error {
+	return emitModelEvent(tx, MenuCreated, menu.ID, MenuEvent{Menu: *menu})
+}
+
+func (menu *Menu) AfterUpdate(tx *gorm.DB) error {
+	return emitModelEvent(tx, MenuUpdated, menu.ID, MenuEvent{Menu: *menu})
+}
+
+func (menu *Menu) AfterDelete(tx *gorm.DB) error {
+	return emitModelEvent(tx, MenuDeleted, menu.ID, MenuEvent{Menu: *menu})
+}
+
+func (productCategory *ProductCategory) AfterCreate(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductCategoryCreated, productCategory.ID, ProductCategoryEvent{ProductCategory: *productCategory})
+}
+
+func (productCategory *ProductCategory) AfterUpdate(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductCategoryUpdated, productCategory.ID, ProductCategoryEvent{ProductCategory: *productCategory})
+}
+
+func (productCategory *ProductCategory) AfterDelete(tx *gorm.DB) error {
+	return emitModelEvent(tx, ProductCategoryDeleted, productCategory.ID, ProductCategoryEvent{ProductCategory: *productCategory})
+}
+
+func (user *User) AfterCreate(tx *gorm.DB) error {
+	return emitModelEvent(tx, UserCreated, user.ID, UserEvent{User: TransformUserToOutput(user)})
+}
+
+func (user *User) AfterUpdate(tx *gorm.DB) error {
+	return emitModelEvent(tx, UserUpdated, user.ID, UserEvent{User: TransformUserToOutput(user)})
+}
+
+func (user *User) AfterDelete(tx *gorm.DB) error {
+	return emitModelEvent(tx, UserDeleted, user.ID, UserEvent{User: TransformUserToOutput(user)})
+}
+
+func emitModelEvent(tx *gorm.DB, eventType DomainEventType, id uint, payload interface{}) error {
+	if id == 0 {
+		return nil
+	}
+
+	return EmitEvent(tx.Session(&gorm.Session{NewDB: true}), eventType, id, payload, time.Now())
+}
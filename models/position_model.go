@@ -0,0 +1,66 @@
+package models
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// The categories and products are displayed by position, the position being the order within their parent or category.
+
+// ReorderInput lists all the items of a group in their display order.
+type ReorderInput struct {
+	IDs []uint `json:"ids" binding:"required"`
+}
+
+// ValidateReorder checks that the new order lists each of the current items of the group exactly once.
+func ValidateReorder(context *gin.Context, ids []uint, currentIDs []uint) bool {
+	current := make(map[uint]bool, len(currentIDs))
+	for _, id := range currentIDs {
+		current[id] = true
+	}
+
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !current[id] || seen[id] {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order: each item of the group must be listed once."})
+
+			return false
+		}
+
+		seen[id] = true
+	}
+
+	if len(seen) != len(current) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order: each item of the group must be listed once."})
+
+		return false
+	}
+
+	return true
+}
+
+// ApplyPositions saves the index of each ID as the position of the item.
+func ApplyPositions(tx *gorm.DB, model interface{}, ids []uint) error {
+	for position, id := range ids {
+		if err := tx.Model(model).Where("id = ?", id).Update("position", position).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nextPosition(query *gorm.DB) (int, error) {
+	var position *int
+	if err := query.Select("MAX(position)").Scan(&position).Error; err != nil {
+		return 0, err
+	}
+
+	if position == nil {
+		return 0, nil
+	}
+
+	return *position + 1, nil
+}
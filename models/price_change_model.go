@@ -0,0 +1,263 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PriceChange records a price set for a product or a menu, and who set it.
+// A change effective in the future is scheduled: the price change job applies it when it takes effect.
+type PriceChange struct {
+	ID            uint    `gorm:"primaryKey"`
+	ProductID     *uint   `gorm:"index"`
+	MenuID        *uint   `gorm:"index"`
+	PreviousPrice float64 // Price replaced by the change, set when the change is applied
+	Price         float64
+	UserID        uint
+	EffectiveAt   time.Time  `gorm:"index"`
+	AppliedAt     *time.Time // Nil while the change is scheduled
+	CreatedAt     time.Time
+}
+
+type PriceChangeInsertInput struct {
+	Price       float64   `json:"price" binding:"required,gt=0"`
+	EffectiveAt time.Time `json:"effectiveAt" binding:"required"`
+}
+
+type PriceHistoryOutput struct {
+	Price     float64
+	History   []PriceChange // Applied changes, the latest first
+	Scheduled []PriceChange // Scheduled changes, in the order they take effect
+}
+
+type PriceAtOutput struct {
+	Price         float64
+	At            time.Time
+	PriceChangeID *uint // Change setting the price, nil for a price set before the history was recorded
+}
+
+// Priced is implemented by the products and the menus, whose price changes are recorded.
+type Priced interface {
+	priceOwner() (column string, id uint)
+	currentPrice() float64
+	createdAt() time.Time
+}
+
+func (product *Product) priceOwner() (string, uint) {
+	return "product_id", product.ID
+}
+
+func (product *Product) currentPrice() float64 {
+	return product.Price
+}
+
+func (product *Product) createdAt() time.Time {
+	return product.CreatedAt
+}
+
+func (menu *Menu) priceOwner() (string, uint) {
+	return "menu_id", menu.ID
+}
+
+func (menu *Menu) currentPrice() float64 {
+	return menu.Price
+}
+
+func (menu *Menu) createdAt() time.Time {
+	return menu.CreatedAt
+}
+
+func newPriceChange(item Priced, price float64, userID uint, effectiveAt time.Time) PriceChange {
+	change := PriceChange{Price: price, UserID: userID, EffectiveAt: effectiveAt}
+
+	column, id := item.priceOwner()
+	if column == "product_id" {
+		change.ProductID = &id
+	} else {
+		change.MenuID = &id
+	}
+
+	return change
+}
+
+// RecordPriceChange records a price applied right away, when the item is created or its price updated.
+func RecordPriceChange(tx *gorm.DB, item Priced, previousPrice float64, price float64, userID uint, now time.Time) error {
+	change := newPriceChange(item, price, userID, now)
+	change.PreviousPrice = previousPrice
+	change.AppliedAt = &now
+
+	return tx.Create(&change).Error
+}
+
+// SchedulePriceChange validates and saves a price taking effect at a future date.
+func SchedulePriceChange(context *gin.Context, item Priced, input PriceChangeInsertInput, userID uint, now time.Time) (*PriceChange, bool) {
+	if !input.EffectiveAt.After(now) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Effective date must be in the future."})
+
+		return nil, false
+	}
+
+	change := newPriceChange(item, input.Price, userID, input.EffectiveAt)
+
+	if err := config.DB.WithContext(context).Create(&change).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to schedule price change."})
+
+		return nil, false
+	}
+
+	return &change, true
+}
+
+func FindPriceHistory(context *gin.Context, item Priced) (*PriceHistoryOutput, error) {
+	column, id := item.priceOwner()
+	output := &PriceHistoryOutput{Price: item.currentPrice(), History: []PriceChange{}, Scheduled: []PriceChange{}}
+
+	err := config.DB.WithContext(context).
+		Where(column+" = ? AND applied_at IS NOT NULL", id).
+		Order("effective_at DESC, id DESC").
+		Find(&output.History).Error
+	if err == nil {
+		err = config.DB.WithContext(context).
+			Where(column+" = ? AND applied_at IS NULL", id).
+			Order("effective_at, id").
+			Find(&output.Scheduled).Error
+	}
+
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch price history."})
+
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// FindScheduledPriceChangeByContext finds the scheduled change of the item given by the priceChangeID parameter.
+func FindScheduledPriceChangeByContext(context *gin.Context, item Priced) (change *PriceChange, err error) {
+	id, err := strconv.Atoi(context.Param("priceChangeID"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid price change ID."})
+
+		return nil, err
+	}
+
+	column, ownerID := item.priceOwner()
+
+	if err = config.DB.WithContext(context).Where(column+" = ? AND applied_at IS NULL", ownerID).First(&change, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Scheduled price change not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch price change."})
+
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// FindPriceAt returns the price in effect at the date of the at query parameter, now by default,
+// scheduled changes included for a future date.
+func FindPriceAt(context *gin.Context, item Priced, now time.Time) (*PriceAtOutput, error) {
+	at := now
+	if atParam := context.Query("at"); atParam != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, atParam); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date."})
+
+			return nil, err
+		}
+	}
+
+	if at.Before(item.createdAt()) {
+		context.JSON(http.StatusNotFound, gin.H{"error": "No price at this date."})
+
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	column, id := item.priceOwner()
+	output := &PriceAtOutput{Price: item.currentPrice(), At: at}
+
+	var changes []PriceChange
+
+	if err := config.DB.WithContext(context).
+		Where(column+" = ? AND effective_at <= ?", id, at).
+		Order("effective_at DESC, id DESC").
+		Limit(1).
+		Find(&changes).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch price."})
+
+		return nil, err
+	}
+
+	if len(changes) == 1 {
+		output.Price = changes[0].Price
+		output.PriceChangeID = &changes[0].ID
+
+		return output, nil
+	}
+
+	// Before the first recorded change, the price is the one it replaced.
+	if err := config.DB.WithContext(context).
+		Where(column+" = ? AND applied_at IS NOT NULL", id).
+		Order("effective_at, id").
+		Limit(1).
+		Find(&changes).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch price."})
+
+		return nil, err
+	}
+
+	if len(changes) == 1 && changes[0].PreviousPrice > 0 {
+		output.Price = changes[0].PreviousPrice
+	}
+
+	return output, nil
+}
+
+// Apply sets the price of a scheduled change on its product or menu, recording the price it replaces.
+// A deleted product or menu gets its new price too, kept if it is restored, and the change is only dropped
+// when its product or menu has been purged.
+func (change *PriceChange) Apply(tx *gorm.DB, now time.Time) error {
+	var item interface{}
+
+	if change.ProductID != nil {
+		item = &Product{}
+		if err := tx.Unscoped().First(item, *change.ProductID).Error; err != nil {
+			return change.dropIfNotFound(tx, err)
+		}
+
+		change.PreviousPrice = item.(*Product).Price
+	} else {
+		item = &Menu{}
+		if err := tx.Unscoped().First(item, *change.MenuID).Error; err != nil {
+			return change.dropIfNotFound(tx, err)
+		}
+
+		change.PreviousPrice = item.(*Menu).Price
+	}
+
+	if err := tx.Unscoped().Model(item).Update("price", change.Price).Error; err != nil {
+		return err
+	}
+
+	change.AppliedAt = &now
+
+	return tx.Select("PreviousPrice", "AppliedAt").Updates(change).Error
+}
+
+func (change *PriceChange) dropIfNotFound(tx *gorm.DB, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Delete(change).Error
+	}
+
+	return err
+}
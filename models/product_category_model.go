@@ -11,20 +11,66 @@ import (
 )
 
 type ProductCategory struct {
-	ID          uint `gorm:"primaryKey"`
-	Name        string
-	Description string
-	Products    []Product `gorm:"foreignKey:CategoryID"`
+	ID               uint    `gorm:"primaryKey"`
+	ExternalKey      *string `gorm:"uniqueIndex"` // Identifies the category in catalog imports, set by the first export or import
+	Name             string
+	Description      string
+	Translations     Translations // Name and description in other languages, by locale
+	KitchenStationID *uint
+	ParentID         *uint             `gorm:"index"` // Category it is nested in, nil for a top-level category
+	Position         int               // Display order among the categories of the same parent
+	Children         []ProductCategory `gorm:"foreignKey:ParentID"` // Loaded by the category tree only
+	Products         []Product         `gorm:"foreignKey:CategoryID"`
+	DeletedAt        gorm.DeletedAt    `gorm:"index" swaggertype:"string" format:"date-time"`
+
+	AvailabilitySlots []AvailabilitySlot `gorm:"-"`
+	IsAvailableNow    bool               `gorm:"-"` // Within its slots
+
+	// Loaded for the languages of the request
+	Locale      string `gorm:"-"` // Language of the name and description, empty for the default language
+	defaultName string // Name in the default language, as stored
 }
 
 type ProductCategoryInsertInput struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description" binding:"required"`
+	Name             string                      `json:"name" binding:"required"`
+	Description      string                      `json:"description" binding:"required"`
+	KitchenStationID *uint                       `json:"kitchenStationID"`
+	ParentID         *uint                       `json:"parentID"`
+	Position         *int                        `json:"position" binding:"omitempty,min=0"` // After the other categories of the parent if not set
+	Translations     map[string]TranslationInput `json:"translations"`
 }
 
 type ProductCategoryUpdateInput struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
+	Name             *string                      `json:"name"`
+	Description      *string                      `json:"description"`
+	KitchenStationID *uint                        `json:"kitchenStationID"`
+	ParentID         *uint                        `json:"parentID"` // 0 to move the category to the top level
+	Position         *int                         `json:"position" binding:"omitempty,min=0"`
+	Translations     *map[string]TranslationInput `json:"translations"` // Replaces all the translations
+}
+
+// ProductCategoryReorderInput lists all the categories of a parent, or the top-level categories, in their display order.
+type ProductCategoryReorderInput struct {
+	ParentID *uint  `json:"parentID"`
+	IDs      []uint `json:"ids" binding:"required"`
+}
+
+// ProductCategoryListOptions describes how the product category list can be sorted and searched.
+var ProductCategoryListOptions = ListOptions{
+	IDColumn: "product_categories.id",
+	SortFields: map[string]SortField{
+		"name":     {Column: "product_categories.name", Field: "Name"},
+		"position": {Column: "product_categories.position", Field: "Position"},
+	},
+	SearchFields: []string{"product_categories.name", "product_categories.description"},
+}
+
+// ProductCategoryTreeOptions describes the category tree, its top-level categories being in display order by default.
+var ProductCategoryTreeOptions = ListOptions{
+	IDColumn:     ProductCategoryListOptions.IDColumn,
+	SortFields:   ProductCategoryListOptions.SortFields,
+	DefaultSort:  "position",
+	SearchFields: ProductCategoryListOptions.SearchFields,
 }
 
 func FindProductCategoryByContext(context *gin.Context) (productCategory *ProductCategory, err error) {
@@ -41,9 +87,9 @@ func FindProductCategoryByContext(context *gin.Context) (productCategory *Produc
 }
 
 func FindProductCategoryById(context *gin.Context, id uint, productsPreload bool) (productCategory *ProductCategory, err error) {
-	query := config.DB
+	query := config.DB.WithContext(context)
 	if productsPreload {
-		query = query.Preload("Products")
+		query = query.Preload("Products", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") })
 	}
 
 	if err = query.First(&productCategory, id).Error; err != nil {
@@ -60,3 +106,76 @@ func FindProductCategoryById(context *gin.Context, id uint, productsPreload bool
 
 	return productCategory, nil
 }
+
+// LoadProductCategoryChildren nests their subcategories, at every level, in the given categories, in display order.
+func LoadProductCategoryChildren(context *gin.Context, productCategories []ProductCategory) error {
+	var all []ProductCategory
+	if err := config.DB.WithContext(context).Where("parent_id IS NOT NULL").Order("position, id").Find(&all).Error; err != nil {
+		return err
+	}
+
+	children := make(map[uint][]ProductCategory)
+	for _, productCategory := range all {
+		children[*productCategory.ParentID] = append(children[*productCategory.ParentID], productCategory)
+	}
+
+	var nest func(productCategories []ProductCategory)
+	nest = func(productCategories []ProductCategory) {
+		for index := range productCategories {
+			productCategories[index].Children = append([]ProductCategory{}, children[productCategories[index].ID]...)
+			nest(productCategories[index].Children)
+		}
+	}
+	nest(productCategories)
+
+	return nil
+}
+
+// FindProductCategoryParentId checks the parent given to a category, which cannot be the category itself or one of its
+// subcategories, 0 meaning no parent. The ID of the category is 0 for a new one.
+func FindProductCategoryParentId(context *gin.Context, id uint, parentID *uint) (*uint, bool) {
+	if parentID == nil || *parentID == 0 {
+		return nil, true
+	}
+
+	// Walks up from the parent, the visited categories guarding against a cycle already in the database.
+	visited := map[uint]bool{}
+	for ancestorID := parentID; ancestorID != nil; {
+		if *ancestorID == id {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent: a category cannot be nested in itself."})
+
+			return nil, false
+		}
+
+		if visited[*ancestorID] {
+			break
+		}
+		visited[*ancestorID] = true
+
+		ancestor, err := FindProductCategoryById(context, *ancestorID, false)
+		if err != nil {
+			return nil, false
+		}
+
+		ancestorID = ancestor.ParentID
+	}
+
+	return parentID, true
+}
+
+// NextProductCategoryPosition returns the position after the last category of the parent.
+func NextProductCategoryPosition(db *gorm.DB, parentID *uint) (int, error) {
+	query := db.Model(&ProductCategory{})
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	return nextPosition(query)
+}
+
+// NextProductPosition returns the position after the last product of the category.
+func NextProductPosition(db *gorm.DB, categoryID uint) (int, error) {
+	return nextPosition(db.Model(&Product{}).Where("category_id = ?", categoryID))
+}
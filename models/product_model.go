@@ -14,35 +14,90 @@ import (
 )
 
 type Product struct {
-	ID          uint `gorm:"primaryKey"`
-	Name        string
-	Description string
-	Image       string
-	Price       float64
-	IsAvailable bool
-	CategoryID  uint
-	Category    ProductCategory `gorm:"foreignKey:CategoryID"`
-	Menus       []Menu          `gorm:"many2many:menu_products"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID               uint    `gorm:"primaryKey"`
+	ExternalKey      *string `gorm:"uniqueIndex"` // Identifies the product in catalog imports, set by the first export or import
+	Name             string
+	Description      string
+	Translations     Translations // Name and description in other languages, by locale
+	Image            string
+	Price            float64
+	LoyaltyPoints    int // Points needed to get the product with loyalty points, 0 if it cannot be
+	IsAvailable      bool
+	CategoryID       uint
+	Category         ProductCategory `gorm:"foreignKey:CategoryID"`
+	Position         int             // Display order among the products of the category
+	KitchenStationID *uint
+	Allergens        Allergens
+	IsVegetarian     bool
+	Nutrition        Nutrition        `gorm:"embedded;embeddedPrefix:nutrition_"`
+	Menus            []Menu           `gorm:"many2many:menu_products"`
+	Variants         []ProductVariant `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
+
+	AvailabilitySlots []AvailabilitySlot `gorm:"-"`
+	IsAvailableNow    bool               `gorm:"-"` // Available, and within its slots and the slots of its category
+	categorySlots     []AvailabilitySlot
+
+	// Loaded for the languages of the request
+	Locale      string `gorm:"-"` // Language of the name and description, empty for the default language
+	defaultName string // Name in the default language, as stored, shown to the kitchen stations
 }
 
 type ProductInsertInput struct {
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description" binding:"required"`
-	Price       float64 `json:"price" binding:"required"`
-	IsAvailable bool    `json:"isAvailable" binding:"required"`
-	CategoryID  uint    `json:"categoryID" binding:"required"`
-	Image       string  `json:"image"`
+	Name             string                      `json:"name" binding:"required"`
+	Description      string                      `json:"description" binding:"required"`
+	Price            float64                     `json:"price" binding:"required"`
+	LoyaltyPoints    int                         `json:"loyaltyPoints" binding:"min=0"`
+	IsAvailable      bool                        `json:"isAvailable" binding:"required"`
+	CategoryID       uint                        `json:"categoryID" binding:"required"`
+	KitchenStationID *uint                       `json:"kitchenStationID"`
+	Allergens        Allergens                   `json:"allergens"`
+	IsVegetarian     bool                        `json:"isVegetarian"`
+	Nutrition        NutritionInput              `json:"nutrition"`
+	Image            string                      `json:"image"`
+	Translations     map[string]TranslationInput `json:"translations"`
+	Position         *int                        `json:"position" binding:"omitempty,min=0"` // After the other products of the category if not set
 }
 
 type ProductUpdateInput struct {
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Price       *float64 `json:"price"`
-	IsAvailable *bool    `json:"isAvailable"`
-	CategoryID  *uint    `json:"categoryID"`
-	Image       *string  `json:"image"`
+	Name             *string                      `json:"name"`
+	Description      *string                      `json:"description"`
+	Price            *float64                     `json:"price"`
+	LoyaltyPoints    *int                         `json:"loyaltyPoints" binding:"omitempty,min=0"`
+	IsAvailable      *bool                        `json:"isAvailable"`
+	CategoryID       *uint                        `json:"categoryID"`
+	KitchenStationID *uint                        `json:"kitchenStationID"`
+	Allergens        *Allergens                   `json:"allergens"`
+	IsVegetarian     *bool                        `json:"isVegetarian"`
+	Nutrition        *NutritionInput              `json:"nutrition"`
+	Image            *string                      `json:"image"`
+	Translations     *map[string]TranslationInput `json:"translations"`                       // Replaces all the translations
+	Position         *int                         `json:"position" binding:"omitempty,min=0"` // After the other products of the new category if not set when moved
+}
+
+// ProductListOptions describes how the product list can be sorted and searched.
+var ProductListOptions = ListOptions{
+	IDColumn: "products.id",
+	SortFields: map[string]SortField{
+		"name":     {Column: "products.name", Field: "Name"},
+		"price":    {Column: "products.price", Field: "Price"},
+		"position": {Column: "products.position", Field: "Position"},
+	},
+	SearchFields: []string{"products.name", "products.description"},
+	Preloads:     []string{"Category", "Variants"},
+}
+
+func (product *Product) AfterFind(tx *gorm.DB) error {
+	product.defaultName = product.Name
+	product.Locale = localize(tx.Statement.Context, product.Translations, &product.Name, &product.Description)
+
+	if err := applyCatalogAvailability(tx, "product_id", product.ID, &product.IsAvailable); err != nil {
+		return err
+	}
+
+	return product.applyAvailabilitySchedule(tx)
 }
 
 func FindProductByContext(context *gin.Context) (product *Product, err error) {
@@ -59,7 +114,7 @@ func FindProductByContext(context *gin.Context) (product *Product, err error) {
 }
 
 func FindProductById(context *gin.Context, id uint) (product *Product, err error) {
-	if err = config.DB.Preload("Category").Preload("Menus").First(&product, id).Error; err != nil {
+	if err = config.DB.WithContext(context).Preload("Category").Preload("Menus").Preload("Variants").First(&product, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			context.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("Product %d: item not found.", id)})
 
@@ -75,7 +130,7 @@ func FindProductById(context *gin.Context, id uint) (product *Product, err error
 }
 
 func FindProductsById(context *gin.Context, productsIDs []uint) (products *[]Product, err error) {
-	if err = config.DB.Find(&products, productsIDs).Error; err != nil {
+	if err = config.DB.WithContext(context).Find(&products, productsIDs).Error; err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch products."})
 
 		return nil, err
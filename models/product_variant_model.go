@@ -0,0 +1,181 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductVariant is a size or a version of a product, such as a large Coca-Cola, with its own price, availability and SKU.
+// A product with variants is ordered through one of them.
+type ProductVariant struct {
+	ID             uint `gorm:"primaryKey"`
+	ProductID      uint `gorm:"index"`
+	Name           string
+	SKU            string `gorm:"uniqueIndex"`
+	Price          float64
+	MenuSupplement float64 // Added to the price of a menu when the variant is chosen instead of the product, to upsize it
+	IsAvailable    bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type ProductVariantInsertInput struct {
+	Name           string  `json:"name" binding:"required"`
+	SKU            string  `json:"sku" binding:"required"`
+	Price          float64 `json:"price" binding:"required,gt=0"`
+	MenuSupplement float64 `json:"menuSupplement" binding:"min=0"`
+	IsAvailable    *bool   `json:"isAvailable"` // Available by default
+}
+
+type ProductVariantUpdateInput struct {
+	Name           *string  `json:"name"`
+	SKU            *string  `json:"sku"`
+	Price          *float64 `json:"price" binding:"omitempty,gt=0"`
+	MenuSupplement *float64 `json:"menuSupplement" binding:"omitempty,min=0"`
+	IsAvailable    *bool    `json:"isAvailable"`
+}
+
+// OrderItemMenuChoiceInput upsizes a product of a menu with one of its variants.
+type OrderItemMenuChoiceInput struct {
+	ProductID uint `json:"productID" binding:"required"`
+	VariantID uint `json:"variantID" binding:"required"`
+}
+
+// DisplayName returns the name of the product followed by the name of the variant, such as "Coca-Cola (Large)".
+func (variant *ProductVariant) DisplayName(product *Product) string {
+	return variant.nameWith(product.Name)
+}
+
+func (variant *ProductVariant) nameWith(productName string) string {
+	return fmt.Sprintf("%s (%s)", productName, variant.Name)
+}
+
+func FindProductVariantByContext(context *gin.Context, product *Product) (variant *ProductVariant, err error) {
+	id, err := strconv.Atoi(context.Param("variantID"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid variant ID."})
+
+		return nil, err
+	}
+
+	if err = config.DB.WithContext(context).Where("product_id = ?", product.ID).First(&variant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Product variant not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch product variant."})
+
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+// ValidateVariantSKU checks that the SKU is not used by another variant, SKUs being compared without their surrounding spaces.
+func ValidateVariantSKU(context *gin.Context, sku string, variantID uint) (string, bool) {
+	sku = strings.TrimSpace(sku)
+	if sku == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SKU."})
+
+		return "", false
+	}
+
+	var count int64
+	if err := config.DB.WithContext(context).Model(&ProductVariant{}).Where("sku = ? AND id <> ?", sku, variantID).Count(&count).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to check SKU."})
+
+		return "", false
+	}
+
+	if count > 0 {
+		context.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("SKU %s is already used.", sku)})
+
+		return "", false
+	}
+
+	return sku, true
+}
+
+// FindVariant returns the variant of the product with the given ID.
+func (product *Product) FindVariant(variantID uint) *ProductVariant {
+	for index := range product.Variants {
+		if product.Variants[index].ID == variantID {
+			return &product.Variants[index]
+		}
+	}
+
+	return nil
+}
+
+// orderableVariant returns the variant ordered for a product, required when the product has variants.
+func orderableVariant(context *gin.Context, product *Product, variantID uint, label string) (*ProductVariant, bool) {
+	if variantID == 0 {
+		if len(product.Variants) > 0 {
+			context.JSON(http.StatusBadRequest, gin.H{"error": label + ": a variant must be chosen."})
+
+			return nil, false
+		}
+
+		return nil, true
+	}
+
+	variant := product.FindVariant(variantID)
+	if variant == nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: invalid variant %d.", label, variantID)})
+
+		return nil, false
+	}
+
+	if !variant.IsAvailable {
+		context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: variant %d is not available.", label, variantID)})
+
+		return nil, false
+	}
+
+	return variant, true
+}
+
+// menuChoices validates the upsized products of a menu item, and returns the chosen variant of each product of the menu.
+func menuChoices(context *gin.Context, menu *Menu, choices []OrderItemMenuChoiceInput) (map[uint]*ProductVariant, bool) {
+	variants := make(map[uint]*ProductVariant, len(choices))
+
+	for _, choice := range choices {
+		var product *Product
+		for index := range menu.Products {
+			if menu.Products[index].ID == choice.ProductID {
+				product = &menu.Products[index]
+			}
+		}
+
+		if product == nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Menu %d: product %d is not part of the menu.", menu.ID, choice.ProductID)})
+
+			return nil, false
+		}
+
+		if _, ok := variants[product.ID]; ok {
+			context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Menu %d: product %d is chosen more than once.", menu.ID, product.ID)})
+
+			return nil, false
+		}
+
+		variant, ok := orderableVariant(context, product, choice.VariantID, fmt.Sprintf("Menu %d: product %d", menu.ID, product.ID))
+		if !ok {
+			return nil, false
+		}
+
+		variants[product.ID] = variant
+	}
+
+	return variants, true
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// RestaurantHeader lets an administrator choose which of its restaurants a request applies to.
+	RestaurantHeader = "X-Restaurant-ID"
+
+	RestaurantIdContextKey       = "restaurantID"
+	RestaurantIdsContextKey      = "restaurantIDs"
+	RestaurantLocationContextKey = "restaurantLocation"
+)
+
+type Restaurant struct {
+	ID               uint `gorm:"primaryKey"`
+	Name             string
+	Address          string
+	TimeZone         string `gorm:"default:Europe/Paris"`
+	LastOrderCutoff  int    // Minutes before closing from which orders are refused
+	LastTicketNumber int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type RestaurantInsertInput struct {
+	Name            string `json:"name" binding:"required"`
+	Address         string `json:"address"`
+	TimeZone        string `json:"timeZone"`
+	LastOrderCutoff int    `json:"lastOrderCutoff" binding:"min=0"`
+}
+
+type RestaurantUpdateInput struct {
+	Name            *string `json:"name"`
+	Address         *string `json:"address"`
+	TimeZone        *string `json:"timeZone"`
+	LastOrderCutoff *int    `json:"lastOrderCutoff" binding:"omitempty,min=0"`
+}
+
+// ValidateTimeZone checks that the time zone is a known IANA time zone, such as Europe/Paris.
+func ValidateTimeZone(context *gin.Context, timeZone string) bool {
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time zone."})
+
+		return false
+	}
+
+	return true
+}
+
+// GetRestaurantId returns the restaurant the current request applies to, as set by the Restaurant middleware.
+func GetRestaurantId(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+
+	restaurantID, ok := ctx.Value(RestaurantIdContextKey).(uint)
+
+	return restaurantID, ok
+}
+
+// GetRestaurantIds returns every restaurant the current user can access.
+func GetRestaurantIds(ctx context.Context) []uint {
+	if ctx == nil {
+		return nil
+	}
+
+	restaurantIDs, _ := ctx.Value(RestaurantIdsContextKey).([]uint)
+
+	return restaurantIDs
+}
+
+func FindRestaurantByContext(context *gin.Context) (restaurant *Restaurant, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	return FindRestaurantById(context, uint(id))
+}
+
+// FindRestaurantById only finds the restaurants the current user can access.
+func FindRestaurantById(context *gin.Context, id uint) (restaurant *Restaurant, err error) {
+	if !slices.Contains(GetRestaurantIds(context), id) {
+		context.JSON(http.StatusNotFound, gin.H{"error": "Restaurant not found."})
+
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err = config.DB.WithContext(context).First(&restaurant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Restaurant not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch restaurant."})
+
+		return nil, err
+	}
+
+	return restaurant, nil
+}
+
+// FindRestaurantsById only finds the restaurants the current user can access.
+func FindRestaurantsById(context *gin.Context, restaurantsIDs []uint) (restaurants []Restaurant, ok bool) {
+	restaurants = make([]Restaurant, 0, len(restaurantsIDs))
+
+	for _, restaurantID := range restaurantsIDs {
+		restaurant, err := FindRestaurantById(context, restaurantID)
+		if err != nil {
+			return nil, false
+		}
+
+		restaurants = append(restaurants, *restaurant)
+	}
+
+	return restaurants, true
+}
+
+// NextTicketNumber returns the next ticket number of the restaurant, each restaurant having its own sequence.
+func NextTicketNumber(db *gorm.DB, restaurantID uint) (string, error) {
+	var restaurant Restaurant
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Restaurant{}).Where("id = ?", restaurantID).UpdateColumn("last_ticket_number", gorm.Expr("last_ticket_number + 1")).Error; err != nil {
+			return err
+		}
+
+		return tx.First(&restaurant, restaurantID).Error
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%03d", restaurant.LastTicketNumber), nil
+}
+
+// AssignDefaultRestaurant attaches the users and the orders created before restaurants existed to a first restaurant.
+func AssignDefaultRestaurant(db *gorm.DB) error {
+	var restaurantsCount int64
+	if err := db.Model(&Restaurant{}).Count(&restaurantsCount).Error; err != nil || restaurantsCount > 0 {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		restaurant := Restaurant{Name: "Wacdo"}
+		if err := tx.Create(&restaurant).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&User{}, &Order{}, &ArchivedOrder{}} {
+			if err := tx.Model(model).Where("restaurant_id = 0 OR restaurant_id IS NULL").Update("restaurant_id", restaurant.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,344 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+	_ "time/tzdata"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultTimeZone   = "Europe/Paris"
+	openingHourLayout = "15:04"
+)
+
+// OpeningHour is an opening period of a restaurant on a day of the week (0 for Sunday),
+// a closing time before the opening time meaning that the restaurant closes after midnight.
+type OpeningHour struct {
+	ID           uint `gorm:"primaryKey"`
+	RestaurantID uint `gorm:"index"`
+	DayOfWeek    int
+	OpensAt      string
+	ClosesAt     string
+}
+
+// Closure is an exceptional closing period of a restaurant.
+type Closure struct {
+	ID           uint `gorm:"primaryKey"`
+	RestaurantID uint `gorm:"index"`
+	StartsAt     time.Time
+	EndsAt       time.Time
+	Reason       string
+	CreatedAt    time.Time
+}
+
+type OpeningHourInput struct {
+	DayOfWeek *int   `json:"dayOfWeek" binding:"required"`
+	OpensAt   string `json:"opensAt" binding:"required"`
+	ClosesAt  string `json:"closesAt" binding:"required"`
+}
+
+type ClosureInsertInput struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+type RestaurantStatusOutput struct {
+	RestaurantID  uint
+	Name          string
+	IsOpen        bool
+	AcceptsOrders bool
+	Reason        string
+	ClosesAt      *time.Time
+	NextOpeningAt *time.Time
+}
+
+// RestaurantSchedule gathers what defines when a restaurant is open.
+type RestaurantSchedule struct {
+	Restaurant   Restaurant
+	OpeningHours []OpeningHour
+	Closures     []Closure
+}
+
+type openingPeriod struct {
+	opensAt  time.Time
+	closesAt time.Time
+}
+
+// ToOpeningHour validates the opening hour input.
+func (input OpeningHourInput) ToOpeningHour(restaurantID uint) (OpeningHour, error) {
+	if *input.DayOfWeek < 0 || *input.DayOfWeek > 6 {
+		return OpeningHour{}, errors.New("Invalid day of week.")
+	}
+
+	opensAt, err := time.Parse(openingHourLayout, input.OpensAt)
+	if err != nil {
+		return OpeningHour{}, errors.New("Invalid opening time.")
+	}
+
+	closesAt, err := time.Parse(openingHourLayout, input.ClosesAt)
+	if err != nil {
+		return OpeningHour{}, errors.New("Invalid closing time.")
+	}
+
+	if opensAt.Equal(closesAt) {
+		return OpeningHour{}, errors.New("Opening and closing times must be different.")
+	}
+
+	return OpeningHour{
+		RestaurantID: restaurantID,
+		DayOfWeek:    *input.DayOfWeek,
+		OpensAt:      opensAt.Format(openingHourLayout),
+		ClosesAt:     closesAt.Format(openingHourLayout),
+	}, nil
+}
+
+// LoadRestaurantSchedule loads the opening hours and the closures of a restaurant.
+func LoadRestaurantSchedule(db *gorm.DB, restaurantID uint) (*RestaurantSchedule, error) {
+	schedule := &RestaurantSchedule{}
+
+	if err := db.First(&schedule.Restaurant, restaurantID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("restaurant_id = ?", restaurantID).Order("day_of_week, opens_at").Find(&schedule.OpeningHours).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Where("restaurant_id = ?", restaurantID).Order("starts_at").Find(&schedule.Closures).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (schedule *RestaurantSchedule) location() *time.Location {
+	return loadLocation(schedule.Restaurant.TimeZone)
+}
+
+func loadLocation(timeZone string) *time.Location {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		location, _ = time.LoadLocation(DefaultTimeZone)
+	}
+
+	return location
+}
+
+// RestaurantLocation returns the time zone of the restaurant of the request, loaded once per request.
+func RestaurantLocation(ctx context.Context) *time.Location {
+	if location, ok := ctx.Value(RestaurantLocationContextKey).(*time.Location); ok {
+		return location
+	}
+
+	timeZone := DefaultTimeZone
+
+	if restaurantID, ok := GetRestaurantId(ctx); ok {
+		var restaurant Restaurant
+		if err := config.DB.Select("time_zone").First(&restaurant, restaurantID).Error; err == nil {
+			timeZone = restaurant.TimeZone
+		}
+	}
+
+	location := loadLocation(timeZone)
+
+	if ginContext, ok := ctx.(*gin.Context); ok {
+		ginContext.Set(RestaurantLocationContextKey, location)
+	}
+
+	return location
+}
+
+// openingPeriods returns the opening periods starting on the day of the given date.
+// Without opening hours, a restaurant is open all day long.
+func (schedule *RestaurantSchedule) openingPeriods(date time.Time) []openingPeriod {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	if len(schedule.OpeningHours) == 0 {
+		return []openingPeriod{{opensAt: day, closesAt: day.AddDate(0, 0, 1)}}
+	}
+
+	var periods []openingPeriod
+
+	for _, openingHour := range schedule.OpeningHours {
+		if openingHour.DayOfWeek != int(day.Weekday()) {
+			continue
+		}
+
+		opensAt, errOpening := time.Parse(openingHourLayout, openingHour.OpensAt)
+		closesAt, errClosing := time.Parse(openingHourLayout, openingHour.ClosesAt)
+		if errOpening != nil || errClosing != nil {
+			continue
+		}
+
+		period := openingPeriod{
+			opensAt:  time.Date(day.Year(), day.Month(), day.Day(), opensAt.Hour(), opensAt.Minute(), 0, 0, day.Location()),
+			closesAt: time.Date(day.Year(), day.Month(), day.Day(), closesAt.Hour(), closesAt.Minute(), 0, 0, day.Location()),
+		}
+
+		if !period.closesAt.After(period.opensAt) {
+			period.closesAt = period.closesAt.AddDate(0, 0, 1)
+		}
+
+		periods = append(periods, period)
+	}
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].opensAt.Before(periods[j].opensAt) })
+
+	return periods
+}
+
+func (schedule *RestaurantSchedule) activeClosure(moment time.Time) *Closure {
+	for index := range schedule.Closures {
+		closure := &schedule.Closures[index]
+		if !moment.Before(closure.StartsAt) && moment.Before(closure.EndsAt) {
+			return closure
+		}
+	}
+
+	return nil
+}
+
+// activePeriod returns the opening period including the given moment, if any.
+func (schedule *RestaurantSchedule) activePeriod(moment time.Time) *openingPeriod {
+	local := moment.In(schedule.location())
+
+	// A period opened the day before may still be running after midnight.
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		for _, period := range schedule.openingPeriods(day) {
+			if !local.Before(period.opensAt) && local.Before(period.closesAt) {
+				return &period
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsOpenAt tells whether the restaurant is open at the given moment.
+func (schedule *RestaurantSchedule) IsOpenAt(moment time.Time) bool {
+	return schedule.activeClosure(moment) == nil && schedule.activePeriod(moment) != nil
+}
+
+// Status returns whether the restaurant is open and accepts orders, the last order cutoff closing the orders before the restaurant.
+func (schedule *RestaurantSchedule) Status(now time.Time) RestaurantStatusOutput {
+	status := RestaurantStatusOutput{
+		RestaurantID: schedule.Restaurant.ID,
+		Name:         schedule.Restaurant.Name,
+	}
+
+	if closure := schedule.activeClosure(now); closure != nil {
+		status.Reason = closure.Reason
+		if status.Reason == "" {
+			status.Reason = "Exceptional closure."
+		}
+	} else if len(schedule.OpeningHours) == 0 {
+		// Without opening hours, the restaurant never closes.
+		status.IsOpen = true
+		status.AcceptsOrders = true
+
+		return status
+	} else if period := schedule.activePeriod(now); period != nil {
+		status.IsOpen = true
+		status.ClosesAt = &period.closesAt
+
+		cutoff := period.closesAt.Add(-time.Duration(schedule.Restaurant.LastOrderCutoff) * time.Minute)
+		status.AcceptsOrders = now.Before(cutoff)
+
+		if !status.AcceptsOrders {
+			status.Reason = "Last order time has passed."
+		}
+
+		return status
+	} else {
+		status.Reason = "Outside opening hours."
+	}
+
+	status.NextOpeningAt = schedule.nextOpening(now)
+
+	return status
+}
+
+// nextOpening returns when the restaurant opens next, within the coming week:
+// either at the start of an opening period, or at the end of a closure.
+func (schedule *RestaurantSchedule) nextOpening(now time.Time) *time.Time {
+	local := now.In(schedule.location())
+
+	var candidates []time.Time
+	for day := -1; day <= 7; day++ {
+		for _, period := range schedule.openingPeriods(local.AddDate(0, 0, day)) {
+			candidates = append(candidates, period.opensAt)
+		}
+	}
+
+	for _, closure := range schedule.Closures {
+		candidates = append(candidates, closure.EndsAt.In(local.Location()))
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	for _, candidate := range candidates {
+		if candidate.After(now) && schedule.IsOpenAt(candidate) {
+			return &candidate
+		}
+	}
+
+	return nil
+}
+
+// ValidateOrderAcceptance rejects an order when the restaurant does not accept orders,
+// or, for a scheduled order, when the restaurant is not open or past its last order cutoff at the requested ready time.
+// Managers and administrators can override the opening hours.
+func ValidateOrderAcceptance(context *gin.Context, user *User, requestedReadyAt *time.Time, override bool) bool {
+	if override {
+		if user.Role != Admin && user.Role != Manager {
+			context.JSON(http.StatusForbidden, gin.H{"error": "Only managers can accept orders outside opening hours."})
+
+			return false
+		}
+
+		return true
+	}
+
+	restaurantID, _ := GetRestaurantId(context)
+
+	schedule, err := LoadRestaurantSchedule(config.DB, restaurantID)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch restaurant."})
+
+		return false
+	}
+
+	if requestedReadyAt != nil {
+		status := schedule.Status(*requestedReadyAt)
+
+		if !status.IsOpen {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Requested ready time is outside opening hours."})
+
+			return false
+		}
+
+		if !status.AcceptsOrders {
+			context.JSON(http.StatusBadRequest, gin.H{"error": "Requested ready time is after the last order time."})
+
+			return false
+		}
+
+		return true
+	}
+
+	if status := schedule.Status(time.Now()); !status.AcceptsOrders {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Restaurant does not accept orders.", "reason": status.Reason})
+
+		return false
+	}
+
+	return true
+}
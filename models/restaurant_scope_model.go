@@ -0,0 +1,95 @@
+package models
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RestaurantScoped is implemented by the models belonging to a restaurant.
+// Queries run with a request context (config.DB.WithContext(context)) only see the rows of the request restaurant,
+// and the rows created with it are assigned to this restaurant.
+type RestaurantScoped interface {
+	RestaurantScope(table string, restaurantID uint) clause.Expression
+}
+
+// RegisterRestaurantScope installs the callbacks scoping the queries to the request restaurant.
+func RegisterRestaurantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Query().Before("gorm:query").Register("restaurant:query", addRestaurantScope); err != nil {
+		return err
+	}
+
+	if err := callbacks.Query().Before("gorm:query").Register("restaurant:reset_availabilities", resetFoundAvailabilities); err != nil {
+		return err
+	}
+
+	if err := callbacks.Update().Before("gorm:update").Register("restaurant:update", addRestaurantScope); err != nil {
+		return err
+	}
+
+	if err := callbacks.Delete().Before("gorm:delete").Register("restaurant:delete", addRestaurantScope); err != nil {
+		return err
+	}
+
+	return callbacks.Create().Before("gorm:create").Register("restaurant:create", assignRestaurant)
+}
+
+func restaurantScopeOf(db *gorm.DB) (RestaurantScoped, uint, bool) {
+	if db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+
+	restaurantID, ok := GetRestaurantId(db.Statement.Context)
+	if !ok {
+		return nil, 0, false
+	}
+
+	scoped, ok := reflect.New(db.Statement.Schema.ModelType).Interface().(RestaurantScoped)
+
+	return scoped, restaurantID, ok
+}
+
+func addRestaurantScope(db *gorm.DB) {
+	scoped, restaurantID, ok := restaurantScopeOf(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{scoped.RestaurantScope(db.Statement.Table, restaurantID)}})
+}
+
+func assignRestaurant(db *gorm.DB) {
+	_, restaurantID, ok := restaurantScopeOf(db)
+	if !ok {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField("RestaurantID")
+	if field == nil {
+		return
+	}
+
+	assign := func(value reflect.Value) {
+		if _, isZero := field.ValueOf(db.Statement.Context, value); isZero {
+			if err := field.Set(db.Statement.Context, value, restaurantID); err != nil {
+				_ = db.AddError(err)
+			}
+		}
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < db.Statement.ReflectValue.Len(); index++ {
+			assign(reflect.Indirect(db.Statement.ReflectValue.Index(index)))
+		}
+	case reflect.Struct:
+		assign(db.Statement.ReflectValue)
+	}
+}
+
+func restaurantColumnScope(table string, restaurantID uint) clause.Expression {
+	return clause.Eq{Column: clause.Column{Table: table, Name: "restaurant_id"}, Value: restaurantID}
+}
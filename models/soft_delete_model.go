@@ -0,0 +1,168 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// The products, menus, product categories and users are soft deleted: they are hidden from every query but kept,
+// so that they can be restored, until they are purged.
+
+// WithDeleted includes the deleted records in a preload, for the records which remain visible in the history,
+// such as the user who took an order.
+func WithDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+// FindDeletedByContext returns the deleted record whose ID is in the request, the name of the model being used in the errors.
+func FindDeletedByContext[T any](context *gin.Context, name string) (*T, error) {
+	id, err := strconv.Atoi(context.Param("id"))
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	var record T
+	if err = config.DB.WithContext(context).Unscoped().Where("deleted_at IS NOT NULL").First(&record, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Deleted " + name + " not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch deleted " + name + "."})
+
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// DeletedQuery returns the query of the deleted records of the given table, for their lists.
+func DeletedQuery(context *gin.Context, model interface{}, table string) *gorm.DB {
+	return config.DB.WithContext(context).Unscoped().Model(model).Where(table + ".deleted_at IS NOT NULL")
+}
+
+// Restore clears the deletion of the record.
+func Restore(context *gin.Context, record interface{}) error {
+	return config.DB.WithContext(context).Unscoped().Model(record).Update("deleted_at", nil).Error
+}
+
+// ValidateProductRestore checks that the category of the product has not been deleted too.
+func ValidateProductRestore(context *gin.Context, product *Product) bool {
+	var count int64
+	if err := config.DB.WithContext(context).Model(&ProductCategory{}).Where("id = ?", product.CategoryID).Count(&count).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to check product category."})
+
+		return false
+	}
+
+	if count == 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Cannot restore product: its category is deleted."})
+
+		return false
+	}
+
+	return true
+}
+
+// ValidateMenuRestore checks that none of the products of the menu has been deleted.
+func ValidateMenuRestore(context *gin.Context, menu *Menu) bool {
+	var count int64
+	err := config.DB.WithContext(context).Table("menu_products").
+		Joins("JOIN products ON products.id = menu_products.product_id").
+		Where("menu_products.menu_id = ? AND products.deleted_at IS NOT NULL", menu.ID).
+		Count(&count).Error
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to check menu products."})
+
+		return false
+	}
+
+	if count > 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Cannot restore menu: some of its products are deleted."})
+
+		return false
+	}
+
+	return true
+}
+
+// ValidatePurge checks that no row of the given model references the record, in any restaurant, deleted rows included.
+func ValidatePurge(context *gin.Context, model interface{}, column string, id uint, message string) bool {
+	var count int64
+	if err := config.DB.Unscoped().Model(model).Where(column+" = ?", id).Count(&count).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to check references."})
+
+		return false
+	}
+
+	if count > 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": message})
+
+		return false
+	}
+
+	return true
+}
+
+// ValidateProductCategoryRestore checks that the parent of the category, if any, has not been deleted too.
+func ValidateProductCategoryRestore(context *gin.Context, productCategory *ProductCategory) bool {
+	if productCategory.ParentID == nil {
+		return true
+	}
+
+	var count int64
+	if err := config.DB.WithContext(context).Model(&ProductCategory{}).Where("id = ?", *productCategory.ParentID).Count(&count).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to check parent product category."})
+
+		return false
+	}
+
+	if count == 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Cannot restore product category: its parent is deleted."})
+
+		return false
+	}
+
+	return true
+}
+
+// PurgeProduct deletes the product for good, along with its variants, its availabilities and slots in every restaurant,
+// its price history and scheduled changes, and its rows in the menus, which can only be deleted menus.
+// Everything is deleted explicitly rather than left to the foreign key cascades.
+func PurgeProduct(tx *gorm.DB, product *Product) error {
+	for _, model := range []interface{}{&ProductVariant{}, &CatalogAvailability{}, &AvailabilitySlot{}, &PriceChange{}} {
+		if err := tx.Where("product_id = ?", product.ID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM menu_products WHERE product_id = ?", product.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Delete(product).Error
+}
+
+// PurgeMenu deletes the menu for good, along with its availabilities and slots in every restaurant,
+// its price history and scheduled changes, and its product rows.
+func PurgeMenu(tx *gorm.DB, menu *Menu) error {
+	for _, model := range []interface{}{&CatalogAvailability{}, &AvailabilitySlot{}, &PriceChange{}} {
+		if err := tx.Where("menu_id = ?", menu.ID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec("DELETE FROM menu_products WHERE menu_id = ?", menu.ID).Error; err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Delete(menu).Error
+}
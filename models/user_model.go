@@ -3,21 +3,26 @@ package models
 import (
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 	"wacdo/config"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type User struct {
-	ID        uint   `gorm:"primaryKey"`
-	Email     string `gorm:"unique"`
-	Password  string
-	Role      UserRole
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uint   `gorm:"primaryKey"`
+	Email        string `gorm:"unique"`
+	Password     string
+	Role         UserRole
+	RestaurantID uint         `gorm:"index"`
+	Restaurants  []Restaurant `gorm:"many2many:user_restaurants"` // Other restaurants managed by an administrator
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
 }
 
 type UserLoginInput struct {
@@ -26,23 +31,73 @@ type UserLoginInput struct {
 }
 
 type UserInsertInput struct {
-	Email    string   `json:"email" binding:"required,email"`
-	Password string   `json:"password" binding:"required,min=8"`
-	Role     UserRole `json:"role" binding:"required"`
+	Email         string   `json:"email" binding:"required,email"`
+	Password      string   `json:"password" binding:"required,min=8"`
+	Role          UserRole `json:"role" binding:"required"`
+	RestaurantIDs []uint   `json:"restaurantIDs"`
 }
 
 type UserUpdateInput struct {
-	Email    *string   `json:"email" binding:"omitempty,email"`
-	Password *string   `json:"password"`
-	Role     *UserRole `json:"role"`
+	Email         *string   `json:"email" binding:"omitempty,email"`
+	Password      *string   `json:"password"`
+	Role          *UserRole `json:"role"`
+	RestaurantIDs *[]uint   `json:"restaurantIDs"`
 }
 
 type UserOutput struct {
-	ID        uint
-	Email     string
-	Role      UserRole
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            uint
+	Email         string
+	Role          UserRole
+	RestaurantID  uint
+	RestaurantIDs []uint
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time // Set for a deleted user
+}
+
+// UserListOptions describes how the user list can be sorted and searched.
+var UserListOptions = ListOptions{
+	IDColumn: "users.id",
+	SortFields: map[string]SortField{
+		"email": {Column: "users.email", Field: "Email"},
+		"role":  {Column: "users.role", Field: "Role"},
+	},
+	SearchFields: []string{"users.email"},
+	Preloads:     []string{"Restaurants"},
+}
+
+// RestaurantScope makes the users visible in their restaurant, and the administrators in the restaurants they manage.
+func (User) RestaurantScope(table string, restaurantID uint) clause.Expression {
+	return clause.Or(
+		restaurantColumnScope(table, restaurantID),
+		clause.Expr{SQL: "? IN (SELECT user_id FROM user_restaurants WHERE restaurant_id = ?)", Vars: []interface{}{clause.Column{Table: table, Name: "id"}, restaurantID}},
+	)
+}
+
+// RestaurantIDs returns the restaurants the user can access: its own one, and the ones it manages as an administrator.
+func (user *User) RestaurantIDs() []uint {
+	restaurantIDs := []uint{user.RestaurantID}
+
+	if user.Role == Admin {
+		for _, restaurant := range user.Restaurants {
+			if !slices.Contains(restaurantIDs, restaurant.ID) {
+				restaurantIDs = append(restaurantIDs, restaurant.ID)
+			}
+		}
+	}
+
+	return restaurantIDs
+}
+
+// FindManagedRestaurants validates the restaurants given in a user input, which only administrators can manage.
+func FindManagedRestaurants(context *gin.Context, role UserRole, restaurantsIDs []uint) ([]Restaurant, bool) {
+	if len(restaurantsIDs) > 0 && role != Admin {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Only administrators can manage several restaurants."})
+
+		return nil, false
+	}
+
+	return FindRestaurantsById(context, restaurantsIDs)
 }
 
 func FindUserByContext(context *gin.Context) (user *User, err error) {
@@ -59,7 +114,7 @@ func FindUserByContext(context *gin.Context) (user *User, err error) {
 }
 
 func FindUserById(context *gin.Context, id uint) (user *User, err error) {
-	if err = config.DB.First(&user, id).Error; err != nil {
+	if err = config.DB.WithContext(context).Preload("Restaurants").First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			context.JSON(http.StatusNotFound, gin.H{"error": "User not found."})
 
@@ -85,11 +140,24 @@ func TransformUsersToOutput(users []User) []UserOutput {
 }
 
 func TransformUserToOutput(user *User) UserOutput {
-	return UserOutput{
-		ID:        user.ID,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	restaurantIDs := make([]uint, 0, len(user.Restaurants))
+	for _, restaurant := range user.Restaurants {
+		restaurantIDs = append(restaurantIDs, restaurant.ID)
 	}
+
+	output := UserOutput{
+		ID:            user.ID,
+		Email:         user.Email,
+		Role:          user.Role,
+		RestaurantID:  user.RestaurantID,
+		RestaurantIDs: restaurantIDs,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+
+	if user.DeletedAt.Valid {
+		output.DeletedAt = &user.DeletedAt.Time
+	}
+
+	return output
 }
@@ -7,11 +7,12 @@ const (
 	Greeter     UserRole = "greeter"
 	OrderPicker UserRole = "order_picker"
 	Manager     UserRole = "manager"
+	Driver      UserRole = "driver"
 )
 
 func (role UserRole) IsValid() bool {
 	switch role {
-	case Admin, Greeter, OrderPicker, Manager:
+	case Admin, Greeter, OrderPicker, Manager, Driver:
 		return true
 	}
 
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event to send to a webhook. Pending deliveries form the queue of the dispatch job,
+// and the sent ones are kept as the delivery log of the webhook.
+type WebhookDelivery struct {
+	ID             uint `gorm:"primaryKey"`
+	WebhookID      uint
+	OutboxEventID  uint `gorm:"index"`
+	Event          WebhookEvent
+	OrderID        *uint
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  time.Time
+	ResponseStatus int
+	Error          string
+	CreatedAt      time.Time
+	DeliveredAt    time.Time
+}
+
+// WebhookPayload is the JSON body posted to the webhooks.
+type WebhookPayload struct {
+	Event          WebhookEvent
+	OccurredAt     time.Time
+	PreviousStatus OrderStatus
+	Order          OrderOutput
+}
+
+func FindWebhookDeliveryByContext(context *gin.Context) (delivery *WebhookDelivery, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	webhooks := config.DB.WithContext(context).Model(&Webhook{}).Select("id")
+
+	if err = config.DB.WithContext(context).Where("webhook_id IN (?)", webhooks).First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Webhook delivery not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhook delivery."})
+
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// FindWebhookDeliveries returns the delivery log of a webhook, latest first,
+// optionally filtered by delivery status.
+func FindWebhookDeliveries(context *gin.Context, webhook *Webhook) (deliveries []WebhookDelivery, err error) {
+	query := config.DB.WithContext(context).Where("webhook_id = ?", webhook.ID).Order("id DESC")
+
+	if status := context.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err = query.Find(&deliveries).Error; err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhook deliveries."})
+
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// EnqueueWebhookDeliveries subscribes the webhooks to the order events of the outbox:
+// a delivery of the event is queued for every active webhook of the restaurant of the order subscribed to it.
+// A webhook already having a delivery of the event does not get another one when the event is delivered again.
+func EnqueueWebhookDeliveries(tx *gorm.DB, event *OutboxEvent) error {
+	webhookEvent := WebhookEvent(event.Type)
+
+	var orderEvent OrderEvent
+	if err := event.Decode(&orderEvent); err != nil {
+		return err
+	}
+
+	alreadyQueued := tx.Model(&WebhookDelivery{}).Select("webhook_id").Where("outbox_event_id = ?", event.ID)
+
+	var webhooks []Webhook
+	if err := tx.Where("restaurant_id = ? AND active = ? AND events LIKE ?", orderEvent.Order.RestaurantID, true, "%,"+string(webhookEvent)+",%").Where("id NOT IN (?)", alreadyQueued).Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(WebhookPayload{
+		Event:          webhookEvent,
+		OccurredAt:     event.CreatedAt,
+		PreviousStatus: orderEvent.PreviousStatus,
+		Order:          orderEvent.Order,
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]WebhookDelivery, 0, len(webhooks))
+
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, WebhookDelivery{
+			WebhookID:     webhook.ID,
+			OutboxEventID: event.ID,
+			Event:         webhookEvent,
+			OrderID:       &orderEvent.Order.ID,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	return tx.Create(&deliveries).Error
+}
+
+// RecordAttempt saves the result of a delivery attempt. A failed delivery is retried with an exponential backoff,
+// until the maximum number of attempts is reached.
+func (delivery *WebhookDelivery) RecordAttempt(db *gorm.DB, responseStatus int, attemptErr error, now time.Time) error {
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+	delivery.ResponseStatus = responseStatus
+	delivery.Error = ""
+
+	switch {
+	case attemptErr == nil:
+		delivery.Status = DeliverySucceeded
+		delivery.DeliveredAt = now
+	case delivery.Attempts >= config.WebhookMaxAttempts():
+		delivery.Status = DeliveryFailed
+		delivery.Error = attemptErr.Error()
+	default:
+		delivery.Error = attemptErr.Error()
+		delivery.NextAttemptAt = now.Add(config.WebhookRetryDelay() << (delivery.Attempts - 1))
+	}
+
+	return db.Save(delivery).Error
+}
+
+// Fail gives up the delivery without sending it, such as when its webhook has been deleted.
+func (delivery *WebhookDelivery) Fail(db *gorm.DB, message string, now time.Time) error {
+	delivery.Status = DeliveryFailed
+	delivery.LastAttemptAt = now
+	delivery.Error = message
+
+	return db.Save(delivery).Error
+}
+
+// Replay queues the delivery again to be sent as soon as possible, with a fresh number of attempts.
+func (delivery *WebhookDelivery) Replay(db *gorm.DB, now time.Time) error {
+	updates := map[string]interface{}{
+		"status":        DeliveryPending,
+		"attempts":      0,
+		"nextAttemptAt": now,
+		"error":         "",
+	}
+
+	return db.Model(delivery).Updates(updates).Error
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"wacdo/config"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookEvent is an order event of the outbox a webhook can subscribe to.
+type WebhookEvent string
+
+const (
+	OrderCreatedEvent       WebhookEvent = WebhookEvent(OrderCreated)
+	OrderUpdatedEvent       WebhookEvent = WebhookEvent(OrderUpdated)
+	OrderStatusChangedEvent WebhookEvent = WebhookEvent(OrderStatusChanged)
+	OrderCancelledEvent     WebhookEvent = WebhookEvent(OrderCancelled)
+	OrderAmendedEvent       WebhookEvent = WebhookEvent(OrderAmended)
+)
+
+func (event WebhookEvent) IsValid() bool {
+	switch event {
+	case OrderCreatedEvent, OrderUpdatedEvent, OrderStatusChangedEvent, OrderCancelledEvent, OrderAmendedEvent:
+		return true
+	}
+
+	return false
+}
+
+// WebhookEvents is stored as a comma-delimited string (",order.created,order.cancelled,"),
+// so that the webhooks subscribed to an event can be searched with a portable LIKE condition.
+type WebhookEvents []WebhookEvent
+
+func (events WebhookEvents) GormDataType() string {
+	return "string"
+}
+
+func (events WebhookEvents) Value() (driver.Value, error) {
+	if len(events) == 0 {
+		return "", nil
+	}
+
+	values := make([]string, 0, len(events))
+	for _, event := range events {
+		values = append(values, string(event))
+	}
+
+	return "," + strings.Join(values, ",") + ",", nil
+}
+
+func (events *WebhookEvents) Scan(value interface{}) error {
+	var stored string
+
+	switch typedValue := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = typedValue
+	case []byte:
+		stored = string(typedValue)
+	default:
+		return fmt.Errorf("unable to scan webhook events from %T", value)
+	}
+
+	*events = WebhookEvents{}
+
+	for _, event := range strings.Split(stored, ",") {
+		if event != "" {
+			*events = append(*events, WebhookEvent(event))
+		}
+	}
+
+	return nil
+}
+
+func (events WebhookEvents) MarshalJSON() ([]byte, error) {
+	if events == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal([]WebhookEvent(events))
+}
+
+// Webhook is an endpoint notified of the order events of its restaurant it is subscribed to.
+// Each delivery is signed with its secret, which is only returned when the webhook is created.
+type Webhook struct {
+	ID           uint `gorm:"primaryKey"`
+	RestaurantID uint `gorm:"index"`
+	URL          string
+	Description  string
+	Secret       string `json:"-"`
+	Events       WebhookEvents
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// WebhookSecretOutput is a created webhook, along with its secret.
+type WebhookSecretOutput struct {
+	Webhook
+	Secret string
+}
+
+type WebhookInsertInput struct {
+	URL         string         `json:"url" binding:"required"`
+	Description string         `json:"description"`
+	Secret      string         `json:"secret"`
+	Events      []WebhookEvent `json:"events"`
+	Active      *bool          `json:"active"`
+}
+
+type WebhookUpdateInput struct {
+	URL         *string         `json:"url"`
+	Description *string         `json:"description"`
+	Secret      *string         `json:"secret"`
+	Events      *[]WebhookEvent `json:"events"`
+	Active      *bool           `json:"active"`
+}
+
+func (Webhook) RestaurantScope(table string, restaurantID uint) clause.Expression {
+	return restaurantColumnScope(table, restaurantID)
+}
+
+func FindWebhookByContext(context *gin.Context) (webhook *Webhook, err error) {
+	idParam := context.Param("id")
+	id, err := strconv.Atoi(idParam)
+
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
+
+		return nil, err
+	}
+
+	return FindWebhookById(context, uint(id))
+}
+
+func FindWebhookById(context *gin.Context, id uint) (webhook *Webhook, err error) {
+	if err = config.DB.WithContext(context).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			context.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found."})
+
+			return nil, err
+		}
+
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch webhook."})
+
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// ValidateWebhookURL checks that deliveries can be posted to the URL.
+func ValidateWebhookURL(context *gin.Context, rawURL string) (string, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook URL."})
+
+		return "", false
+	}
+
+	return rawURL, true
+}
+
+// ValidateWebhookEvents checks the subscribed events, and drops the duplicates.
+func ValidateWebhookEvents(context *gin.Context, events []WebhookEvent) (WebhookEvents, bool) {
+	if len(events) == 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "At least one event is required."})
+
+		return nil, false
+	}
+
+	validEvents := WebhookEvents{}
+
+	for _, event := range events {
+		if !event.IsValid() {
+			context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid webhook event: %s.", event)})
+
+			return nil, false
+		}
+
+		if !slices.Contains(validEvents, event) {
+			validEvents = append(validEvents, event)
+		}
+	}
+
+	return validEvents, true
+}
+
+// GenerateWebhookSecret returns a random secret, used when none is given for a webhook.
+func GenerateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// SignWebhookPayload returns the signature sent in the X-Wacdo-Signature header:
+// the hex-encoded HMAC-SHA256 of the timestamp and the body joined by a dot, keyed with the webhook secret.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
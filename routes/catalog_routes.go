@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"wacdo/controllers"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CatalogRoutes(router *gin.Engine) {
+	routesGroup := router.Group("/catalog")
+
+	// The catalog is exchanged without a restaurant, whose availabilities would replace the ones of the catalog.
+	routesGroup.Use(middlewares.Authentication())
+
+	{
+		routesGroup.GET("/export", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetCatalogExport)
+		routesGroup.POST("/import", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostCatalogImport)
+	}
+}
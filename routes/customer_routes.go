@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"wacdo/controllers"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CustomerRoutes(router *gin.Engine) {
+	routesGroup := router.Group("/customers")
+
+	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+
+	{
+		routesGroup.GET("/", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.GetCustomers)
+		routesGroup.GET("/:id", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.GetCustomer)
+		routesGroup.GET("/:id/loyalty-transactions", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.GetCustomerLoyaltyTransactions)
+		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.PostCustomer)
+		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.PutCustomer)
+		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.DeleteCustomer)
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"wacdo/controllers"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func KitchenStationRoutes(router *gin.Engine) {
+	routesGroup := router.Group("/kitchen-stations")
+
+	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+
+	{
+		routesGroup.GET("/", controllers.GetKitchenStations)
+		routesGroup.GET("/:id", controllers.GetKitchenStation)
+		routesGroup.GET("/:id/queue", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager}), controllers.GetKitchenStationQueue)
+		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostKitchenStation)
+		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutKitchenStation)
+		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteKitchenStation)
+		routesGroup.PATCH("/items/:id/in-preparation", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker}), controllers.PatchKitchenStationItemInPreparation)
+		routesGroup.PATCH("/items/:id/prepared", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker}), controllers.PatchKitchenStationItemPrepared)
+	}
+}
@@ -12,12 +12,24 @@ func MenuRoutes(router *gin.Engine) {
 	routesGroup := router.Group("/menus")
 
 	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+	routesGroup.Use(middlewares.Locale())
 
 	{
 		routesGroup.GET("/", controllers.GetMenus)
+		routesGroup.GET("/deleted", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetDeletedMenus)
+		routesGroup.GET("/pricing-report", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.GetMenusPricingReport)
 		routesGroup.GET("/:id", controllers.GetMenu)
 		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostMenu)
 		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutMenu)
+		routesGroup.PUT("/:id/availability", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.PutMenuAvailability)
+		routesGroup.PUT("/:id/schedule", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutMenuSchedule)
 		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteMenu)
+		routesGroup.POST("/:id/restore", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostMenuRestore)
+		routesGroup.DELETE("/:id/purge", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteMenuPurge)
+		routesGroup.GET("/:id/price", controllers.GetMenuPrice)
+		routesGroup.GET("/:id/prices", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.GetMenuPrices)
+		routesGroup.POST("/:id/prices", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostMenuPrice)
+		routesGroup.DELETE("/:id/prices/:priceChangeID", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteMenuPrice)
 	}
 }
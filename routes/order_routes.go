@@ -12,14 +12,25 @@ func OrderRoutes(router *gin.Engine) {
 	routesGroup := router.Group("/orders")
 
 	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+	routesGroup.Use(middlewares.Locale())
 
 	{
 		routesGroup.GET("/", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager}), controllers.GetOrders)
+		routesGroup.GET("/queue", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager}), controllers.GetOrdersQueue)
+		routesGroup.GET("/deliveries", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager, models.Driver}), controllers.GetOrdersDeliveries)
 		routesGroup.GET("/:id", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager}), controllers.GetOrder)
+		routesGroup.GET("/:id/history", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager}), controllers.GetOrderHistory)
 		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.PostOrder)
+		routesGroup.POST("/quote", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.PostOrderQuote)
 		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin, models.Greeter, models.Manager}), controllers.PutOrder)
-		routesGroup.PATCH("/:id/in-preparation", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker}), controllers.PatchOrderInPreparation)
-		routesGroup.PATCH("/:id/prepared", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker}), controllers.PatchOrderPrepared)
-		routesGroup.PATCH("/:id/delivered", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager, models.Greeter}), controllers.PatchOrderDelivered)
+		routesGroup.PATCH("/status", middlewares.CheckRole([]models.UserRole{models.Admin, models.OrderPicker, models.Manager, models.Greeter, models.Driver}), controllers.PatchOrdersStatus)
+		routesGroup.PATCH("/:id/in-preparation", middlewares.CheckRole(models.OrderStatusRoles[models.InPreparation]), controllers.PatchOrderInPreparation)
+		routesGroup.PATCH("/:id/prepared", middlewares.CheckRole(models.OrderStatusRoles[models.Prepared]), controllers.PatchOrderPrepared)
+		routesGroup.PATCH("/:id/claimed", middlewares.CheckRole(models.OrderStatusRoles[models.Claimed]), controllers.PatchOrderClaimed)
+		routesGroup.PATCH("/:id/out-for-delivery", middlewares.CheckRole(models.OrderStatusRoles[models.OutForDelivery]), controllers.PatchOrderOutForDelivery)
+		routesGroup.PATCH("/:id/delivered", middlewares.CheckRole(models.OrderStatusRoles[models.Delivered]), controllers.PatchOrderDelivered)
+		routesGroup.PATCH("/:id/cancelled", middlewares.CheckRole(models.OrderStatusRoles[models.Cancelled]), controllers.PatchOrderCancelled)
+		routesGroup.PATCH("/:id/revert", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.PatchOrderRevert)
 	}
 }
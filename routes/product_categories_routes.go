@@ -12,12 +12,20 @@ func ProductCategoryRoutes(router *gin.Engine) {
 	routesGroup := router.Group("/products/categories")
 
 	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+	routesGroup.Use(middlewares.Locale())
 
 	{
 		routesGroup.GET("/", controllers.GetProductsCategories)
+		routesGroup.GET("/deleted", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetDeletedProductsCategories)
 		routesGroup.GET("/:id", controllers.GetProductCategory)
 		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProductCategory)
+		routesGroup.PUT("/reorder", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductCategoriesReorder)
 		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductCategory)
+		routesGroup.PUT("/:id/schedule", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductCategorySchedule)
+		routesGroup.PUT("/:id/products/reorder", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductCategoryProductsReorder)
 		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProductCategory)
+		routesGroup.POST("/:id/restore", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProductCategoryRestore)
+		routesGroup.DELETE("/:id/purge", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProductCategoryPurge)
 	}
 }
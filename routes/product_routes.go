@@ -12,12 +12,26 @@ func ProductRoutes(router *gin.Engine) {
 	routesGroup := router.Group("/products")
 
 	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+	routesGroup.Use(middlewares.Locale())
 
 	{
 		routesGroup.GET("/", controllers.GetProducts)
+		routesGroup.GET("/deleted", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetDeletedProducts)
 		routesGroup.GET("/:id", controllers.GetProduct)
 		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProduct)
 		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProduct)
+		routesGroup.PUT("/:id/availability", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.PutProductAvailability)
+		routesGroup.PUT("/:id/schedule", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductSchedule)
+		routesGroup.POST("/:id/variants", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProductVariant)
+		routesGroup.PUT("/:id/variants/:variantID", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutProductVariant)
+		routesGroup.DELETE("/:id/variants/:variantID", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProductVariant)
 		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProduct)
+		routesGroup.POST("/:id/restore", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProductRestore)
+		routesGroup.DELETE("/:id/purge", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProductPurge)
+		routesGroup.GET("/:id/price", controllers.GetProductPrice)
+		routesGroup.GET("/:id/prices", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.GetProductPrices)
+		routesGroup.POST("/:id/prices", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostProductPrice)
+		routesGroup.DELETE("/:id/prices/:priceChangeID", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteProductPrice)
 	}
 }
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"wacdo/controllers"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RestaurantRoutes(router *gin.Engine) {
+	// The status is public, for the kiosks and the customer board.
+	router.GET("/restaurants/:id/status", controllers.GetRestaurantStatus)
+
+	routesGroup := router.Group("/restaurants")
+
+	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+
+	{
+		routesGroup.GET("/", controllers.GetRestaurants)
+		routesGroup.GET("/:id", controllers.GetRestaurant)
+		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostRestaurant)
+		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutRestaurant)
+		routesGroup.GET("/:id/opening-hours", controllers.GetRestaurantOpeningHours)
+		routesGroup.PUT("/:id/opening-hours", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.PutRestaurantOpeningHours)
+		routesGroup.GET("/:id/closures", controllers.GetRestaurantClosures)
+		routesGroup.POST("/:id/closures", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.PostRestaurantClosure)
+		routesGroup.DELETE("/:id/closures/:closureId", middlewares.CheckRole([]models.UserRole{models.Admin, models.Manager}), controllers.DeleteRestaurantClosure)
+	}
+}
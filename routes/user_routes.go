@@ -12,12 +12,16 @@ func UserRoutes(router *gin.Engine) {
 	routesGroup := router.Group("/users")
 
 	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
 
 	{
 		routesGroup.GET("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetUsers)
+		routesGroup.GET("/deleted", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetDeletedUsers)
 		routesGroup.GET("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetUser)
 		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostUser)
 		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutUser)
 		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteUser)
+		routesGroup.POST("/:id/restore", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostUserRestore)
+		routesGroup.DELETE("/:id/purge", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteUserPurge)
 	}
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"wacdo/controllers"
+	"wacdo/middlewares"
+	"wacdo/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WebhookRoutes(router *gin.Engine) {
+	routesGroup := router.Group("/webhooks")
+
+	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Restaurant())
+
+	{
+		routesGroup.GET("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetWebhooks)
+		routesGroup.GET("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetWebhook)
+		routesGroup.GET("/:id/deliveries", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.GetWebhookDeliveries)
+		routesGroup.POST("/", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostWebhook)
+		routesGroup.PUT("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PutWebhook)
+		routesGroup.DELETE("/:id", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.DeleteWebhook)
+		routesGroup.POST("/deliveries/:id/replay", middlewares.CheckRole([]models.UserRole{models.Admin}), controllers.PostWebhookDeliveryReplay)
+	}
+}
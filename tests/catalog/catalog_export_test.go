@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportCatalog(router *gin.Engine) models.Catalog {
+	response := tests.SendRequest(router, http.MethodGet, "/catalog/export", nil, 1)
+	if response.Code != http.StatusOK {
+		log.Fatal("Unable to export catalog: ", response.Body.String())
+	}
+
+	var catalog models.Catalog
+	if err := json.NewDecoder(response.Body).Decode(&catalog); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return catalog
+}
+
+func TestExportCatalog(testing *testing.T) {
+	router := tests.InitTest()
+
+	catalog := exportCatalog(router)
+
+	assert.Equal(testing, 3, len(catalog.Categories))
+	assert.Equal(testing, 4, len(catalog.Products))
+	assert.Equal(testing, 2, len(catalog.Menus))
+
+	// The items without an external key are given one.
+	assert.Equal(testing, "category-1", catalog.Categories[0].Key)
+	assert.Equal(testing, "product-1", catalog.Products[0].Key)
+	assert.Equal(testing, "category-1", catalog.Products[0].CategoryKey)
+	assert.Equal(testing, 2.5, catalog.Products[0].Price)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Milk}, catalog.Products[0].Allergens)
+	assert.Equal(testing, "menu-1", catalog.Menus[0].Key)
+	assert.Equal(testing, []string{"product-1", "product-2"}, catalog.Menus[0].ProductKeys)
+
+	// The keys are kept from an export to the next one.
+	assert.Equal(testing, catalog, exportCatalog(router))
+}
+
+func TestExportCatalogCSV(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/catalog/export?format=csv", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Header().Get("Content-Type"), "text/csv")
+
+	lines := strings.Split(strings.TrimSpace(response.Body.String()), "\n")
+	assert.Equal(testing, 10, len(lines))
+	assert.True(testing, strings.HasPrefix(lines[0], "type,key,categoryKey,name,"))
+	assert.True(testing, strings.HasPrefix(lines[4], "product,product-1,category-1,Test product 1,"))
+	assert.True(testing, strings.HasSuffix(lines[8], ",product-1|product-2"))
+
+	response = tests.SendRequest(router, http.MethodGet, "/catalog/export?format=xml", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid format.")
+}
+
+func TestExportImportCatalogCSV(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/catalog/export?format=csv", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	before := exportCatalog(router)
+
+	// Imported back, every item is matched by its key and left unchanged.
+	response = tests.SendRequest(router, http.MethodPost, "/catalog/import?format=csv", response.Body, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var report models.CatalogImportReport
+	if err := json.NewDecoder(bytes.NewReader(response.Body.Bytes())).Decode(&report); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Empty(testing, report.Errors)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 3}, report.Categories)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 4}, report.Products)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 2}, report.Menus)
+	assert.Equal(testing, before, exportCatalog(router))
+}
+
+func TestExportCatalogNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/catalog/export", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
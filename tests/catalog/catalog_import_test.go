@@ -0,0 +1,185 @@
+package catalog
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func importCatalog(router *gin.Engine, url string, catalog map[string]interface{}) (*httptest.ResponseRecorder, models.CatalogImportReport) {
+	response := tests.SendRequest(router, http.MethodPost, url, catalog, 1)
+
+	var report models.CatalogImportReport
+	if err := json.Unmarshal(response.Body.Bytes(), &report); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return response, report
+}
+
+func newCatalog(burgerPrice float64) map[string]interface{} {
+	return map[string]interface{}{
+		"categories": []map[string]interface{}{
+			{"key": "burgers", "name": "Burgers", "description": "Our burgers"},
+		},
+		"products": []map[string]interface{}{
+			{
+				"key": "big-burger", "categoryKey": "burgers", "name": "Big burger", "description": "A big burger",
+				"price": burgerPrice, "isAvailable": true, "allergens": []string{"gluten"},
+				"variants": []map[string]interface{}{
+					{"sku": "BIG-XL", "name": "XL", "price": burgerPrice + 1.5, "menuSupplement": 1, "isAvailable": true},
+				},
+			},
+		},
+		"menus": []map[string]interface{}{
+			{"key": "big-menu", "name": "Big menu", "description": "A big menu", "price": 9.9, "isAvailable": true, "productKeys": []string{"big-burger"}},
+		},
+	}
+}
+
+func countProducts() int64 {
+	var count int64
+	config.DB.Model(&models.Product{}).Count(&count)
+
+	return count
+}
+
+func TestImportCatalogDryRun(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, report := importCatalog(router, "/catalog/import?dryRun=true", newCatalog(6.5))
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.True(testing, report.DryRun)
+	assert.Empty(testing, report.Errors)
+	assert.Equal(testing, models.CatalogImportCount{Created: 1}, report.Categories)
+	assert.Equal(testing, models.CatalogImportCount{Created: 1}, report.Products)
+	assert.Equal(testing, models.CatalogImportCount{Created: 1}, report.Variants)
+	assert.Equal(testing, models.CatalogImportCount{Created: 1}, report.Menus)
+
+	assert.Equal(testing, int64(4), countProducts())
+}
+
+func TestImportCatalog(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, report := importCatalog(router, "/catalog/import", newCatalog(6.5))
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.False(testing, report.DryRun)
+	assert.Equal(testing, models.CatalogImportCount{Created: 1}, report.Menus)
+
+	var product models.Product
+	config.DB.Preload("Category").Preload("Variants").Where("external_key = ?", "big-burger").First(&product)
+	assert.Equal(testing, "Burgers", product.Category.Name)
+	assert.Equal(testing, 6.5, product.Price)
+	assert.Equal(testing, "BIG-XL", product.Variants[0].SKU)
+
+	var menu models.Menu
+	config.DB.Preload("Products").Where("external_key = ?", "big-menu").First(&menu)
+	assert.Equal(testing, 1, len(menu.Products))
+	assert.Equal(testing, product.ID, menu.Products[0].ID)
+
+	// Imported again, the items are matched by their key.
+	response, report = importCatalog(router, "/catalog/import", newCatalog(7))
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 1}, report.Categories)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 1}, report.Products)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 1}, report.Variants)
+	assert.Equal(testing, models.CatalogImportCount{Updated: 1}, report.Menus)
+	assert.Equal(testing, int64(5), countProducts())
+
+	var changes []models.PriceChange
+	config.DB.Where("product_id = ?", product.ID).Order("id").Find(&changes)
+	assert.Equal(testing, 2, len(changes))
+	assert.Equal(testing, 6.5, changes[1].PreviousPrice)
+	assert.Equal(testing, 7.0, changes[1].Price)
+	assert.Equal(testing, uint(1), changes[1].UserID)
+}
+
+func TestImportCatalogInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	variant := models.ProductVariant{ProductID: 1, Name: "Large", SKU: "BIG-XL", Price: 3, IsAvailable: true}
+	config.DB.Create(&variant)
+
+	catalog := newCatalog(6.5)
+	catalog["categories"] = append(catalog["categories"].([]map[string]interface{}),
+		map[string]interface{}{"name": "Without key"},
+		map[string]interface{}{"key": "burgers", "name": "Burgers again"},
+	)
+	catalog["products"] = append(catalog["products"].([]map[string]interface{}),
+		map[string]interface{}{"key": "salad", "categoryKey": "salads", "name": "Salad", "price": 4, "allergens": []string{"ketchup"}},
+	)
+	catalog["menus"] = append(catalog["menus"].([]map[string]interface{}),
+		map[string]interface{}{"key": "salad-menu", "name": "Salad menu", "price": 0, "productKeys": []string{"soup"}},
+	)
+
+	response, report := importCatalog(router, "/catalog/import", catalog)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Equal(testing, []string{
+		"Category 2: key is required.",
+		"Category burgers: key is used more than once.",
+		"Product big-burger: SKU BIG-XL is already used by another product.",
+		"Product salad: invalid allergen.",
+		"Product salad: category salads not found.",
+		"Menu salad-menu: price must be positive.",
+		"Menu salad-menu: product soup not found.",
+	}, report.Errors)
+
+	// Nothing is imported when the catalog has errors.
+	assert.Equal(testing, int64(4), countProducts())
+
+	var count int64
+	config.DB.Model(&models.ProductCategory{}).Where("external_key = ?", "burgers").Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestImportCatalogCSVInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	for body, message := range map[string]string{
+		"key,name\nburgers,Burgers\n":                                "Missing type column.",
+		"type,key,name,price\nproduct,burger,Burger,cheap\n":         "Line 2: invalid price.",
+		"type,key,name,price\ndrink,cola,Cola,2\n":                   "Line 2: invalid type.",
+		"type,key,sku,name,price\nvariant,burger,BURGER-XL,XL,7.5\n": "Line 2: product burger not found.",
+	} {
+		response := tests.SendRequest(router, http.MethodPost, "/catalog/import?format=csv", strings.NewReader(body), 1)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code, message)
+		assert.Contains(testing, response.Body.String(), message)
+	}
+}
+
+func TestImportCatalogNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/catalog/import", strings.NewReader("{}"), 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
+
+func TestImportCatalogRestoresDeleted(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/catalog/export", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	config.DB.Delete(&models.Product{}, 4)
+	assert.Equal(testing, int64(3), countProducts())
+
+	response = tests.SendRequest(router, http.MethodPost, "/catalog/import", response.Body, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, int64(4), countProducts())
+}
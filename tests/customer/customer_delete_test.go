@@ -0,0 +1,45 @@
+package customer
+
+import (
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCustomerSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 1, "productID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/customers/1", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer deleted successfully.")
+
+	var order models.Order
+	config.DB.First(&order, 5)
+
+	assert.Nil(testing, order.CustomerID)
+
+	var transactionsCount int64
+	config.DB.Model(&models.LoyaltyTransaction{}).Count(&transactionsCount)
+
+	assert.Equal(testing, int64(0), transactionsCount)
+}
+
+func TestDeleteCustomerAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodDelete, "/customers/1", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,82 @@
+package customer
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCustomersSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/customers/", nil, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var customers []models.Customer
+	if err := json.NewDecoder(response.Body).Decode(&customers); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 2, len(customers))
+	assert.Equal(testing, "Test customer 1", customers[0].Name)
+	assert.Equal(testing, "customer1@example.com", *customers[0].Email)
+	assert.Equal(testing, 100, customers[0].LoyaltyPoints)
+	assert.Nil(testing, customers[1].Email)
+}
+
+func TestGetCustomersSearch(testing *testing.T) {
+	router := tests.InitTest()
+
+	for _, search := range []string{"CUSTOMER1@", "98%2076%2054", "customer%202"} {
+		response := tests.SendRequest(router, http.MethodGet, "/customers/?search="+search, nil, 2)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+
+		var customers []models.Customer
+		if err := json.NewDecoder(response.Body).Decode(&customers); err != nil {
+			log.Fatal("Unable to decode JSON: ", err)
+		}
+
+		assert.Equal(testing, 1, len(customers), search)
+	}
+}
+
+func TestGetCustomerSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/customers/1", nil, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	customer := models.Customer{}
+	if err := json.NewDecoder(response.Body).Decode(&customer); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "+33612345678", *customer.Phone)
+	assert.True(testing, customer.LoyaltyConsent)
+	assert.False(testing, customer.MarketingConsent)
+}
+
+func TestGetCustomerNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/customers/99", nil, 2)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer not found.")
+}
+
+func TestGetCustomersAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/customers/", nil, 4)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
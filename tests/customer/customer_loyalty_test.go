@@ -0,0 +1,234 @@
+package customer
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCustomerLoyaltyPoints(router *gin.Engine, customerID string) int {
+	response := tests.SendRequest(router, http.MethodGet, "/customers/"+customerID, nil, 1)
+
+	customer := models.Customer{}
+	if err := json.NewDecoder(response.Body).Decode(&customer); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return customer.LoyaltyPoints
+}
+
+func TestLoyaltyPointsEarnedOnDelivery(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 2, "productID": 3}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, uint(1), *order.CustomerID)
+	assert.Equal(testing, 100, getCustomerLoyaltyPoints(router, "1"))
+
+	for _, status := range []string{"in-preparation", "prepared", "delivered"} {
+		response = tests.SendRequest(router, http.MethodPatch, "/orders/5/"+status, nil, 1)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+	}
+
+	// 7.30 € spent
+	assert.Equal(testing, 107, getCustomerLoyaltyPoints(router, "1"))
+
+	// Points are taken back when the delivery is reverted.
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/revert", map[string]interface{}{"reason": "Wrong order"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 100, getCustomerLoyaltyPoints(router, "1"))
+
+	response = tests.SendRequest(router, http.MethodGet, "/customers/1/loyalty-transactions", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var transactions []models.LoyaltyTransaction
+	if err := json.NewDecoder(response.Body).Decode(&transactions); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 2, len(transactions))
+
+	assert.Equal(testing, models.Earned, transactions[0].Type)
+	assert.Equal(testing, 7, transactions[0].Points)
+	assert.Equal(testing, 107, transactions[0].Balance)
+	assert.Equal(testing, uint(5), *transactions[0].OrderID)
+
+	assert.Equal(testing, models.Reversed, transactions[1].Type)
+	assert.Equal(testing, -7, transactions[1].Points)
+	assert.Equal(testing, 100, transactions[1].Balance)
+}
+
+func TestLoyaltyPointsWithoutConsent(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 2,
+		"items":      []map[string]interface{}{{"quantity": 2, "productID": 3}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	for _, status := range []string{"in-preparation", "prepared", "delivered"} {
+		response = tests.SendRequest(router, http.MethodPatch, "/orders/5/"+status, nil, 1)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+	}
+
+	assert.Equal(testing, 0, getCustomerLoyaltyPoints(router, "2"))
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 2,
+		"items":      []map[string]interface{}{{"quantity": 1, "productID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer has not joined the loyalty program.")
+}
+
+func TestLoyaltyPointsRedeemed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items": []map[string]interface{}{
+			{"quantity": 2, "productID": 1, "redeemPoints": true},
+			{"quantity": 1, "productID": 3},
+		},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 60, order.RedeemedPoints)
+	assert.Equal(testing, 3.65, order.TotalPrice)
+	assert.Equal(testing, 40, getCustomerLoyaltyPoints(router, "1"))
+
+	// Points are given back when the order is cancelled.
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/cancelled", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 100, getCustomerLoyaltyPoints(router, "1"))
+}
+
+func TestLoyaltyPointsRedeemedOnUpdate(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 1, "productID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+	assert.Equal(testing, 70, getCustomerLoyaltyPoints(router, "1"))
+
+	// The points already redeemed for the order can be spent again on it.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "menuID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 20, getCustomerLoyaltyPoints(router, "1"))
+
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"customerID": 2,
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order customer cannot be changed.")
+}
+
+func TestLoyaltyPointsNotEnough(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 2, "menuID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Not enough loyalty points.")
+}
+
+func TestLoyaltyPointsRedeemInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "A customer is required to redeem loyalty points.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 1, "productID": 3, "redeemPoints": true}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 3: item cannot be redeemed with loyalty points.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 99,
+		"items":      []map[string]interface{}{{"quantity": 1, "productID": 3}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer not found.")
+}
+
+func TestLoyaltyPointsQuote(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"customerID": 1,
+		"items": []map[string]interface{}{
+			{"quantity": 1, "productID": 1, "redeemPoints": true},
+			{"quantity": 3, "productID": 3},
+		},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	quote := models.OrderQuoteOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&quote); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 30, quote.RedeemedPoints)
+	assert.Equal(testing, 30, quote.Items[0].RedeemedPoints)
+	assert.Equal(testing, 0.0, quote.Items[0].TotalPrice)
+	assert.Equal(testing, 10.95, quote.TotalPrice)
+	assert.Equal(testing, 10, quote.EarnedPoints)
+	assert.Equal(testing, 100, getCustomerLoyaltyPoints(router, "1"))
+}
+
+func TestGetCustomerLoyaltyTransactionsAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/customers/1/loyalty-transactions", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,82 @@
+package customer
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostCustomerSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/customers/", map[string]interface{}{
+		"name":             "New customer",
+		"email":            "New.Customer@Example.com",
+		"phone":            "06 11 22 33 44",
+		"loyaltyConsent":   true,
+		"marketingConsent": true,
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	customer := models.Customer{}
+	if err := json.NewDecoder(response.Body).Decode(&customer); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, uint(3), customer.ID)
+	assert.Equal(testing, "new.customer@example.com", *customer.Email)
+	assert.Equal(testing, "0611223344", *customer.Phone)
+	assert.True(testing, customer.LoyaltyConsent)
+	assert.True(testing, customer.MarketingConsent)
+	assert.Equal(testing, 0, customer.LoyaltyPoints)
+}
+
+func TestPostCustomerWithoutContact(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/customers/", map[string]interface{}{
+		"name":  "New customer",
+		"phone": " ",
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Email or phone is required.")
+}
+
+func TestPostCustomerInvalidPhone(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/customers/", map[string]interface{}{
+		"phone": "call me",
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid phone number.")
+}
+
+func TestPostCustomerAlreadyExists(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/customers/", map[string]interface{}{
+		"phone": "+33 6 98 76 54 32",
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer already exists.")
+}
+
+func TestPostCustomerAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/customers/", map[string]interface{}{
+		"email": "new.customer@example.com",
+	}, 4)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
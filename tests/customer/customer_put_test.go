@@ -0,0 +1,55 @@
+package customer
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutCustomerSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/customers/2", map[string]interface{}{
+		"email":            "customer2@example.com",
+		"loyaltyConsent":   true,
+		"marketingConsent": true,
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	customer := models.Customer{}
+	if err := json.NewDecoder(response.Body).Decode(&customer); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test customer 2", customer.Name)
+	assert.Equal(testing, "customer2@example.com", *customer.Email)
+	assert.Equal(testing, "+33698765432", *customer.Phone)
+	assert.True(testing, customer.LoyaltyConsent)
+	assert.True(testing, customer.MarketingConsent)
+}
+
+func TestPutCustomerAlreadyExists(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/customers/2", map[string]interface{}{
+		"email": "customer1@example.com",
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Customer already exists.")
+}
+
+func TestPutCustomerNoData(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/customers/1", map[string]interface{}{}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "No data to update.")
+}
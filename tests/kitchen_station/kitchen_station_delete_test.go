@@ -0,0 +1,64 @@
+package kitchen_station
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteKitchenStationSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodDelete, "/kitchen-stations/3", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestDeleteKitchenStationErrorAssociatedProducts(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodDelete, "/kitchen-stations/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Cannot delete kitchen station: there are products or products categories associated with it.")
+}
+
+func TestDeleteKitchenStationAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodDelete, "/kitchen-stations/3", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUser(request, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,230 @@
+package kitchen_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKitchenStationsSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var results []models.KitchenStation
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 3, len(results))
+
+	assert.Equal(testing, "Test kitchen station 1", results[0].Name)
+	assert.Equal(testing, "Test kitchen station description 1", results[0].Description)
+
+	assert.Equal(testing, "Test kitchen station 2", results[1].Name)
+	assert.Equal(testing, "Test kitchen station description 2", results[1].Description)
+}
+
+func TestGetKitchenStationsUnauthorized(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertUnauthorized(testing, response)
+}
+
+func TestGetKitchenStationSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.KitchenStation{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test kitchen station 1", result.Name)
+	assert.Equal(testing, "Test kitchen station description 1", result.Description)
+}
+
+func TestGetKitchenStationNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/0", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Kitchen station not found.")
+}
+
+func TestGetKitchenStationInvalidId(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/a", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid ID.")
+}
+
+func TestGetKitchenStationQueueSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"notes":        "Customer is in a hurry",
+		"allergens":    []string{"peanuts"},
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"menuID":    1,
+				"notes":     "No pickles",
+				"allergens": []string{"mustard"},
+			},
+			{
+				"quantity":  2,
+				"productID": 3,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	request, err = http.NewRequest(http.MethodGet, "/kitchen-stations/1/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var results []models.KitchenTicketOutput
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(results))
+
+	assert.Equal(testing, "005", results[0].TicketNumber)
+	assert.Equal(testing, "Test product 1", results[0].ProductName)
+	assert.Equal(testing, 1, results[0].Quantity)
+	assert.Equal(testing, models.Created, results[0].Status)
+	assert.Equal(testing, "No pickles", results[0].Notes)
+	assert.Equal(testing, "Customer is in a hurry", results[0].OrderNotes)
+	assert.Equal(testing, models.Allergens{models.Mustard, models.Peanuts}, results[0].Allergens)
+
+	request, err = http.NewRequest(http.MethodGet, "/kitchen-stations/2/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	results = []models.KitchenTicketOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(results))
+
+	assert.Equal(testing, "005", results[0].TicketNumber)
+	assert.Equal(testing, "Test product 3", results[0].ProductName)
+	assert.Equal(testing, 2, results[0].Quantity)
+}
+
+func TestGetKitchenStationQueueAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/kitchen-stations/1/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUser(request, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
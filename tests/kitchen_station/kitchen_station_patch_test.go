@@ -0,0 +1,201 @@
+package kitchen_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func postOrderWithStationItems(router *gin.Engine) {
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"items": []map[string]interface{}{
+			{
+				"quantity":  2,
+				"productID": 1,
+			},
+			{
+				"quantity":  1,
+				"productID": 3,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+}
+
+func patchKitchenStationItem(router *gin.Engine, url string) *httptest.ResponseRecorder {
+	request, err := http.NewRequest(http.MethodPatch, url, nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func getOrder(router *gin.Engine, url string) models.OrderOutput {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return result
+}
+
+func TestPatchKitchenStationItemsOrderPrepared(testing *testing.T) {
+	router := tests.InitTest()
+
+	postOrderWithStationItems(router)
+
+	response := patchKitchenStationItem(router, "/kitchen-stations/items/1/in-preparation")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderStationItem{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.InPreparation, result.Status)
+	assert.Equal(testing, "Test product 1", result.ProductName)
+	assert.Equal(testing, 2, result.Quantity)
+
+	order := getOrder(router, "/orders/5")
+	assert.Equal(testing, models.InPreparation, order.Status)
+	assert.Equal(testing, models.InPreparation, order.Items[0].StationItems[0].Status)
+	assert.Equal(testing, models.Created, order.Items[1].StationItems[0].Status)
+
+	response = patchKitchenStationItem(router, "/kitchen-stations/items/1/prepared")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	order = getOrder(router, "/orders/5")
+	assert.Equal(testing, models.InPreparation, order.Status)
+
+	response = patchKitchenStationItem(router, "/kitchen-stations/items/2/in-preparation")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = patchKitchenStationItem(router, "/kitchen-stations/items/2/prepared")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	order = getOrder(router, "/orders/5")
+	assert.Equal(testing, models.Prepared, order.Status)
+	assert.NotEqual(testing, time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), order.PreparedAt)
+
+	var preparedCount int64
+	config.DB.Model(&models.OrderStatusHistory{}).Where("order_id = ? AND to_status = ?", 5, models.Prepared).Count(&preparedCount)
+	assert.Equal(testing, int64(1), preparedCount)
+}
+
+func TestPatchKitchenStationItemPreparedInvalidStatus(testing *testing.T) {
+	router := tests.InitTest()
+
+	postOrderWithStationItems(router)
+
+	response := patchKitchenStationItem(router, "/kitchen-stations/items/1/prepared")
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Item must be in preparation before it can be prepared.")
+}
+
+func TestPatchKitchenStationItemInPreparationInvalidStatus(testing *testing.T) {
+	router := tests.InitTest()
+
+	postOrderWithStationItems(router)
+
+	patchKitchenStationItem(router, "/kitchen-stations/items/1/in-preparation")
+	response := patchKitchenStationItem(router, "/kitchen-stations/items/1/in-preparation")
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Item is already in preparation.")
+}
+
+func TestPatchOrderPreparedCompletesKitchenStationItems(testing *testing.T) {
+	router := tests.InitTest()
+
+	postOrderWithStationItems(router)
+
+	patchKitchenStationItem(router, "/kitchen-stations/items/1/in-preparation")
+
+	response := patchKitchenStationItem(router, "/orders/5/prepared")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	order := getOrder(router, "/orders/5")
+	assert.Equal(testing, models.Prepared, order.Status)
+	assert.Equal(testing, models.Prepared, order.Items[0].StationItems[0].Status)
+	assert.Equal(testing, models.Prepared, order.Items[1].StationItems[0].Status)
+}
+
+func TestPatchKitchenStationItemNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := patchKitchenStationItem(router, "/kitchen-stations/items/0/prepared")
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Order item not found.")
+}
+
+func TestPatchKitchenStationItemAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/kitchen-stations/items/1/prepared", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUser(request, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
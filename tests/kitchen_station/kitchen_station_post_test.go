@@ -0,0 +1,109 @@
+package kitchen_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostKitchenStationSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	kitchenStation := map[string]interface{}{
+		"name":        "Test kitchen station 4",
+		"description": "Test kitchen station description 4",
+	}
+
+	data, err := json.Marshal(kitchenStation)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/kitchen-stations/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.KitchenStation{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test kitchen station 4", result.Name)
+	assert.Equal(testing, "Test kitchen station description 4", result.Description)
+}
+
+func TestPostKitchenStationError(testing *testing.T) {
+	router := tests.InitTest()
+
+	kitchenStation := map[string]interface{}{
+		"name": 1234,
+	}
+
+	data, err := json.Marshal(kitchenStation)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/kitchen-stations/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid data.")
+}
+
+func TestPostKitchenStationAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	kitchenStation := map[string]interface{}{
+		"name": "Test kitchen station 4",
+	}
+
+	data, err := json.Marshal(kitchenStation)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/kitchen-stations/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUser(request, 4)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,104 @@
+package kitchen_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutKitchenStationSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	kitchenStation := map[string]interface{}{
+		"name": "Test kitchen station 1 updated",
+	}
+
+	data, err := json.Marshal(kitchenStation)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/kitchen-stations/1", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.KitchenStation{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test kitchen station 1 updated", result.Name)
+	assert.Equal(testing, "Test kitchen station description 1", result.Description)
+}
+
+func TestPutKitchenStationNoData(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPut, "/kitchen-stations/1", bytes.NewBufferString("{}"))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "No data to update.")
+}
+
+func TestPutKitchenStationNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	kitchenStation := map[string]interface{}{
+		"name": "Test kitchen station 0",
+	}
+
+	data, err := json.Marshal(kitchenStation)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/kitchen-stations/0", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Kitchen station not found.")
+}
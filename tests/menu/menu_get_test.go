@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"wacdo/config"
 	"wacdo/models"
 	"wacdo/tests"
 
@@ -27,11 +28,13 @@ func TestGetMenusSuccess(testing *testing.T) {
 
 	assert.Equal(testing, http.StatusOK, response.Code)
 
-	var results []models.Menu
-	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+	var list models.ListOutput[models.Menu]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
 		log.Fatal("Unable to decode JSON: ", err)
 	}
 
+	results := list.Items
+
 	assert.Equal(testing, 2, len(results))
 
 	assert.Equal(testing, "Test menu 1", results[0].Name)
@@ -63,6 +66,112 @@ func TestGetMenusSuccess(testing *testing.T) {
 	assert.True(testing, results[1].Products[1].IsAvailable)
 }
 
+func TestGetMenusVegetarian(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/?vegetarian=true", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.Menu]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	results := list.Items
+
+	assert.Equal(testing, 1, len(results))
+
+	assert.Equal(testing, "Test menu 1", results[0].Name)
+	assert.True(testing, results[0].IsVegetarian)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Milk}, results[0].Allergens)
+	assert.Equal(testing, 400.0, results[0].Nutrition.Calories)
+	assert.Equal(testing, 35.0, results[0].Nutrition.Sugars)
+}
+
+func TestGetMenusVegetarianWithoutProducts(testing *testing.T) {
+	router := tests.InitTest()
+
+	config.DB.Create(&models.Menu{Name: "Empty menu", Description: "Empty menu description", Price: 5, IsAvailable: true})
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/?vegetarian=true", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.Menu]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	for _, menu := range list.Items {
+		assert.True(testing, menu.IsVegetarian, menu.Name)
+	}
+
+	assert.Equal(testing, 1, len(list.Items))
+}
+
+func TestGetMenusExcludeAllergens(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/?excludeAllergens=gluten", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.Menu]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	results := list.Items
+
+	assert.Equal(testing, 0, len(results))
+}
+
+func TestGetMenusInvalidVegetarianFilter(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/?vegetarian=maybe", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid vegetarian filter.")
+}
+
 func TestGetMenusUnauthorized(testing *testing.T) {
 	router := tests.InitTest()
 
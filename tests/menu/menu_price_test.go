@@ -0,0 +1,48 @@
+package menu
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/jobs"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMenuPriceHistory(testing *testing.T) {
+	router := tests.InitTest()
+	effectiveAt := time.Now().Add(2 * time.Hour)
+
+	response := tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"price": 8.9}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/menus/1/prices", map[string]interface{}{"price": 9.5, "effectiveAt": effectiveAt}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	applied, err := jobs.ApplyPriceChanges(config.DB, effectiveAt.Add(time.Second))
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, applied)
+
+	response = tests.SendRequest(router, http.MethodGet, "/menus/1/prices", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var history models.PriceHistoryOutput
+	if err := json.NewDecoder(response.Body).Decode(&history); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 9.5, history.Price)
+	assert.Empty(testing, history.Scheduled)
+	assert.Equal(testing, 2, len(history.History))
+	assert.Equal(testing, 8.9, history.History[0].PreviousPrice)
+	assert.Equal(testing, 9.5, history.History[0].Price)
+	assert.Equal(testing, 8.54, history.History[1].PreviousPrice)
+	assert.Equal(testing, 8.9, history.History[1].Price)
+	assert.Nil(testing, history.History[0].ProductID)
+	assert.Equal(testing, uint(1), *history.History[0].MenuID)
+}
@@ -0,0 +1,143 @@
+package menu
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeMenu(response *httptest.ResponseRecorder) models.Menu {
+	var menu models.Menu
+	if err := json.NewDecoder(response.Body).Decode(&menu); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return menu
+}
+
+func getMenusPricingReport(router http.Handler) []models.MenuPricingReportItem {
+	response := tests.SendRequest(router, http.MethodGet, "/menus/pricing-report", nil, 1)
+
+	var items []models.MenuPricingReportItem
+	if err := json.NewDecoder(response.Body).Decode(&items); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return items
+}
+
+func TestGetMenuSaving(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/menus/1", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	menu := decodeMenu(response)
+
+	assert.Equal(testing, 7.49, menu.ALaCartePrice)
+	assert.Equal(testing, -1.05, menu.Saving)
+}
+
+func TestPostMenuPricingWarning(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/menus/", map[string]interface{}{
+		"name":        "Test menu 3",
+		"description": "Test menu description 3",
+		"price":       5,
+		"isAvailable": true,
+		"productsIDs": []int{1, 3},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+	assert.Empty(testing, response.Header().Get("Warning"))
+	assert.Equal(testing, 1.15, decodeMenu(response).Saving)
+
+	response = tests.SendRequest(router, http.MethodPost, "/menus/", map[string]interface{}{
+		"name":        "Test menu 4",
+		"description": "Test menu description 4",
+		"price":       7,
+		"isAvailable": true,
+		"productsIDs": []int{1, 3},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+	assert.Contains(testing, response.Header().Get("Warning"), "Menu price is higher than its products bought separately (6.15).")
+	assert.Equal(testing, -0.85, decodeMenu(response).Saving)
+}
+
+func TestMenuPricingRejected(testing *testing.T) {
+	testing.Setenv("MENU_PRICING_POLICY", "reject")
+
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/menus/", map[string]interface{}{
+		"name":        "Test menu 3",
+		"description": "Test menu description 3",
+		"price":       7,
+		"isAvailable": true,
+		"productsIDs": []int{1, 3},
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Menu price is higher than its products bought separately (6.15)."}`, response.Body.String())
+
+	// The price is only checked when it or the products change.
+	response = tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"name": "Test menu 1b"}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"price": 9}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"productsIDs": []int{1}}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"price": 7}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 0.49, decodeMenu(response).Saving)
+}
+
+func TestGetMenusPricingReport(testing *testing.T) {
+	router := tests.InitTest()
+
+	items := getMenusPricingReport(router)
+
+	assert.Equal(testing, 2, len(items))
+	assert.Equal(testing, uint(1), items[0].MenuID)
+	assert.Equal(testing, 8.54, items[0].Price)
+	assert.Equal(testing, 7.49, items[0].ALaCartePrice)
+	assert.Equal(testing, -1.05, items[0].Saving)
+	assert.Equal(testing, uint(2), items[1].MenuID)
+
+	response := tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{"price": 7}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	items = getMenusPricingReport(router)
+
+	assert.Equal(testing, 1, len(items))
+	assert.Equal(testing, uint(2), items[0].MenuID)
+}
+
+func TestGetMenusPricingReportAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/pricing-report", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUser(request, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
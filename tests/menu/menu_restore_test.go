@@ -0,0 +1,65 @@
+package menu
+
+import (
+	"net/http"
+	"testing"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRestoreMenu(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodDelete, "/menus/1", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/menus/1", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/menus/deleted", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"Name":"Test menu 1"`)
+
+	// Once the menu is deleted, its products can be deleted too, but the menu cannot be restored without them.
+	response = tests.SendRequest(router, http.MethodDelete, "/products/2", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/menus/1/restore", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot restore menu: some of its products are deleted.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/2/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/menus/1/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"Name":"Test product 2"`)
+}
+
+func TestPurgeMenu(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "menuID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	tests.SendRequest(router, http.MethodDelete, "/menus/1", nil, 1)
+	tests.SendRequest(router, http.MethodDelete, "/menus/2", nil, 1)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/menus/1/purge", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot purge menu: there are orders associated with it.")
+
+	response = tests.SendRequest(router, http.MethodDelete, "/menus/2/purge", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/menus/2/restore", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Deleted menu not found.")
+
+	// Its products are left.
+	response = tests.SendRequest(router, http.MethodGet, "/products/3", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutMenuSchedule(testing *testing.T) {
+	router := tests.InitTest()
+
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+	breakfastDay := (int(time.Now().In(location).Weekday()) + 3) % 7
+
+	response := tests.SendRequest(router, http.MethodPut, "/menus/1/schedule", map[string]interface{}{
+		"slots": []map[string]interface{}{{"dayOfWeek": breakfastDay, "startsAt": "07:00", "endsAt": "11:00"}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var menu models.Menu
+	if err := json.NewDecoder(response.Body).Decode(&menu); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.True(testing, menu.IsAvailable)
+	assert.False(testing, menu.IsAvailableNow)
+	assert.Equal(testing, []models.AvailabilitySlot{{ID: menu.AvailabilitySlots[0].ID, MenuID: &menu.ID, DayOfWeek: breakfastDay, StartsAt: "07:00", EndsAt: "11:00"}}, menu.AvailabilitySlots)
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "menuID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Menu 1: item is not available at this time.")
+
+	// The slots are kept with a deleted menu, to be restored with it, and removed when it is purged.
+	response = tests.SendRequest(router, http.MethodDelete, "/menus/1", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var slots int64
+	config.DB.Model(&models.AvailabilitySlot{}).Count(&slots)
+	assert.Equal(testing, int64(1), slots)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/menus/1/purge", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	config.DB.Model(&models.AvailabilitySlot{}).Count(&slots)
+	assert.Equal(testing, int64(0), slots)
+}
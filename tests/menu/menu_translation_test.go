@@ -0,0 +1,52 @@
+package menu
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMenuTranslated(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/menus/1", map[string]interface{}{
+		"translations": map[string]interface{}{"en": map[string]string{"name": "Menu one", "description": "The first menu"}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{
+		"translations": map[string]interface{}{"en": map[string]string{"name": "Fries"}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	request, err := http.NewRequest(http.MethodGet, "/menus/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", "en-US")
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	menu := decodeMenu(response)
+
+	assert.Equal(testing, "en", menu.Locale)
+	assert.Equal(testing, "Menu one", menu.Name)
+	assert.Equal(testing, "The first menu", menu.Description)
+	assert.Equal(testing, "Fries", menu.Products[0].Name)
+	assert.Equal(testing, "Test product 2", menu.Products[1].Name)
+
+	// Without Accept-Language, the default content is returned.
+	menu = decodeMenu(tests.SendRequest(router, http.MethodGet, "/menus/1", nil, 1))
+
+	assert.Equal(testing, "", menu.Locale)
+	assert.Equal(testing, "Test menu 1", menu.Name)
+}
@@ -0,0 +1,224 @@
+package order
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// initDeliveryTest creates two drivers (users 6 and 7) and a prepared delivery order (order 5).
+func initDeliveryTest() *gin.Engine {
+	router := tests.InitTest()
+
+	config.DB.Create(&models.User{Email: "driver1@example.com", Role: models.Driver, RestaurantID: 1})
+	config.DB.Create(&models.User{Email: "driver2@example.com", Role: models.Driver, RestaurantID: 1})
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"channel":         models.Delivery,
+		"deliveryAddress": " 1 rue de la Paix, 75002 Paris ",
+		"deliveryPhone":   "06 12 34 56 78",
+		"items":           []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	if response.Code != http.StatusCreated {
+		log.Fatal("Unable to create delivery order: ", response.Body.String())
+	}
+
+	for _, status := range []string{"in-preparation", "prepared"} {
+		tests.SendRequest(router, http.MethodPatch, "/orders/5/"+status, nil, 4)
+	}
+
+	return router
+}
+
+func TestDeliveryOrderSuccess(testing *testing.T) {
+	router := initDeliveryTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/orders/deliveries", nil, 6)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var deliveries []models.OrderOutput
+	if err := json.NewDecoder(response.Body).Decode(&deliveries); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(deliveries))
+	assert.Equal(testing, "1 rue de la Paix, 75002 Paris", deliveries[0].DeliveryAddress)
+	assert.Equal(testing, "0612345678", deliveries[0].DeliveryPhone)
+	assert.Equal(testing, models.Prepared, deliveries[0].Status)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 6)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Claimed, result.Status)
+	assert.Equal(testing, uint(6), *result.DriverID)
+	assert.False(testing, result.ClaimedAt.IsZero())
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/out-for-delivery", nil, 6)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result = models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.OutForDelivery, result.Status)
+	assert.False(testing, result.OutForDeliveryAt.IsZero())
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/delivered", nil, 6)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result = models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Delivered, result.Status)
+	assert.False(testing, result.DeliveredAt.IsZero())
+}
+
+func TestDeliveryOrderClaimedByAnotherDriver(testing *testing.T) {
+	router := initDeliveryTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 6)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 7)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order is already claimed.")
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/out-for-delivery", nil, 7)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order is not claimed by this driver.")
+}
+
+func TestDeliveryOrderInvalidStatus(testing *testing.T) {
+	router := initDeliveryTest()
+
+	// A delivery order goes out for delivery before being delivered.
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/5/delivered", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order must be out for delivery before it can be delivered.")
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/out-for-delivery", nil, 6)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order must be claimed before it can be out for delivery.")
+
+	// Only delivery orders can be claimed.
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/3/claimed", nil, 6)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order is not a delivery order.")
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/2/claimed", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order is not a delivery order.")
+}
+
+func TestDeliveryOrderRevertClaim(testing *testing.T) {
+	router := initDeliveryTest()
+
+	tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 6)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/5/revert", map[string]interface{}{"reason": "Driver is unavailable"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Prepared, result.Status)
+	assert.Nil(testing, result.DriverID)
+	assert.True(testing, result.ClaimedAt.IsZero())
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 7)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestDeliveryOrderMissingContact(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"channel": models.Delivery,
+		"items":   []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Delivery address is required.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"channel":         models.Delivery,
+		"deliveryAddress": "1 rue de la Paix, 75002 Paris",
+		"items":           []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Delivery phone is required.")
+
+	// The phone of the customer is used by default.
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"channel":         models.Delivery,
+		"deliveryAddress": "1 rue de la Paix, 75002 Paris",
+		"customerID":      1,
+		"items":           []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "+33612345678", result.DeliveryPhone)
+
+	// A counter order cannot become a delivery order without an address.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/1", map[string]interface{}{
+		"channel": models.Delivery,
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Delivery address is required.")
+}
+
+func TestDeliveryOrderAccessNotAllowed(testing *testing.T) {
+	router := initDeliveryTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/5/claimed", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/deliveries", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+
+	// Drivers do not prepare orders.
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/1/in-preparation", nil, 6)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,103 @@
+package order
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostOrderEstimatedReadyAt(testing *testing.T) {
+	router := tests.InitTest()
+
+	before := time.Now()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var output models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &output)
+
+	// The 4 items of the seeded orders are prepared first, one minute per item by default
+	if assert.NotNil(testing, output.EstimatedReadyAt) {
+		assert.WithinDuration(testing, before.Add(6*time.Minute), *output.EstimatedReadyAt, 5*time.Second)
+	}
+}
+
+func TestGetOrderEstimatedReadyAtFromHistory(testing *testing.T) {
+	router := tests.InitTest()
+
+	// The seeded prepared order has 1 item prepared in 3 minutes
+	now := time.Now()
+	config.DB.Model(&models.Order{}).Where("id = ?", 3).Updates(map[string]interface{}{
+		"in_preparation_at": now.Add(-time.Hour),
+		"prepared_at":       now.Add(-time.Hour).Add(3 * time.Minute),
+	})
+
+	response := tests.SendRequest(router, http.MethodGet, "/orders/1", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var output models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &output)
+
+	if assert.NotNil(testing, output.EstimatedReadyAt) {
+		assert.WithinDuration(testing, now.Add(9*time.Minute), *output.EstimatedReadyAt, 5*time.Second)
+	}
+}
+
+func TestGetOrderEstimatedReadyAtScheduled(testing *testing.T) {
+	router := tests.InitTest()
+
+	requestedReadyAt := time.Now().Add(3 * time.Hour).Truncate(time.Second)
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"requestedReadyAt": requestedReadyAt,
+		"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var output models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &output)
+
+	if assert.NotNil(testing, output.EstimatedReadyAt) {
+		assert.True(testing, requestedReadyAt.Equal(*output.EstimatedReadyAt))
+	}
+}
+
+func TestGetOrderEstimatedReadyAtNotQueued(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/orders/4", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var output models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &output)
+
+	assert.Nil(testing, output.EstimatedReadyAt)
+}
+
+func TestEstimateReadyTimesInPreparation(testing *testing.T) {
+	now := time.Now()
+	stats := models.PreparationStats{ItemDuration: time.Minute}
+
+	orders := []models.Order{
+		{ID: 1, Status: models.InPreparation, CreatedAt: now.Add(-5 * time.Minute), InPreparationAt: now.Add(-time.Minute), Items: []models.OrderItem{{Quantity: 3}}},
+		{ID: 2, Status: models.Created, CreatedAt: now, Items: []models.OrderItem{{Quantity: 1}}},
+	}
+
+	estimates := models.EstimateReadyTimes(orders, stats, now)
+
+	assert.Equal(testing, now.Add(2*time.Minute), estimates[1])
+	assert.Equal(testing, now.Add(3*time.Minute), estimates[2])
+}
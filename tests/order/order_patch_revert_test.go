@@ -0,0 +1,197 @@
+package order
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchOrderRevertSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/2/prepared", nil, 4)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/2/revert", map[string]interface{}{"reason": "Wrong ticket"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.InPreparation, result.Status)
+	assert.True(testing, result.PreparedAt.IsZero())
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/2/history", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var history []models.OrderStatusHistory
+	if err := json.NewDecoder(response.Body).Decode(&history); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 2, len(history))
+
+	assert.Equal(testing, models.InPreparation, history[0].FromStatus)
+	assert.Equal(testing, models.Prepared, history[0].ToStatus)
+	assert.Equal(testing, uint(4), history[0].UserID)
+	assert.False(testing, history[0].IsRevert)
+
+	assert.Equal(testing, models.Prepared, history[1].FromStatus)
+	assert.Equal(testing, models.InPreparation, history[1].ToStatus)
+	assert.Equal(testing, uint(1), history[1].UserID)
+	assert.True(testing, history[1].IsRevert)
+	assert.Equal(testing, "Wrong ticket", history[1].Reason)
+
+	// Reverting again goes one step further back.
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/2/revert", map[string]interface{}{"reason": "Not started yet"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result = models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Created, result.Status)
+}
+
+func TestPatchOrderRevertWithoutHistory(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/4/revert", map[string]interface{}{"reason": "Customer came back"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Prepared, result.Status)
+	assert.True(testing, result.DeliveredAt.IsZero())
+}
+
+func TestPatchOrderRevertCancelled(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/3/cancelled", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/3/revert", map[string]interface{}{"reason": "Cancelled by mistake"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Prepared, result.Status)
+	assert.True(testing, result.CancelledAt.IsZero())
+}
+
+func TestPatchOrderRevertReopensKitchenItems(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	for _, status := range []string{"in-preparation", "prepared"} {
+		response = tests.SendRequest(router, http.MethodPatch, "/orders/5/"+status, nil, 1)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+	}
+
+	response = tests.SendRequest(router, http.MethodGet, "/kitchen-stations/1/queue", nil, 1)
+
+	var tickets []models.KitchenTicketOutput
+	if err := json.NewDecoder(response.Body).Decode(&tickets); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 0, len(tickets))
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/revert", map[string]interface{}{"reason": "Wrong ticket"}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/kitchen-stations/1/queue", nil, 1)
+
+	tickets = nil
+	if err := json.NewDecoder(response.Body).Decode(&tickets); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(tickets))
+	assert.Equal(testing, order.ID, tickets[0].OrderID)
+	assert.Equal(testing, models.InPreparation, tickets[0].Status)
+}
+
+func TestPatchOrderRevertNoPreviousStatus(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/revert", map[string]interface{}{"reason": "Wrong ticket"}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order has no previous status.")
+}
+
+func TestPatchOrderRevertWithoutReason(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/3/revert", map[string]interface{}{}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Invalid data."}`, response.Body.String())
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/3/revert", map[string]interface{}{"reason": "  "}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Reason is required.")
+}
+
+func TestPatchOrderRevertUnauthorized(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/3/revert", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertUnauthorized(testing, response)
+}
+
+func TestPatchOrderRevertAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/3/revert", map[string]interface{}{"reason": "Wrong ticket"}, 4)
+
+	tests.AssertAccessNotAllowed(testing, response)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/3/revert", map[string]interface{}{"reason": "Wrong ticket"}, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,222 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func patchOrdersStatus(input map[string]interface{}, userID uint) *http.Request {
+	jsonValue, err := json.Marshal(input)
+	if err != nil {
+		log.Fatal("Unable to marshal JSON: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/status", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUser(request, userID)
+
+	return request
+}
+
+func TestPatchOrdersStatusBestEffort(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{3, 4, 1, 99, 3},
+		"status":   models.Delivered,
+		"mode":     models.BestEffort,
+	}, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderStatusBulkOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Delivered, result.Status)
+	assert.Equal(testing, models.BestEffort, result.Mode)
+	assert.Equal(testing, 1, result.Succeeded)
+	assert.Equal(testing, 3, result.Failed)
+	assert.Equal(testing, 4, len(result.Results))
+
+	assert.Equal(testing, uint(3), result.Results[0].OrderID)
+	assert.True(testing, result.Results[0].Success)
+	assert.Equal(testing, models.Delivered, result.Results[0].Order.Status)
+	assert.False(testing, result.Results[0].Order.DeliveredAt.IsZero())
+
+	assert.Equal(testing, uint(4), result.Results[1].OrderID)
+	assert.False(testing, result.Results[1].Success)
+	assert.Equal(testing, "Order is already delivered.", result.Results[1].Error)
+	assert.Nil(testing, result.Results[1].Order)
+
+	assert.Equal(testing, uint(1), result.Results[2].OrderID)
+	assert.Equal(testing, "Order must be prepared before it can be delivered.", result.Results[2].Error)
+
+	assert.Equal(testing, uint(99), result.Results[3].OrderID)
+	assert.Equal(testing, "Order not found.", result.Results[3].Error)
+}
+
+func TestPatchOrdersStatusAtomicSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{1, 2, 3},
+		"status":   models.Cancelled,
+	}, 1)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderStatusBulkOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Atomic, result.Mode)
+	assert.Equal(testing, 3, result.Succeeded)
+	assert.Equal(testing, 0, result.Failed)
+
+	var cancelledOrdersCount int64
+	config.DB.Model(&models.Order{}).Where("status = ?", models.Cancelled).Count(&cancelledOrdersCount)
+
+	assert.Equal(testing, int64(3), cancelledOrdersCount)
+}
+
+func TestPatchOrdersStatusAtomicFailure(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{3, 1},
+		"status":   models.Delivered,
+		"mode":     models.Atomic,
+	}, 1)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	result := models.OrderStatusBulkOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 0, result.Succeeded)
+	assert.Equal(testing, 2, result.Failed)
+	assert.Equal(testing, "Order was not updated because other orders cannot be.", result.Results[0].Error)
+	assert.Equal(testing, "Order must be prepared before it can be delivered.", result.Results[1].Error)
+
+	var order models.Order
+	config.DB.First(&order, 3)
+
+	assert.Equal(testing, models.Prepared, order.Status)
+}
+
+func TestPatchOrdersStatusInvalidMode(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{3},
+		"status":   models.Delivered,
+		"mode":     "sometimes",
+	}, 1)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid mode.")
+}
+
+func TestPatchOrdersStatusInvalidStatus(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{3},
+		"status":   models.Created,
+	}, 1)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid status.")
+}
+
+func TestPatchOrdersStatusInvalidData(testing *testing.T) {
+	router := tests.InitTest()
+
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{},
+		"status":   models.Delivered,
+	}, 1)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid data.")
+}
+
+func TestPatchOrdersStatusAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	// A greeter can deliver orders, but not prepare them.
+	request := patchOrdersStatus(map[string]interface{}{
+		"orderIDs": []uint{2},
+		"status":   models.Prepared,
+	}, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
+
+func TestPatchOrdersStatusUnauthorized(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/status", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertUnauthorized(testing, response)
+}
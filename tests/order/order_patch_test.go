@@ -1,6 +1,7 @@
 package order
 
 import (
+	"bytes"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -125,6 +126,54 @@ func TestPatchOrderInPreparationInvalidStatus3(testing *testing.T) {
 	assert.Contains(testing, body, "Order is already delivered.")
 }
 
+func TestPatchOrderInPreparationScheduled(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber":     "005",
+		"requestedReadyAt": time.Now().Add(2 * time.Hour),
+		"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	request, err = http.NewRequest(http.MethodPatch, "/orders/5/in-preparation", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Order is scheduled for later and cannot be prepared yet.")
+}
+
 func TestPatchOrderInPreparationUnauthorized(testing *testing.T) {
 	router := tests.InitTest()
 
@@ -577,3 +626,90 @@ func TestPatchOrderDeliveredInvalidId(testing *testing.T) {
 	assert.Contains(testing, body, "error")
 	assert.Contains(testing, body, "Invalid ID.")
 }
+
+func TestPatchOrderCancelledSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/2/cancelled", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "002", result.TicketNumber)
+	assert.Equal(testing, models.Cancelled, result.Status)
+	assert.WithinDuration(testing, time.Now(), result.CancelledAt, time.Minute)
+
+	request, err = http.NewRequest(http.MethodPatch, "/orders/2/prepared", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Order is cancelled.")
+}
+
+func TestPatchOrderCancelledInvalidStatus(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/4/cancelled", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Order is already delivered.")
+}
+
+func TestPatchOrderCancelledAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodPatch, "/orders/1/cancelled", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUser(request, 4)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,161 @@
+package order
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostOrderQuoteSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"allergens": []string{"peanuts"},
+		"items": []map[string]interface{}{
+			{
+				"quantity": 1,
+				"menuID":   1,
+			},
+			{
+				"quantity":  2,
+				"productID": 3,
+			},
+		},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderQuoteOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Counter, result.Channel)
+	assert.Equal(testing, 15.84, result.TotalPrice)
+	assert.Equal(testing, 3, result.ItemsCount)
+	assert.Equal(testing, models.Allergens{models.Peanuts}, result.AllergenAlert)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Milk}, result.ContentAllergens)
+	assert.Equal(testing, 1400.0, result.Nutrition.Calories)
+
+	assert.Equal(testing, 2, len(result.Items))
+
+	assert.Equal(testing, uint(1), *result.Items[0].MenuID)
+	assert.Nil(testing, result.Items[0].ProductID)
+	assert.Equal(testing, "Test menu 1", result.Items[0].Name)
+	assert.Equal(testing, 1, result.Items[0].Quantity)
+	assert.Equal(testing, 8.54, result.Items[0].UnitPrice)
+	assert.Equal(testing, 8.54, result.Items[0].TotalPrice)
+
+	assert.Equal(testing, uint(3), *result.Items[1].ProductID)
+	assert.Equal(testing, "Test product 3", result.Items[1].Name)
+	assert.Equal(testing, 2, result.Items[1].Quantity)
+	assert.Equal(testing, 3.65, result.Items[1].UnitPrice)
+	assert.Equal(testing, 7.3, result.Items[1].TotalPrice)
+}
+
+func TestPostOrderQuoteDiscountAndVAT(testing *testing.T) {
+	router := tests.InitTest()
+
+	// Menu 1 is cheaper than its products 1 and 2 bought separately (7.49).
+	config.DB.Model(&models.Menu{}).Where("id = ?", 1).Update("price", 6.99)
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{
+				"quantity": 2,
+				"menuID":   1,
+			},
+			{
+				"quantity":  1,
+				"productID": 3,
+			},
+		},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderQuoteOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1.0, result.Items[0].Discount)
+	assert.Equal(testing, 0.0, result.Items[1].Discount)
+
+	assert.Equal(testing, 18.63, result.Subtotal)
+	assert.Equal(testing, 1.0, result.Discount)
+	assert.Equal(testing, 17.63, result.TotalPrice)
+	assert.Equal(testing, 10.0, result.VATRate)
+	assert.Equal(testing, 1.6, result.VATAmount)
+	assert.Equal(testing, 16.03, result.TotalExcludingVAT)
+}
+
+func TestPostOrderQuoteDoesNotSave(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var ordersCount int64
+	config.DB.Model(&models.Order{}).Count(&ordersCount)
+
+	assert.Equal(testing, int64(4), ordersCount)
+
+	// The ticket number sequence is left untouched.
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "005", result.TicketNumber)
+}
+
+func TestPostOrderQuoteInvalidData(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 99}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 99: item not found.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"channel": "drone",
+		"items":   []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid channel.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"items": []map[string]interface{}{},
+	}, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+}
+
+func TestPostOrderQuoteAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/quote", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 4)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
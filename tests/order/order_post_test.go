@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"wacdo/models"
@@ -60,6 +61,9 @@ func TestPostOrderSuccess(testing *testing.T) {
 	assert.Equal(testing, "admin1@example.com", result.User.Email)
 	assert.NotContains(testing, "password", result.User)
 	assert.Equal(testing, 15.84, result.TotalPrice)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Milk}, result.ContentAllergens)
+	assert.Equal(testing, 1400.0, result.Nutrition.Calories)
+	assert.Equal(testing, 62.0, result.Nutrition.Proteins)
 	assert.Equal(testing, time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), result.PreparedAt)
 	assert.Equal(testing, time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), result.DeliveredAt)
 
@@ -319,6 +323,305 @@ func TestPostOrderMenuNotAvailable(testing *testing.T) {
 	assert.Contains(testing, body, "Menu 2: item is not available.")
 }
 
+func TestPostOrderScheduled(testing *testing.T) {
+	router := tests.InitTest()
+
+	requestedReadyAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+
+	order := map[string]interface{}{
+		"ticketNumber":     "005",
+		"channel":          "clickAndCollect",
+		"requestedReadyAt": requestedReadyAt,
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "005", result.TicketNumber)
+	assert.Equal(testing, models.ClickAndCollect, result.Channel)
+	assert.NotNil(testing, result.RequestedReadyAt)
+	assert.True(testing, requestedReadyAt.Equal(*result.RequestedReadyAt))
+}
+
+func TestPostOrderDefaultChannel(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Counter, result.Channel)
+	assert.Nil(testing, result.RequestedReadyAt)
+}
+
+func TestPostOrderInvalidChannel(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"channel":      "carrierPigeon",
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid channel.")
+}
+
+func TestPostOrderRequestedReadyAtInPast(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber":     "005",
+		"requestedReadyAt": time.Now().Add(-time.Hour),
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Requested ready time must be in the future.")
+}
+
+func TestPostOrderNotesAndAllergens(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"notes":        "  Customer is <b>allergic</b> to peanuts  ",
+		"allergens":    []string{"peanuts"},
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+				"notes":     "Sauce on the side",
+				"allergens": []string{"milk"},
+			},
+			{
+				"quantity": 1,
+				"menuID":   1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Customer is allergic to peanuts", result.Notes)
+	assert.Equal(testing, models.Allergens{models.Peanuts}, result.Allergens)
+	assert.Equal(testing, models.Allergens{models.Milk, models.Peanuts}, result.AllergenAlert)
+
+	assert.Equal(testing, "Sauce on the side", result.Items[0].Notes)
+	assert.Equal(testing, models.Allergens{models.Milk}, result.Items[0].Allergens)
+	assert.Equal(testing, "", result.Items[1].Notes)
+	assert.Equal(testing, models.Allergens{}, result.Items[1].Allergens)
+}
+
+func TestPostOrderNotesTooLong(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+				"notes":     strings.Repeat("a", 141),
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Notes must not exceed 140 characters.")
+}
+
+func TestPostOrderInvalidAllergen(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"ticketNumber": "005",
+		"allergens":    []string{"chocolate"},
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": 1,
+			},
+		},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid allergen.")
+}
+
 func TestPostOrderError(testing *testing.T) {
 	router := tests.InitTest()
 
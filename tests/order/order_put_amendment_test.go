@@ -0,0 +1,203 @@
+package order
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createOrderInPreparation(testing *testing.T, router http.Handler) uint {
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}, {"quantity": 1, "productID": 3}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var order models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &order)
+
+	response = tests.SendRequest(router, http.MethodPatch, "/orders/5/in-preparation", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	return order.ID
+}
+
+func TestPutOrderAmendment(testing *testing.T) {
+	router := tests.InitTest()
+
+	createOrderInPreparation(testing, router)
+
+	response := tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}, {"quantity": 1, "menuID": 1}},
+	}, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var order models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &order)
+
+	if assert.Len(testing, order.Amendments, 1) {
+		amendment := order.Amendments[0]
+
+		assert.Equal(testing, "+1 Test product 1, -1 Test product 3, +1 Test menu 1", amendment.Summary)
+		assert.Equal(testing, uint(2), amendment.UserID)
+
+		if assert.Len(testing, amendment.Lines, 3) {
+			assert.Equal(testing, 1, amendment.Lines[0].PreviousQuantity)
+			assert.Equal(testing, 2, amendment.Lines[0].Quantity)
+			assert.Equal(testing, 1, amendment.Lines[0].QuantityChange)
+			assert.Equal(testing, -1, amendment.Lines[1].QuantityChange)
+			assert.Equal(testing, uint(1), *amendment.Lines[2].MenuID)
+		}
+	}
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/5", nil, 1)
+	json.Unmarshal(response.Body.Bytes(), &order)
+
+	assert.Len(testing, order.Amendments, 1)
+
+	var events []models.OutboxEvent
+	config.DB.Where("type = ?", models.OrderAmended).Find(&events)
+
+	if assert.Len(testing, events, 1) {
+		assert.Equal(testing, uint(5), events[0].AggregateID)
+	}
+}
+
+func TestPutOrderAmendmentKitchenTicket(testing *testing.T) {
+	router := tests.InitTest()
+
+	createOrderInPreparation(testing, router)
+
+	response := tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/kitchen-stations/1/queue", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var tickets []models.KitchenTicketOutput
+	json.Unmarshal(response.Body.Bytes(), &tickets)
+
+	found := false
+	for _, ticket := range tickets {
+		if ticket.OrderID == 5 {
+			found = true
+
+			assert.Equal(testing, 2, ticket.Quantity)
+			assert.Equal(testing, []string{"+1 Test product 1, -1 Test product 3"}, ticket.Amendments)
+		}
+	}
+
+	assert.True(testing, found)
+}
+
+func TestPutOrderAmendmentSameQuantities(testing *testing.T) {
+	router := tests.InitTest()
+
+	createOrderInPreparation(testing, router)
+
+	response := tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 3, "notes": "No salt"}, {"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var order models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &order)
+
+	assert.Empty(testing, order.Amendments)
+}
+
+func TestPutOrderNoAmendmentBeforePreparation(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/orders/1", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 3, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var order models.OrderOutput
+	json.Unmarshal(response.Body.Bytes(), &order)
+
+	assert.Empty(testing, order.Amendments)
+
+	var count int64
+	config.DB.Model(&models.OrderAmendment{}).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestPutOrderAmendmentKeepsPreparedStationItems(testing *testing.T) {
+	router := tests.InitTest()
+
+	createOrderInPreparation(testing, router)
+
+	var stationItem models.OrderStationItem
+	config.DB.Joins("JOIN order_items ON order_items.id = order_station_items.order_item_id").
+		Where("order_items.order_id = ? AND order_station_items.product_id = ?", 5, 3).
+		First(&stationItem)
+
+	response := tests.SendRequest(router, http.MethodPatch, fmt.Sprintf("/kitchen-stations/items/%d/in-preparation", stationItem.ID), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPatch, fmt.Sprintf("/kitchen-stations/items/%d/prepared", stationItem.ID), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// Product 3 is unchanged, only product 1 is added.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}, {"quantity": 1, "productID": 3}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var keptStationItem models.OrderStationItem
+	if err := config.DB.First(&keptStationItem, stationItem.ID).Error; err != nil {
+		log.Fatal("Unable to fetch station item: ", err)
+	}
+
+	assert.Equal(testing, models.Prepared, keptStationItem.Status)
+	assert.Equal(testing, 1, keptStationItem.Quantity)
+
+	// Growing its quantity sends it back to preparation, with the new quantity.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}, {"quantity": 3, "productID": 3}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	config.DB.First(&keptStationItem, stationItem.ID)
+
+	assert.Equal(testing, models.InPreparation, keptStationItem.Status)
+	assert.Equal(testing, 3, keptStationItem.Quantity)
+
+	// Removing the product deletes its station item.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var count int64
+	config.DB.Model(&models.OrderStationItem{}).Where("id = ?", stationItem.ID).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestDiffOrderItems(testing *testing.T) {
+	fries, cola := uint(1), uint(2)
+
+	previousItems := []models.OrderItem{
+		{ProductID: &fries, OrderContentName: "Frites", Quantity: 1},
+		{ProductID: &cola, OrderContentName: "Coca-Cola", Quantity: 1},
+		{ProductID: &fries, OrderContentName: "Frites", Quantity: 1},
+	}
+	items := []models.OrderItem{
+		{ProductID: &fries, OrderContentName: "Frites", Quantity: 3},
+	}
+
+	amendment := models.OrderAmendment{Lines: models.DiffOrderItems(previousItems, items)}
+
+	assert.Equal(testing, "+1 Frites, -1 Coca-Cola", amendment.Summary())
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+	"wacdo/config"
 	"wacdo/models"
 	"wacdo/tests"
 
@@ -75,6 +76,131 @@ func TestPutOrderSuccess(testing *testing.T) {
 	assert.Equal(testing, 2.50, result.Items[1].OrderContentPrice)
 }
 
+func TestPutOrderNotes(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := map[string]interface{}{
+		"notes":     "No ice in the drinks",
+		"allergens": []string{"gluten", "sesame"},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/orders/2", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "002", result.TicketNumber)
+	assert.Equal(testing, "No ice in the drinks", result.Notes)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Sesame}, result.Allergens)
+	assert.Equal(testing, models.Allergens{models.Gluten, models.Sesame}, result.AllergenAlert)
+}
+
+func TestPutOrderClearRequestedReadyAt(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"requestedReadyAt": time.Now().Add(2 * time.Hour),
+		"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{"requestedReadyAt": nil}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var order models.OrderOutput
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Nil(testing, order.RequestedReadyAt)
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/5", nil, 1)
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Nil(testing, order.RequestedReadyAt)
+}
+
+func TestPutOrderRescheduleInPreparation(testing *testing.T) {
+	router := tests.InitTest()
+
+	// Order 2 is in preparation.
+	response := tests.SendRequest(router, http.MethodPut, "/orders/2", map[string]interface{}{"requestedReadyAt": time.Now().Add(2 * time.Hour)}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Order cannot be rescheduled because its preparation has started."}`, response.Body.String())
+}
+
+func TestPutOrderRescheduleAfterLastOrderTime(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	config.DB.Model(&models.Restaurant{}).Where("id = ?", 1).Update("last_order_cutoff", 30)
+	for day := 0; day < 7; day++ {
+		config.DB.Create(&models.OpeningHour{RestaurantID: 1, DayOfWeek: day, OpensAt: "11:00", ClosesAt: "23:00"})
+	}
+
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+	tomorrow := time.Now().In(location).AddDate(0, 0, 1)
+
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"requestedReadyAt": time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 22, 45, 0, 0, location),
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Requested ready time is after the last order time."}`, response.Body.String())
+
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{
+		"requestedReadyAt": time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 12, 0, 0, 0, location),
+	}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestPutOrderRescheduleItemNotAvailable(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+	now := time.Now().In(location)
+	productID := uint(1)
+	config.DB.Create(&models.AvailabilitySlot{ProductID: &productID, DayOfWeek: int(now.Weekday()), StartsAt: now.Add(-time.Hour).Format("15:04"), EndsAt: now.Add(time.Hour).Format("15:04")})
+
+	// Product 1 is no longer available once rescheduled later, even if the items are not changed.
+	response = tests.SendRequest(router, http.MethodPut, "/orders/5", map[string]interface{}{"requestedReadyAt": now.Add(3 * time.Hour)}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Product 1: item is not available at this time."}`, response.Body.String())
+}
+
 func TestPutOrderNoItems(testing *testing.T) {
 	router := tests.InitTest()
 
@@ -0,0 +1,144 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrdersQueueSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	orders := []map[string]interface{}{
+		{
+			"ticketNumber": "005",
+			"channel":      "driveThrough",
+			"items":        []map[string]interface{}{{"quantity": 1, "productID": 1}},
+		},
+		{
+			"ticketNumber":     "006",
+			"requestedReadyAt": time.Now().Add(2 * time.Hour),
+			"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+		},
+		{
+			"ticketNumber":     "007",
+			"requestedReadyAt": time.Now().Add(5 * time.Minute),
+			"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+		},
+	}
+
+	for _, order := range orders {
+		data, err := json.Marshal(order)
+		if err != nil {
+			log.Fatal("Unable to marshal data: ", err)
+		}
+
+		request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+		if err != nil {
+			log.Fatal("Unable to create request: ", err)
+		}
+
+		request.Header.Set("Content-Type", "application/json")
+
+		tests.AuthenticateUserAsAdmin(request)
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(testing, http.StatusCreated, response.Code)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, "/orders/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var results []models.OrderQueueOutput
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 4, len(results))
+
+	assert.Equal(testing, "005", results[0].TicketNumber)
+	assert.Equal(testing, 1, results[0].Priority)
+
+	assert.Equal(testing, "001", results[1].TicketNumber)
+	assert.Equal(testing, models.Created, results[1].Status)
+	assert.Equal(testing, 2, results[1].Priority)
+
+	assert.Equal(testing, "002", results[2].TicketNumber)
+	assert.Equal(testing, models.InPreparation, results[2].Status)
+	assert.Equal(testing, 3, results[2].Priority)
+
+	assert.Equal(testing, "007", results[3].TicketNumber)
+	assert.Equal(testing, 4, results[3].Priority)
+}
+
+func TestGetOrdersQueueScheduledInPreparation(testing *testing.T) {
+	router := tests.InitTest()
+
+	// Order 2 is in preparation: the kitchen keeps it in the queue, even scheduled for later.
+	config.DB.Model(&models.Order{ID: 2}).Update("requested_ready_at", time.Now().Add(3*time.Hour))
+
+	response := tests.SendRequest(router, http.MethodGet, "/orders/queue", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var results []models.OrderQueueOutput
+	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	found := false
+	for _, result := range results {
+		found = found || result.ID == 2
+	}
+
+	assert.True(testing, found)
+}
+
+func TestGetOrdersQueueUnauthorized(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/orders/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertUnauthorized(testing, response)
+}
+
+func TestGetOrdersQueueAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/orders/queue", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUser(request, 2)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
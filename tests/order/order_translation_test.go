@@ -0,0 +1,70 @@
+package order
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostOrderKeepsLanguage(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/3", map[string]interface{}{
+		"translations": map[string]interface{}{"en": map[string]string{"name": "Cheeseburger", "description": "A burger with cheese"}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"ticketNumber": "005",
+		"items":        []map[string]interface{}{{"quantity": 1, "productID": 3}},
+	})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/orders/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var order models.OrderOutput
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	// The order is read later in the language it was taken in, the kitchen getting the default name.
+	response = tests.SendRequest(router, http.MethodGet, "/orders/"+fmt.Sprint(order.ID), nil, 1)
+
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Cheeseburger", order.Items[0].OrderContentName)
+	assert.Equal(testing, "A burger with cheese", order.Items[0].OrderContentDescription)
+	assert.Equal(testing, "en", order.Items[0].OrderContentLocale)
+
+	var stationItem models.OrderStationItem
+	if err := config.DB.Where("order_item_id = ?", order.Items[0].ID).First(&stationItem).Error; err != nil {
+		log.Fatal("Unable to fetch station item: ", err)
+	}
+
+	assert.Equal(testing, "Test product 3", stationItem.ProductName)
+}
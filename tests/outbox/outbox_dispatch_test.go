@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/jobs"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// subscribe registers a test subscriber recording the events it handles, for the duration of the test.
+func subscribe(testing *testing.T, name string, handler models.EventHandler, types ...models.DomainEventType) *[]models.OutboxEvent {
+	handled := &[]models.OutboxEvent{}
+
+	models.Subscribe(name, func(tx *gorm.DB, event *models.OutboxEvent) error {
+		if handler != nil {
+			if err := handler(tx, event); err != nil {
+				return err
+			}
+		}
+
+		*handled = append(*handled, *event)
+
+		return nil
+	}, types...)
+	testing.Cleanup(func() { models.Unsubscribe(name) })
+
+	return handled
+}
+
+func findEvents(eventType models.DomainEventType) []models.OutboxEvent {
+	var events []models.OutboxEvent
+	config.DB.Where("type = ?", eventType).Order("id").Find(&events)
+
+	return events
+}
+
+func TestOutboxOrderStatusChanged(testing *testing.T) {
+	router := tests.InitTest()
+
+	handled := subscribe(testing, "test", nil, models.OrderStatusChanged)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/in-preparation", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	events := findEvents(models.OrderStatusChanged)
+	assert.Len(testing, events, 1)
+	assert.Equal(testing, uint(1), events[0].AggregateID)
+
+	_, err := jobs.DispatchOutboxEvents(config.DB, time.Now())
+	assert.Nil(testing, err)
+
+	if assert.Len(testing, *handled, 1) {
+		var payload models.OrderEvent
+		assert.Nil(testing, (*handled)[0].Decode(&payload))
+
+		assert.Equal(testing, models.Created, payload.PreviousStatus)
+		assert.Equal(testing, models.InPreparation, payload.Order.Status)
+	}
+
+	config.DB.First(&events[0], events[0].ID)
+	assert.False(testing, events[0].DispatchedAt.IsZero())
+	assert.Equal(testing, 1, events[0].Attempts)
+
+	// Dispatched events are not delivered again
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+	assert.Len(testing, *handled, 1)
+}
+
+func TestOutboxCatalogAndUserEvents(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{"price": 2.9}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/users/3", map[string]interface{}{"role": "manager"}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	products := findEvents(models.ProductUpdated)
+	if assert.NotEmpty(testing, products) {
+		var payload models.ProductEvent
+		assert.Nil(testing, products[len(products)-1].Decode(&payload))
+
+		assert.Equal(testing, uint(1), payload.Product.ID)
+		assert.Equal(testing, 2.9, payload.Product.Price)
+	}
+
+	users := findEvents(models.UserUpdated)
+	if assert.NotEmpty(testing, users) {
+		assert.Equal(testing, uint(3), users[0].AggregateID)
+		assert.NotContains(testing, users[0].Payload, "Password")
+	}
+}
+
+func TestOutboxRolledBackChange(testing *testing.T) {
+	router := tests.InitTest()
+
+	// The customer has 100 points, not enough for 4 products worth 30 points
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"customerID": 1,
+		"items":      []map[string]interface{}{{"quantity": 4, "productID": 1, "redeemPoints": true}},
+	}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	assert.Empty(testing, findEvents(models.OrderCreated))
+}
+
+func TestOutboxFailingSubscriberRetried(testing *testing.T) {
+	testing.Setenv("OUTBOX_RETRY_DELAY", "10")
+
+	router := tests.InitTest()
+
+	failing := true
+	succeeding := subscribe(testing, "a-succeeding", nil, models.OrderCancelled)
+	failed := subscribe(testing, "b-failing", func(tx *gorm.DB, event *models.OutboxEvent) error {
+		if failing {
+			return errors.New("printer offline")
+		}
+
+		return nil
+	}, models.OrderCancelled)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/cancelled", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	now := time.Now()
+	jobs.DispatchOutboxEvents(config.DB, now)
+
+	event := findEvents(models.OrderCancelled)[0]
+	assert.True(testing, event.DispatchedAt.IsZero())
+	assert.Equal(testing, 1, event.Attempts)
+	assert.Equal(testing, "b-failing: printer offline", event.Error)
+	assert.WithinDuration(testing, now.Add(10*time.Second), event.NextAttemptAt, time.Second)
+	assert.Len(testing, *succeeding, 1)
+	assert.Len(testing, *failed, 0)
+
+	// The event is not attempted again before its next attempt
+	jobs.DispatchOutboxEvents(config.DB, now.Add(5*time.Second))
+	assert.Len(testing, *succeeding, 1)
+
+	failing = false
+	jobs.DispatchOutboxEvents(config.DB, now.Add(10*time.Second))
+
+	event = findEvents(models.OrderCancelled)[0]
+	assert.False(testing, event.DispatchedAt.IsZero())
+	assert.Empty(testing, event.Error)
+	assert.Len(testing, *succeeding, 2)
+	assert.Len(testing, *failed, 1)
+}
@@ -0,0 +1,149 @@
+package product
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getProducts(router http.Handler, url string) (*httptest.ResponseRecorder, models.ListOutput[models.Product]) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	var list models.ListOutput[models.Product]
+	if response.Code == http.StatusOK {
+		if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+			log.Fatal("Unable to decode JSON: ", err)
+		}
+	}
+
+	return response, list
+}
+
+func productNames(products []models.Product) []string {
+	names := make([]string, 0, len(products))
+	for _, product := range products {
+		names = append(names, product.Name)
+	}
+
+	return names
+}
+
+func TestGetProductsPagination(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, list := getProducts(router, "/products/?limit=3")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, int64(4), list.Total)
+	assert.Equal(testing, 3, list.Limit)
+	assert.Equal(testing, []string{"Test product 1", "Test product 2", "Test product 3"}, productNames(list.Items))
+	assert.NotEmpty(testing, list.NextCursor)
+
+	response, list = getProducts(router, "/products/?limit=3&cursor="+list.NextCursor)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, int64(4), list.Total)
+	assert.Equal(testing, []string{"Test product 4"}, productNames(list.Items))
+	assert.Empty(testing, list.NextCursor)
+}
+
+func TestGetProductsSortedByPrice(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, list := getProducts(router, "/products/?sort=-price&limit=2")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 4", "Test product 2"}, productNames(list.Items))
+
+	response, list = getProducts(router, "/products/?sort=-price&limit=2&cursor="+list.NextCursor)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 3", "Test product 1"}, productNames(list.Items))
+	assert.Empty(testing, list.NextCursor)
+}
+
+func TestGetProductsSearch(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, list := getProducts(router, "/products/?search=DESCRIPTION%203")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, int64(1), list.Total)
+	assert.Equal(testing, []string{"Test product 3"}, productNames(list.Items))
+}
+
+func TestGetProductsFilters(testing *testing.T) {
+	router := tests.InitTest()
+
+	response, list := getProducts(router, "/products/?categoryID=1&minPrice=3&maxPrice=9.5")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 3", "Test product 4"}, productNames(list.Items))
+
+	response, list = getProducts(router, "/products/?available=false")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 2"}, productNames(list.Items))
+
+	response, list = getProducts(router, "/products/?vegetarian=true&available=true")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 1"}, productNames(list.Items))
+}
+
+func TestGetProductsInvalidListParameters(testing *testing.T) {
+	router := tests.InitTest()
+
+	for url, message := range map[string]string{
+		"/products/?limit=0":             "Invalid limit.",
+		"/products/?limit=101":           "Invalid limit.",
+		"/products/?sort=calories":       "Invalid sort.",
+		"/products/?cursor=invalid":      "Invalid cursor.",
+		"/products/?categoryID=a":        "Invalid categoryID filter.",
+		"/products/?available=sometimes": "Invalid available filter.",
+		"/products/?minPrice=-1":         "Invalid minPrice filter.",
+	} {
+		response, _ := getProducts(router, url)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code, url)
+		assert.Contains(testing, response.Body.String(), message, url)
+	}
+}
+
+func TestGetProductsSortedByPosition(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/4", map[string]interface{}{"position": 0}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{"position": 2}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/3", map[string]interface{}{"position": 1}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response, list := getProducts(router, "/products/?categoryID=1&sort=position")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, []string{"Test product 4", "Test product 3", "Test product 1"}, productNames(list.Items))
+
+	// Moved to another category, a product goes after its products.
+	response = tests.SendRequest(router, http.MethodPut, "/products/4", map[string]interface{}{"categoryID": 2}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 1, decodeProduct(response).Position)
+}
@@ -27,11 +27,13 @@ func TestGetProductsSuccess(testing *testing.T) {
 
 	assert.Equal(testing, http.StatusOK, response.Code)
 
-	var results []models.Product
-	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+	var list models.ListOutput[models.Product]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
 		log.Fatal("Unable to decode JSON: ", err)
 	}
 
+	results := list.Items
+
 	assert.Equal(testing, 4, len(results))
 
 	assert.Equal(testing, "Test product 1", results[0].Name)
@@ -55,6 +57,88 @@ func TestGetProductsSuccess(testing *testing.T) {
 	assert.True(testing, results[3].IsAvailable)
 }
 
+func TestGetProductsExcludeAllergens(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/products/?excludeAllergens=milk", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.Product]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	results := list.Items
+
+	assert.Equal(testing, 3, len(results))
+
+	assert.Equal(testing, "Test product 2", results[0].Name)
+	assert.Equal(testing, "Test product 3", results[1].Name)
+	assert.Equal(testing, models.Allergens{models.Gluten}, results[1].Allergens)
+	assert.Equal(testing, "Test product 4", results[2].Name)
+}
+
+func TestGetProductsVegetarian(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/products/?vegetarian=true", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.Product]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	results := list.Items
+
+	assert.Equal(testing, 2, len(results))
+
+	assert.Equal(testing, "Test product 1", results[0].Name)
+	assert.True(testing, results[0].IsVegetarian)
+	assert.Equal(testing, 250.0, results[0].Nutrition.Calories)
+	assert.Equal(testing, "Test product 2", results[1].Name)
+	assert.True(testing, results[1].IsVegetarian)
+}
+
+func TestGetProductsInvalidAllergen(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/products/?excludeAllergens=chocolate", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Invalid allergen.")
+}
+
 func TestGetProductsUnauthorized(testing *testing.T) {
 	router := tests.InitTest()
 
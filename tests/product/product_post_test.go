@@ -56,6 +56,56 @@ func TestPostProductSuccess(testing *testing.T) {
 	assert.Equal(testing, "https://res.cloudinary.com/demo/image/upload/sample.jpg", result.Image)
 }
 
+func TestPostProductAllergensNutrition(testing *testing.T) {
+	router := tests.InitTest()
+
+	product := map[string]interface{}{
+		"name":         "Test product 5",
+		"description":  "Test product description 5",
+		"price":        8.25,
+		"isAvailable":  true,
+		"categoryId":   1,
+		"allergens":    []string{"sesame"},
+		"isVegetarian": true,
+		"nutrition":    map[string]interface{}{"calories": 320, "salt": 0.8},
+		"image":        "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8BQDwAEhQGAhKmMIQAAAABJRU5ErkJggg==",
+	}
+
+	data, err := json.Marshal(product)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/products/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	result := models.Product{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test product 5", result.Name)
+	assert.Equal(testing, "Test product description 5", result.Description)
+	assert.Equal(testing, 8.25, result.Price)
+	assert.True(testing, result.IsAvailable)
+	assert.Equal(testing, "https://res.cloudinary.com/demo/image/upload/sample.jpg", result.Image)
+	assert.Equal(testing, models.Allergens{models.Sesame}, result.Allergens)
+	assert.True(testing, result.IsVegetarian)
+	assert.Equal(testing, 320.0, result.Nutrition.Calories)
+	assert.Equal(testing, 0.8, result.Nutrition.Salt)
+}
+
 func TestPostProductInvalidCategory(testing *testing.T) {
 	router := tests.InitTest()
 
@@ -92,6 +142,43 @@ func TestPostProductInvalidCategory(testing *testing.T) {
 	assert.Contains(testing, body, "Product category not found.")
 }
 
+func TestPostProductInvalidKitchenStation(testing *testing.T) {
+	router := tests.InitTest()
+
+	product := map[string]interface{}{
+		"name":             "Test product 5",
+		"description":      "Test product description 5",
+		"price":            8.25,
+		"isAvailable":      true,
+		"categoryId":       1,
+		"kitchenStationID": 9999,
+	}
+
+	data, err := json.Marshal(product)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/products/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, "error")
+	assert.Contains(testing, body, "Kitchen station not found.")
+}
+
 func TestPostProductError(testing *testing.T) {
 	router := tests.InitTest()
 
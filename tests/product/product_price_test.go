@@ -0,0 +1,226 @@
+package product
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/jobs"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodePriceHistory(response *httptest.ResponseRecorder) models.PriceHistoryOutput {
+	var history models.PriceHistoryOutput
+	if err := json.NewDecoder(response.Body).Decode(&history); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return history
+}
+
+func decodePriceAt(response *httptest.ResponseRecorder) models.PriceAtOutput {
+	var price models.PriceAtOutput
+	if err := json.NewDecoder(response.Body).Decode(&price); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return price
+}
+
+func TestPutProductRecordsPriceHistory(testing *testing.T) {
+	router := tests.InitTest()
+	before := time.Now()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{"price": 2.9}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// Updating another field does not record a price change.
+	response = tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{"name": "Renamed product"}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/prices", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	history := decodePriceHistory(response)
+
+	assert.Equal(testing, 2.9, history.Price)
+	assert.Equal(testing, 1, len(history.History))
+	assert.Equal(testing, 2.5, history.History[0].PreviousPrice)
+	assert.Equal(testing, 2.9, history.History[0].Price)
+	assert.Equal(testing, uint(1), history.History[0].UserID)
+	assert.NotNil(testing, history.History[0].AppliedAt)
+	assert.Empty(testing, history.Scheduled)
+
+	// Before the change, the price is the one it replaced.
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/price?at="+url.QueryEscape(before.Format(time.RFC3339Nano)), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 2.5, decodePriceAt(response).Price)
+}
+
+func TestPostProductRecordsInitialPrice(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/", map[string]interface{}{
+		"name":        "New product",
+		"description": "New product description",
+		"price":       5.5,
+		"isAvailable": true,
+		"categoryID":  1,
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var product models.Product
+	if err := json.NewDecoder(response.Body).Decode(&product); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	var changes []models.PriceChange
+	config.DB.Where("product_id = ?", product.ID).Find(&changes)
+
+	assert.Equal(testing, 1, len(changes))
+	assert.Equal(testing, 0.0, changes[0].PreviousPrice)
+	assert.Equal(testing, 5.5, changes[0].Price)
+}
+
+func TestScheduledProductPriceChange(testing *testing.T) {
+	router := tests.InitTest()
+	effectiveAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/1/prices", map[string]interface{}{"price": 3.1, "effectiveAt": effectiveAt}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var change models.PriceChange
+	if err := json.NewDecoder(response.Body).Decode(&change); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Nil(testing, change.AppliedAt)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/prices", nil, 1)
+	history := decodePriceHistory(response)
+
+	assert.Equal(testing, 2.5, history.Price)
+	assert.Equal(testing, 1, len(history.Scheduled))
+	assert.Equal(testing, 3.1, history.Scheduled[0].Price)
+
+	// The scheduled price is in effect from its date, not before.
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/price", nil, 2)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 2.5, decodePriceAt(response).Price)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/price?at="+url.QueryEscape(effectiveAt.Format(time.RFC3339)), nil, 2)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	price := decodePriceAt(response)
+	assert.Equal(testing, 3.1, price.Price)
+	assert.Equal(testing, &change.ID, price.PriceChangeID)
+
+	// Nothing is applied before the effective date.
+	applied, err := jobs.ApplyPriceChanges(config.DB, time.Now())
+	assert.Nil(testing, err)
+	assert.Equal(testing, 0, applied)
+
+	applied, err = jobs.ApplyPriceChanges(config.DB, effectiveAt.Add(time.Minute))
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, applied)
+
+	var product models.Product
+	config.DB.First(&product, 1)
+	assert.Equal(testing, 3.1, product.Price)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/prices", nil, 1)
+	history = decodePriceHistory(response)
+
+	assert.Empty(testing, history.Scheduled)
+	assert.Equal(testing, 1, len(history.History))
+	assert.Equal(testing, 2.5, history.History[0].PreviousPrice)
+	assert.NotNil(testing, history.History[0].AppliedAt)
+
+	var events int64
+	config.DB.Model(&models.OutboxEvent{}).Where("type = ? AND aggregate_id = ?", models.ProductUpdated, 1).Count(&events)
+	assert.Equal(testing, int64(1), events)
+}
+
+func TestScheduledPriceChangeOfDeletedProduct(testing *testing.T) {
+	router := tests.InitTest()
+	effectiveAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/4/prices", map[string]interface{}{"price": 9.5, "effectiveAt": effectiveAt}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// The change is applied to the deleted product, which gets its new price when restored.
+	applied, err := jobs.ApplyPriceChanges(config.DB, effectiveAt.Add(time.Minute))
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, applied)
+
+	var count int64
+	config.DB.Model(&models.PriceChange{}).Where("product_id = ? AND applied_at IS NOT NULL", 4).Count(&count)
+	assert.Equal(testing, int64(1), count)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, 9.5, decodeProduct(response).Price)
+}
+
+func TestDeleteScheduledProductPriceChange(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/1/prices", map[string]interface{}{"price": 3.1, "effectiveAt": time.Now().Add(time.Hour)}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var change models.PriceChange
+	if err := json.NewDecoder(response.Body).Decode(&change); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/3/prices/"+strconv.FormatUint(uint64(change.ID), 10), nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Scheduled price change not found.")
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/1/prices/"+strconv.FormatUint(uint64(change.ID), 10), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/prices", nil, 1)
+	assert.Empty(testing, decodePriceHistory(response).Scheduled)
+}
+
+func TestScheduleProductPriceChangeInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/1/prices", map[string]interface{}{"price": 3.1, "effectiveAt": time.Now().Add(-time.Hour)}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Effective date must be in the future.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/1/prices", map[string]interface{}{"price": -1, "effectiveAt": time.Now().Add(time.Hour)}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid data.")
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/price?at=tomorrow", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid date.")
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/price?at=2000-01-01T00:00:00Z", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "No price at this date.")
+}
+
+func TestProductPricesAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/1/prices", map[string]interface{}{"price": 3.1, "effectiveAt": time.Now().Add(time.Hour)}, 2)
+	tests.AssertAccessNotAllowed(testing, response)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1/prices", nil, 2)
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -56,6 +56,57 @@ func TestPutProductSuccess(testing *testing.T) {
 	assert.Equal(testing, "https://res.cloudinary.com/demo/image/upload/sample.jpg", result.Image)
 }
 
+func TestPutProductAllergensNutrition(testing *testing.T) {
+	router := tests.InitTest()
+
+	product := map[string]interface{}{
+		"name":         "Test product 1b",
+		"description":  "Test product description 1b",
+		"price":        3.29,
+		"isAvailable":  false,
+		"categoryId":   1,
+		"allergens":    []string{"milk"},
+		"isVegetarian": false,
+		"nutrition":    map[string]interface{}{"calories": 180, "proteins": 4.5},
+		"image":        "data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8BQDwAEhQGAhKmMIQAAAABJRU5ErkJggg==",
+	}
+
+	data, err := json.Marshal(product)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/products/1", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.Product{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test product 1b", result.Name)
+	assert.Equal(testing, "Test product description 1b", result.Description)
+	assert.Equal(testing, 3.29, result.Price)
+	assert.False(testing, result.IsAvailable)
+	assert.Equal(testing, "https://res.cloudinary.com/demo/image/upload/sample.jpg", result.Image)
+	assert.Equal(testing, models.Allergens{models.Milk}, result.Allergens)
+	assert.False(testing, result.IsVegetarian)
+	assert.Equal(testing, 180.0, result.Nutrition.Calories)
+	assert.Equal(testing, 4.5, result.Nutrition.Proteins)
+	assert.Equal(testing, 0.0, result.Nutrition.Salt)
+}
+
 func TestPutProductInvalidCategory(testing *testing.T) {
 	router := tests.InitTest()
 
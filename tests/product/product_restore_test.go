@@ -0,0 +1,168 @@
+package product
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRestoreProduct(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// A deleted product is hidden everywhere but in the list of deleted products.
+	response = tests.SendRequest(router, http.MethodGet, "/products/4", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	_, list := getProducts(router, "/products/")
+	assert.Equal(testing, int64(3), list.Total)
+
+	_, list = getProducts(router, "/products/deleted")
+	assert.Equal(testing, int64(1), list.Total)
+	assert.Equal(testing, uint(4), list.Items[0].ID)
+	assert.True(testing, list.Items[0].DeletedAt.Valid)
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 4}},
+	}, 2)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	product := decodeProduct(response)
+	assert.Equal(testing, uint(4), product.ID)
+	assert.False(testing, product.DeletedAt.Valid)
+
+	_, list = getProducts(router, "/products/")
+	assert.Equal(testing, int64(4), list.Total)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Deleted product not found.")
+}
+
+func TestRestoreProductDeletedCategory(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/4", map[string]interface{}{"name": "Test product 4", "categoryID": 3}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/categories/3", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot restore product: its category is deleted.")
+
+	// The category cannot be purged while a product, even deleted, belongs to it.
+	response = tests.SendRequest(router, http.MethodDelete, "/products/categories/3/purge", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot purge product category: there are products associated with it.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/categories/3/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestPurgeProduct(testing *testing.T) {
+	router := tests.InitTest()
+
+	// Only a deleted product can be purged.
+	response := tests.SendRequest(router, http.MethodDelete, "/products/4/purge", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Deleted product not found.")
+
+	createVariant(testing, router, 4, map[string]interface{}{"name": "Large", "sku": "P4-L", "price": 10})
+
+	tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/4/purge", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	// The SKU of its variants can be used again.
+	createVariant(testing, router, 3, map[string]interface{}{"name": "Large", "sku": "P4-L", "price": 4})
+}
+
+func TestPurgeProductReferences(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/menus/", map[string]interface{}{
+		"name": "Test menu 3", "description": "Test menu description 3", "price": 9, "isAvailable": true, "productsIDs": []int{4},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	menuID := decodeProduct(response).ID
+
+	createVariant(testing, router, 4, map[string]interface{}{"name": "Large", "sku": "P4-L", "price": 10})
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/4/prices", map[string]interface{}{"price": 9.5, "effectiveAt": time.Now().Add(24 * time.Hour)}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	tests.SendRequest(router, http.MethodDelete, "/menus/"+strconv.Itoa(int(menuID)), nil, 1)
+	tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/4/purge", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	for table, model := range map[string]interface{}{"variants": &models.ProductVariant{}, "price changes": &models.PriceChange{}} {
+		var count int64
+		config.DB.Model(model).Where("product_id = ?", 4).Count(&count)
+		assert.Equal(testing, int64(0), count, table)
+	}
+
+	var count int64
+	config.DB.Table("menu_products").Where("product_id = ?", 4).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestPurgeProductOrdered(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 4}},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := decodeOrder(response)
+
+	tests.SendRequest(router, http.MethodDelete, "/products/4", nil, 1)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/4/purge", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot purge product: there are orders associated with it.")
+
+	// The order keeps the deleted product.
+	order = decodeOrder(tests.SendRequest(router, http.MethodGet, "/orders/"+strconv.Itoa(int(order.ID)), nil, 1))
+	assert.Equal(testing, "Test product 4", order.Items[0].OrderContentName)
+}
+
+func TestDeletedProductsAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	for _, route := range []struct{ method, url string }{
+		{http.MethodGet, "/products/deleted"},
+		{http.MethodPost, "/products/4/restore"},
+		{http.MethodDelete, "/products/4/purge"},
+	} {
+		response := tests.SendRequest(router, route.method, route.url, nil, 2)
+
+		tests.AssertAccessNotAllowed(testing, response)
+	}
+}
@@ -0,0 +1,170 @@
+package product
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// currentSlot returns a slot including the current time, in the default time zone of the restaurants.
+func currentSlot() map[string]interface{} {
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+	startsAt := time.Now().In(location).Add(-time.Hour)
+
+	return map[string]interface{}{
+		"dayOfWeek": int(startsAt.Weekday()),
+		"startsAt":  startsAt.Format("15:04"),
+		"endsAt":    startsAt.Add(2 * time.Hour).Format("15:04"),
+	}
+}
+
+// otherDaySlot returns a slot on another day than the current one.
+func otherDaySlot() map[string]interface{} {
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+
+	return map[string]interface{}{
+		"dayOfWeek": (int(time.Now().In(location).Weekday()) + 3) % 7,
+		"startsAt":  "07:00",
+		"endsAt":    "11:00",
+	}
+}
+
+func decodeProduct(response *httptest.ResponseRecorder) models.Product {
+	var product models.Product
+	if err := json.NewDecoder(response.Body).Decode(&product); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return product
+}
+
+func TestPutProductSchedule(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/products/1", nil, 1)
+	assert.True(testing, decodeProduct(response).IsAvailableNow)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{
+		"slots": []map[string]interface{}{otherDaySlot()},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	product := decodeProduct(response)
+	assert.True(testing, product.IsAvailable)
+	assert.False(testing, product.IsAvailableNow)
+	assert.Equal(testing, 1, len(product.AvailabilitySlots))
+	assert.Equal(testing, "07:00", product.AvailabilitySlots[0].StartsAt)
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 1: item is not available at this time.")
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{
+		"slots": []map[string]interface{}{otherDaySlot(), currentSlot()},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.True(testing, decodeProduct(response).IsAvailableNow)
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	// Without slots, the product can be ordered at any time again.
+	response = tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{"slots": []map[string]interface{}{}}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	product = decodeProduct(response)
+	assert.True(testing, product.IsAvailableNow)
+	assert.Empty(testing, product.AvailabilitySlots)
+}
+
+func TestPutProductCategorySchedule(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/1/schedule", map[string]interface{}{
+		"slots": []map[string]interface{}{otherDaySlot()},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var productCategory models.ProductCategory
+	if err := json.NewDecoder(response.Body).Decode(&productCategory); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.False(testing, productCategory.IsAvailableNow)
+	assert.Equal(testing, 1, len(productCategory.AvailabilitySlots))
+
+	// The slots of the category apply to its products, but not to the products of other categories.
+	response, list := getProducts(router, "/products/")
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	for _, product := range list.Items {
+		assert.Equal(testing, product.IsAvailable && product.CategoryID != 1, product.IsAvailableNow, product.Name)
+		assert.Empty(testing, product.AvailabilitySlots, product.Name)
+	}
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 3}},
+	}, 2)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 3: item is not available at this time.")
+}
+
+func TestPutProductScheduleInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	for message, slot := range map[string]map[string]interface{}{
+		"Invalid day of week.":                   {"dayOfWeek": 7, "startsAt": "07:00", "endsAt": "11:00"},
+		"Invalid start time.":                    {"dayOfWeek": 1, "startsAt": "25:00", "endsAt": "11:00"},
+		"Invalid end time.":                      {"dayOfWeek": 1, "startsAt": "07:00", "endsAt": "eleven"},
+		"Start and end times must be different.": {"dayOfWeek": 1, "startsAt": "07:00", "endsAt": "07:00"},
+		"Invalid data.":                          {"startsAt": "07:00", "endsAt": "11:00"},
+	} {
+		response := tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{
+			"slots": []map[string]interface{}{slot},
+		}, 1)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code, message)
+		assert.Contains(testing, response.Body.String(), message)
+	}
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{"slots": []map[string]interface{}{}}, 2)
+	tests.AssertAccessNotAllowed(testing, response)
+}
+
+func TestPostScheduledOrderProductSchedule(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/1/schedule", map[string]interface{}{
+		"slots": []map[string]interface{}{otherDaySlot()},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.False(testing, decodeProduct(response).IsAvailableNow)
+
+	// The slot is on the day three days from now, from 07:00 to 11:00.
+	location, _ := time.LoadLocation(models.DefaultTimeZone)
+	day := time.Now().In(location).AddDate(0, 0, 3)
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"requestedReadyAt": time.Date(day.Year(), day.Month(), day.Day(), 14, 0, 0, 0, location),
+		"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 1: item is not available at this time.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"requestedReadyAt": time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, location),
+		"items":            []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+}
@@ -0,0 +1,152 @@
+package product
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getLocalized(router http.Handler, url string, acceptLanguage string) *httptest.ResponseRecorder {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", acceptLanguage)
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func translateProduct1(testing *testing.T, router http.Handler) {
+	response := tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{
+		"translations": map[string]interface{}{
+			"en":    map[string]string{"name": "Fries", "description": "Crispy fries"},
+			"en-GB": map[string]string{"name": "Chips"},
+			"es":    map[string]string{"name": "Patatas fritas", "description": "Patatas crujientes"},
+		},
+	}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestGetProductTranslated(testing *testing.T) {
+	router := tests.InitTest()
+	translateProduct1(testing, router)
+
+	for acceptLanguage, expected := range map[string][]string{
+		"en-US,en;q=0.9":   {"en", "Fries", "Crispy fries"},
+		"en-GB":            {"en-gb", "Chips", "Test product description 1"},
+		"de, es;q=0.5":     {"es", "Patatas fritas", "Patatas crujientes"},
+		"de":               {"", "Test product 1", "Test product description 1"},
+		"fr-CA, en;q=0.8":  {"", "Test product 1", "Test product description 1"},
+		"*, es;q=0":        {"", "Test product 1", "Test product description 1"},
+		"es;q=0.2, en-AU":  {"en", "Fries", "Crispy fries"},
+		"invalid language": {"", "Test product 1", "Test product description 1"},
+	} {
+		response := getLocalized(router, "/products/1", acceptLanguage)
+
+		assert.Equal(testing, http.StatusOK, response.Code, acceptLanguage)
+
+		product := decodeProduct(response)
+
+		assert.Equal(testing, expected[0], product.Locale, acceptLanguage)
+		assert.Equal(testing, expected[1], product.Name, acceptLanguage)
+		assert.Equal(testing, expected[2], product.Description, acceptLanguage)
+	}
+
+	response := getLocalized(router, "/products/?search=test%20product%201", "en")
+	_, list := getProducts(router, "/products/?search=test%20product%201")
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"Name":"Fries"`)
+	assert.Equal(testing, "Test product 1", list.Items[0].Name)
+	assert.Equal(testing, 3, len(list.Items[0].Translations))
+}
+
+func TestPostProductTranslations(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/", map[string]interface{}{
+		"name":        "Frites",
+		"description": "Frites croustillantes",
+		"price":       2.5,
+		"isAvailable": true,
+		"categoryID":  1,
+		"translations": map[string]interface{}{
+			"EN": map[string]string{"name": "Fries", "description": "Crispy fries"},
+		},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	product := decodeProduct(response)
+
+	assert.Equal(testing, "Frites", product.Name)
+	assert.Equal(testing, "Fries", product.Translations["en"].Name)
+}
+
+func TestPutProductTranslationsInvalid(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{
+		"translations": map[string]interface{}{"english": map[string]string{"name": "Fries"}},
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Invalid locale english."}`, response.Body.String())
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1", map[string]interface{}{
+		"translations": map[string]interface{}{"en": map[string]string{"description": "Crispy fries"}},
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Translation en: name is required."}`, response.Body.String())
+}
+
+func TestGetProductsTranslatedSortedByName(testing *testing.T) {
+	router := tests.InitTest()
+
+	// Translated names sort in another order than the default ones.
+	for id, name := range map[string]string{"1": "Zucchini", "2": "Yam", "3": "Apple"} {
+		response := tests.SendRequest(router, http.MethodPut, "/products/"+id, map[string]interface{}{
+			"translations": map[string]interface{}{"en": map[string]string{"name": name}},
+		}, 1)
+		assert.Equal(testing, http.StatusOK, response.Code)
+	}
+
+	var names []string
+	url := "/products/?sort=name&limit=1"
+
+	for page := 0; page < 10; page++ {
+		response := getLocalized(router, url, "en")
+		assert.Equal(testing, http.StatusOK, response.Code)
+
+		var list models.ListOutput[models.Product]
+		if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+			log.Fatal("Unable to decode JSON: ", err)
+		}
+
+		for _, product := range list.Items {
+			names = append(names, product.Name)
+		}
+
+		if list.NextCursor == "" {
+			break
+		}
+
+		url = "/products/?sort=name&limit=1&cursor=" + list.NextCursor
+	}
+
+	// Pages follow the names stored in the default language.
+	assert.Equal(testing, []string{"Zucchini", "Yam", "Apple", "Test product 4"}, names)
+}
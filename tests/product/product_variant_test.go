@@ -0,0 +1,149 @@
+package product
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createVariant(testing *testing.T, router http.Handler, productID uint, input map[string]interface{}) models.ProductVariant {
+	response := tests.SendRequest(router, http.MethodPost, "/products/"+strconv.Itoa(int(productID))+"/variants", input, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var variant models.ProductVariant
+	if err := json.NewDecoder(response.Body).Decode(&variant); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return variant
+}
+
+func decodeOrder(response *httptest.ResponseRecorder) models.OrderOutput {
+	var order models.OrderOutput
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return order
+}
+
+func TestProductVariants(testing *testing.T) {
+	router := tests.InitTest()
+
+	small := createVariant(testing, router, 1, map[string]interface{}{"name": "Small", "sku": "P1-S", "price": 2})
+	large := createVariant(testing, router, 1, map[string]interface{}{"name": "Large", "sku": "P1-L", "price": 3.2, "menuSupplement": 0.7})
+
+	assert.True(testing, small.IsAvailable)
+	assert.Equal(testing, 0.7, large.MenuSupplement)
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/1/variants", map[string]interface{}{"name": "Medium", "sku": " P1-L ", "price": 2.5}, 1)
+	assert.Equal(testing, http.StatusConflict, response.Code)
+	assert.Contains(testing, response.Body.String(), "SKU P1-L is already used.")
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/1/variants/"+strconv.Itoa(int(small.ID)), map[string]interface{}{"isAvailable": false, "price": 2.1}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1", nil, 1)
+	product := decodeProduct(response)
+
+	assert.Equal(testing, 2, len(product.Variants))
+	assert.Equal(testing, 2.1, product.Variants[0].Price)
+	assert.False(testing, product.Variants[0].IsAvailable)
+
+	// A variant is updated through its own product only.
+	response = tests.SendRequest(router, http.MethodPut, "/products/3/variants/"+strconv.Itoa(int(small.ID)), map[string]interface{}{"price": 1}, 1)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product variant not found.")
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/1/variants/"+strconv.Itoa(int(small.ID)), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/products/1", nil, 1)
+	product = decodeProduct(response)
+
+	assert.Equal(testing, 1, len(product.Variants))
+	assert.Equal(testing, large.ID, product.Variants[0].ID)
+	assert.Equal(testing, "P1-L", product.Variants[0].SKU)
+}
+
+func TestOrderProductVariant(testing *testing.T) {
+	router := tests.InitTest()
+
+	small := createVariant(testing, router, 1, map[string]interface{}{"name": "Small", "sku": "P1-S", "price": 2, "isAvailable": false})
+	large := createVariant(testing, router, 1, map[string]interface{}{"name": "Large", "sku": "P1-L", "price": 3.2})
+
+	for variantID, message := range map[uint]string{
+		0:        "Product 1: a variant must be chosen.",
+		999:      "Product 1: invalid variant 999.",
+		small.ID: "Product 1: variant " + strconv.Itoa(int(small.ID)) + " is not available.",
+	} {
+		response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+			"items": []map[string]interface{}{{"quantity": 1, "productID": 1, "variantID": variantID}},
+		}, 2)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code, message)
+		assert.Contains(testing, response.Body.String(), message)
+	}
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1, "variantID": large.ID}},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := decodeOrder(response)
+
+	assert.Equal(testing, "Test product 1 (Large)", order.Items[0].OrderContentName)
+	assert.Equal(testing, 3.2, order.Items[0].OrderContentPrice)
+	assert.Equal(testing, &large.ID, order.Items[0].ProductVariantID)
+	assert.Equal(testing, 6.4, order.TotalPrice)
+
+	var stationItem models.OrderStationItem
+	config.DB.Joins("JOIN order_items ON order_items.id = order_station_items.order_item_id").Where("order_items.order_id = ?", order.ID).First(&stationItem)
+	assert.Equal(testing, "Test product 1 (Large)", stationItem.ProductName)
+}
+
+func TestOrderMenuUpsized(testing *testing.T) {
+	router := tests.InitTest()
+
+	large := createVariant(testing, router, 1, map[string]interface{}{"name": "Large", "sku": "P1-L", "price": 3.2, "menuSupplement": 1})
+	other := createVariant(testing, router, 3, map[string]interface{}{"name": "Large", "sku": "P3-L", "price": 4.5, "menuSupplement": 1})
+
+	for message, choice := range map[string]map[string]interface{}{
+		"Menu 1: product 1: invalid variant " + strconv.Itoa(int(other.ID)) + ".": {"productID": 1, "variantID": other.ID},
+		"Menu 1: product 3 is not part of the menu.":                              {"productID": 3, "variantID": other.ID},
+	} {
+		response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+			"items": []map[string]interface{}{{"quantity": 1, "menuID": 1, "menuChoices": []map[string]interface{}{choice}}},
+		}, 2)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code, message)
+		assert.Contains(testing, response.Body.String(), message)
+	}
+
+	// In a menu, the product keeps its price unless it is upsized.
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"quantity": 1, "menuID": 1},
+			{"quantity": 2, "menuID": 1, "menuChoices": []map[string]interface{}{{"productID": 1, "variantID": large.ID}}},
+		},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order := decodeOrder(response)
+
+	assert.Equal(testing, "Test menu 1", order.Items[0].OrderContentName)
+	assert.Equal(testing, 8.54, order.Items[0].OrderContentPrice)
+	assert.Empty(testing, order.Items[0].MenuChoices)
+
+	assert.Equal(testing, "Test menu 1", order.Items[1].OrderContentName)
+	assert.Equal(testing, "Test product 1 (Large)", order.Items[1].MenuChoices)
+	assert.InDelta(testing, 9.54, order.Items[1].OrderContentPrice, 0.001)
+}
@@ -27,11 +27,13 @@ func TestGetProductCategoriesSuccess(testing *testing.T) {
 
 	assert.Equal(testing, http.StatusOK, response.Code)
 
-	var results []models.ProductCategory
-	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+	var list models.ListOutput[models.ProductCategory]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
 		log.Fatal("Unable to decode JSON: ", err)
 	}
 
+	results := list.Items
+
 	assert.Equal(testing, 3, len(results))
 
 	assert.Equal(testing, "Test product category 1", results[0].Name)
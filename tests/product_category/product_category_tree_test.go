@@ -0,0 +1,242 @@
+package product_category
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func createSubcategory(testing *testing.T, router *gin.Engine, name string, parentID uint) models.ProductCategory {
+	response := tests.SendRequest(router, http.MethodPost, "/products/categories/", map[string]interface{}{
+		"name":        name,
+		"description": name,
+		"parentID":    parentID,
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var productCategory models.ProductCategory
+	if err := json.NewDecoder(response.Body).Decode(&productCategory); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return productCategory
+}
+
+func getProductCategoriesTree(router *gin.Engine, url string) models.ListOutput[models.ProductCategory] {
+	response := tests.SendRequest(router, http.MethodGet, url, nil, 1)
+
+	var list models.ListOutput[models.ProductCategory]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return list
+}
+
+func TestGetProductCategoriesTree(testing *testing.T) {
+	router := tests.InitTest()
+
+	beef := createSubcategory(testing, router, "Beef", 1)
+	chicken := createSubcategory(testing, router, "Chicken", 1)
+	doubleBeef := createSubcategory(testing, router, "Double beef", beef.ID)
+
+	assert.Equal(testing, 0, beef.Position)
+	assert.Equal(testing, 1, chicken.Position)
+	assert.Equal(testing, 0, doubleBeef.Position)
+
+	list := getProductCategoriesTree(router, "/products/categories/")
+
+	assert.Equal(testing, int64(3), list.Total)
+	assert.Equal(testing, 3, len(list.Items))
+	assert.Equal(testing, uint(1), list.Items[0].ID)
+	assert.Equal(testing, 2, len(list.Items[0].Children))
+	assert.Equal(testing, "Beef", list.Items[0].Children[0].Name)
+	assert.Equal(testing, "Chicken", list.Items[0].Children[1].Name)
+	assert.Equal(testing, "Double beef", list.Items[0].Children[0].Children[0].Name)
+	assert.Empty(testing, list.Items[1].Children)
+
+	// The search finds the subcategories too.
+	list = getProductCategoriesTree(router, "/products/categories/?search=chicken")
+
+	assert.Equal(testing, 1, len(list.Items))
+	assert.Equal(testing, chicken.ID, list.Items[0].ID)
+}
+
+func TestPostProductCategoryParentNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/products/categories/", map[string]interface{}{
+		"name":        "Beef",
+		"description": "Beef",
+		"parentID":    999,
+	}, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
+
+func TestPutProductCategoryParentCycle(testing *testing.T) {
+	router := tests.InitTest()
+
+	beef := createSubcategory(testing, router, "Beef", 1)
+	doubleBeef := createSubcategory(testing, router, "Double beef", beef.ID)
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/1", map[string]interface{}{"parentID": doubleBeef.ID}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Invalid parent: a category cannot be nested in itself."}`, response.Body.String())
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/categories/1", map[string]interface{}{"parentID": 1}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+}
+
+func TestPutProductCategoryParentMove(testing *testing.T) {
+	router := tests.InitTest()
+
+	beef := createSubcategory(testing, router, "Beef", 1)
+
+	// Moved under category 2, then back to the top level, after the other categories.
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/3", map[string]interface{}{"parentID": 2}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/categories/"+fmt.Sprint(beef.ID), map[string]interface{}{"parentID": 0}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	list := getProductCategoriesTree(router, "/products/categories/")
+
+	assert.Equal(testing, 3, len(list.Items))
+	assert.Equal(testing, uint(3), list.Items[1].Children[0].ID)
+	assert.Equal(testing, beef.ID, list.Items[2].ID)
+	assert.Empty(testing, list.Items[0].Children)
+}
+
+func TestPutProductCategoriesReorder(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/reorder", map[string]interface{}{"ids": []uint{3, 1, 2}}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	list := getProductCategoriesTree(router, "/products/categories/")
+
+	assert.Equal(testing, uint(3), list.Items[0].ID)
+	assert.Equal(testing, uint(1), list.Items[1].ID)
+	assert.Equal(testing, uint(2), list.Items[2].ID)
+
+	beef := createSubcategory(testing, router, "Beef", 1)
+	chicken := createSubcategory(testing, router, "Chicken", 1)
+
+	response = tests.SendRequest(router, http.MethodPut, "/products/categories/reorder", map[string]interface{}{"parentID": 1, "ids": []uint{chicken.ID, beef.ID}}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	list = getProductCategoriesTree(router, "/products/categories/")
+
+	assert.Equal(testing, "Chicken", list.Items[1].Children[0].Name)
+	assert.Equal(testing, "Beef", list.Items[1].Children[1].Name)
+}
+
+func TestPutProductCategoriesReorderIncomplete(testing *testing.T) {
+	router := tests.InitTest()
+
+	for _, ids := range [][]uint{{3, 1}, {3, 1, 2, 2}, {3, 1, 2, 4}} {
+		response := tests.SendRequest(router, http.MethodPut, "/products/categories/reorder", map[string]interface{}{"ids": ids}, 1)
+
+		assert.Equal(testing, http.StatusBadRequest, response.Code)
+		assert.JSONEq(testing, `{"error": "Invalid order: each item of the group must be listed once."}`, response.Body.String())
+	}
+}
+
+func TestPutProductCategoryProductsReorder(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/1/products/reorder", map[string]interface{}{"ids": []uint{4, 1, 3}}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var productCategory models.ProductCategory
+	if err := json.NewDecoder(response.Body).Decode(&productCategory); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 3, len(productCategory.Products))
+	assert.Equal(testing, uint(4), productCategory.Products[0].ID)
+	assert.Equal(testing, uint(1), productCategory.Products[1].ID)
+	assert.Equal(testing, uint(3), productCategory.Products[2].ID)
+
+	// A product of another category cannot be ordered in this one.
+	response = tests.SendRequest(router, http.MethodPut, "/products/categories/1/products/reorder", map[string]interface{}{"ids": []uint{4, 1, 2}}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+}
+
+func TestDeleteProductCategoryWithSubcategories(testing *testing.T) {
+	router := tests.InitTest()
+
+	beef := createSubcategory(testing, router, "Beef", 3)
+
+	response := tests.SendRequest(router, http.MethodDelete, "/products/categories/3", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Cannot delete product category: there are subcategories in it."}`, response.Body.String())
+
+	// Once its subcategory is deleted, the parent can be deleted, but the subcategory cannot be restored without it.
+	response = tests.SendRequest(router, http.MethodDelete, "/products/categories/"+fmt.Sprint(beef.ID), nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/categories/3", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = tests.SendRequest(router, http.MethodPost, "/products/categories/"+fmt.Sprint(beef.ID)+"/restore", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Cannot restore product category: its parent is deleted."}`, response.Body.String())
+
+	response = tests.SendRequest(router, http.MethodDelete, "/products/categories/3/purge", nil, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Cannot purge product category: there are subcategories in it."}`, response.Body.String())
+}
+
+func TestGetProductCategoriesTreeTranslated(testing *testing.T) {
+	router := tests.InitTest()
+
+	beef := createSubcategory(testing, router, "Boeuf", 1)
+
+	response := tests.SendRequest(router, http.MethodPut, "/products/categories/"+fmt.Sprint(beef.ID), map[string]interface{}{
+		"translations": map[string]interface{}{"en": map[string]string{"name": "Beef"}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	request, err := http.NewRequest(http.MethodGet, "/products/categories/", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", "en")
+	tests.AuthenticateUserAsAdmin(request)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	var list models.ListOutput[models.ProductCategory]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Test product category 1", list.Items[0].Name)
+	assert.Equal(testing, "Beef", list.Items[0].Children[0].Name)
+	assert.Equal(testing, "Boeuf", list.Items[0].Children[0].Description)
+	assert.Equal(testing, "en", list.Items[0].Children[0].Locale)
+}
@@ -0,0 +1,323 @@
+package restaurant
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendRequest sends a request as the given user, in the given restaurant when it is not 0.
+func sendRequest(router *gin.Engine, method string, url string, body interface{}, userID uint, restaurantID uint) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			log.Fatal("Unable to marshal data: ", err)
+		}
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if restaurantID != 0 {
+		request.Header.Set(models.RestaurantHeader, strconv.Itoa(int(restaurantID)))
+	}
+
+	tests.AuthenticateUser(request, userID)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func postOrder(router *gin.Engine, userID uint, restaurantID uint, productID uint) models.OrderOutput {
+	response := sendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{
+				"quantity":  1,
+				"productID": productID,
+			},
+		},
+	}, userID, restaurantID)
+
+	if response.Code != http.StatusCreated {
+		log.Fatal("Unable to create order: ", response.Body.String())
+	}
+
+	result := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	return result
+}
+
+func TestRestaurantOrdersIsolation(testing *testing.T) {
+	router := tests.InitTest()
+
+	order := postOrder(router, 5, 0, 1)
+
+	assert.Equal(testing, uint(5), order.ID)
+	assert.Equal(testing, uint(2), order.RestaurantID)
+	assert.Equal(testing, "001", order.TicketNumber)
+
+	// The second restaurant only sees its own order.
+	response := sendRequest(router, http.MethodGet, "/orders/", nil, 1, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var orders []models.OrderOutput
+	if err := json.NewDecoder(response.Body).Decode(&orders); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(orders))
+	assert.Equal(testing, uint(5), orders[0].ID)
+	assert.Equal(testing, "greeter3@example.com", orders[0].User.Email)
+
+	// The first restaurant does not see it.
+	response = sendRequest(router, http.MethodGet, "/orders/", nil, 1, 0)
+
+	orders = nil
+	if err := json.NewDecoder(response.Body).Decode(&orders); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 4, len(orders))
+
+	for _, order := range orders {
+		assert.Equal(testing, uint(1), order.RestaurantID)
+	}
+
+	response = sendRequest(router, http.MethodGet, "/orders/5", nil, 1, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Order not found.")
+}
+
+func TestRestaurantOrdersUpdateIsolation(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/orders/1", map[string]interface{}{"notes": "Test"}, 5, 0)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendRequest(router, http.MethodPatch, "/orders/3/delivered", nil, 5, 0)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendRequest(router, http.MethodPatch, "/orders/status", map[string]interface{}{
+		"orderIDs": []uint{3},
+		"status":   models.Delivered,
+		"mode":     models.BestEffort,
+	}, 5, 0)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	result := models.OrderStatusBulkOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "Order not found.", result.Results[0].Error)
+
+	response = sendRequest(router, http.MethodGet, "/orders/3", nil, 1, 0)
+
+	order := models.OrderOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&order); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.Prepared, order.Status)
+}
+
+func TestRestaurantTicketSequences(testing *testing.T) {
+	router := tests.InitTest()
+
+	assert.Equal(testing, "005", postOrder(router, 1, 0, 1).TicketNumber)
+	assert.Equal(testing, "001", postOrder(router, 1, 2, 1).TicketNumber)
+	assert.Equal(testing, "006", postOrder(router, 2, 0, 1).TicketNumber)
+	assert.Equal(testing, "002", postOrder(router, 5, 0, 1).TicketNumber)
+}
+
+func TestRestaurantKitchenQueueIsolation(testing *testing.T) {
+	router := tests.InitTest()
+
+	postOrder(router, 1, 0, 3)
+	postOrder(router, 5, 0, 3)
+
+	response := sendRequest(router, http.MethodGet, "/kitchen-stations/2/queue", nil, 1, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var tickets []models.KitchenTicketOutput
+	if err := json.NewDecoder(response.Body).Decode(&tickets); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(tickets))
+	assert.Equal(testing, uint(6), tickets[0].OrderID)
+}
+
+func TestRestaurantUsersIsolation(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodGet, "/users/", nil, 1, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.UserOutput]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	users := list.Items
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	// The administrator manages the second restaurant, the greeter works in it.
+	assert.Equal(testing, 2, len(users))
+	assert.Equal(testing, "admin1@example.com", users[0].Email)
+	assert.Equal(testing, []uint{2}, users[0].RestaurantIDs)
+	assert.Equal(testing, "greeter3@example.com", users[1].Email)
+	assert.Equal(testing, uint(2), users[1].RestaurantID)
+
+	response = sendRequest(router, http.MethodGet, "/users/2", nil, 1, 2)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendRequest(router, http.MethodDelete, "/users/2", nil, 1, 2)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
+
+func TestRestaurantPostUserInCurrentRestaurant(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPost, "/users/", map[string]interface{}{
+		"email":    "greeter4@example.com",
+		"password": "Greeter3456!",
+		"role":     models.Greeter,
+	}, 1, 2)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	user := models.UserOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&user); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, uint(2), user.RestaurantID)
+
+	response = sendRequest(router, http.MethodPost, "/users/", map[string]interface{}{
+		"email":         "greeter5@example.com",
+		"password":      "Greeter3456!",
+		"role":          models.Greeter,
+		"restaurantIDs": []uint{1},
+	}, 1, 2)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Only administrators can manage several restaurants.")
+
+	response = sendRequest(router, http.MethodPost, "/users/", map[string]interface{}{
+		"email":         "admin2@example.com",
+		"password":      "Admin3456!",
+		"role":          models.Admin,
+		"restaurantIDs": []uint{3},
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Restaurant not found.")
+}
+
+func TestRestaurantAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodGet, "/products/", nil, 2, 2)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Contains(testing, response.Body.String(), "Access to this restaurant not allowed.")
+
+	response = sendRequest(router, http.MethodGet, "/products/", nil, 5, 1)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Contains(testing, response.Body.String(), "Access to this restaurant not allowed.")
+}
+
+func TestRestaurantCatalogAvailability(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/products/1/availability", map[string]interface{}{"isAvailable": false}, 1, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	product := models.Product{}
+	if err := json.NewDecoder(response.Body).Decode(&product); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.False(testing, product.IsAvailable)
+
+	// The product is still available in the first restaurant.
+	response = sendRequest(router, http.MethodGet, "/products/1", nil, 1, 1)
+
+	product = models.Product{}
+	if err := json.NewDecoder(response.Body).Decode(&product); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.True(testing, product.IsAvailable)
+
+	response = sendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 5, 0)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Product 1: item is not available.")
+
+	// A product unavailable everywhere can be made available in one restaurant.
+	response = sendRequest(router, http.MethodPut, "/menus/2/availability", map[string]interface{}{"isAvailable": true}, 1, 2)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = sendRequest(router, http.MethodGet, "/menus/2", nil, 5, 0)
+
+	menu := models.Menu{}
+	if err := json.NewDecoder(response.Body).Decode(&menu); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.True(testing, menu.IsAvailable)
+
+	response = sendRequest(router, http.MethodGet, "/menus/2", nil, 1, 0)
+
+	menu = models.Menu{}
+	if err := json.NewDecoder(response.Body).Decode(&menu); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.False(testing, menu.IsAvailable)
+}
+
+func TestRestaurantCatalogAvailabilityAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/products/1/availability", map[string]interface{}{"isAvailable": false}, 5, 0)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
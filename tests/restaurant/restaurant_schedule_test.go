@@ -0,0 +1,253 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSchedule() *models.RestaurantSchedule {
+	return &models.RestaurantSchedule{
+		Restaurant: models.Restaurant{ID: 1, Name: "Test restaurant 1", TimeZone: "Europe/Paris", LastOrderCutoff: 30},
+		OpeningHours: []models.OpeningHour{
+			{DayOfWeek: int(time.Monday), OpensAt: "11:00", ClosesAt: "23:00"},
+			{DayOfWeek: int(time.Friday), OpensAt: "18:00", ClosesAt: "02:00"},
+		},
+	}
+}
+
+func TestRestaurantScheduleStatus(testing *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		log.Fatal("Unable to load location: ", err)
+	}
+
+	schedule := newTestSchedule()
+
+	// Monday at noon
+	status := schedule.Status(time.Date(2026, time.October, 19, 12, 0, 0, 0, paris))
+
+	assert.True(testing, status.IsOpen)
+	assert.True(testing, status.AcceptsOrders)
+	assert.Equal(testing, time.Date(2026, time.October, 19, 23, 0, 0, 0, paris), status.ClosesAt.In(paris))
+	assert.Nil(testing, status.NextOpeningAt)
+
+	// Monday, after the last order time
+	status = schedule.Status(time.Date(2026, time.October, 19, 22, 45, 0, 0, paris))
+
+	assert.True(testing, status.IsOpen)
+	assert.False(testing, status.AcceptsOrders)
+	assert.Equal(testing, "Last order time has passed.", status.Reason)
+
+	// Monday at 4am, given in UTC
+	status = schedule.Status(time.Date(2026, time.October, 19, 2, 0, 0, 0, time.UTC))
+
+	assert.False(testing, status.IsOpen)
+	assert.False(testing, status.AcceptsOrders)
+	assert.Equal(testing, "Outside opening hours.", status.Reason)
+	assert.Equal(testing, time.Date(2026, time.October, 19, 11, 0, 0, 0, paris), status.NextOpeningAt.In(paris))
+
+	// Saturday at 1am, the Friday opening running after midnight
+	status = schedule.Status(time.Date(2026, time.October, 24, 1, 0, 0, 0, paris))
+
+	assert.True(testing, status.IsOpen)
+	assert.Equal(testing, time.Date(2026, time.October, 24, 2, 0, 0, 0, paris), status.ClosesAt.In(paris))
+
+	// Tuesday, next opening on Friday
+	status = schedule.Status(time.Date(2026, time.October, 20, 12, 0, 0, 0, paris))
+
+	assert.False(testing, status.IsOpen)
+	assert.Equal(testing, time.Date(2026, time.October, 23, 18, 0, 0, 0, paris), status.NextOpeningAt.In(paris))
+}
+
+func TestRestaurantScheduleClosure(testing *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		log.Fatal("Unable to load location: ", err)
+	}
+
+	schedule := newTestSchedule()
+	schedule.Closures = []models.Closure{
+		{StartsAt: time.Date(2026, time.October, 19, 10, 0, 0, 0, paris), EndsAt: time.Date(2026, time.October, 19, 15, 0, 0, 0, paris), Reason: "Inventory"},
+	}
+
+	status := schedule.Status(time.Date(2026, time.October, 19, 12, 0, 0, 0, paris))
+
+	assert.False(testing, status.IsOpen)
+	assert.False(testing, status.AcceptsOrders)
+	assert.Equal(testing, "Inventory", status.Reason)
+	assert.Equal(testing, time.Date(2026, time.October, 19, 15, 0, 0, 0, paris), status.NextOpeningAt.In(paris))
+
+	assert.False(testing, schedule.IsOpenAt(time.Date(2026, time.October, 19, 14, 59, 0, 0, paris)))
+	assert.True(testing, schedule.IsOpenAt(time.Date(2026, time.October, 19, 15, 0, 0, 0, paris)))
+}
+
+func TestRestaurantScheduleWithoutOpeningHours(testing *testing.T) {
+	schedule := &models.RestaurantSchedule{Restaurant: models.Restaurant{ID: 1, LastOrderCutoff: 30}}
+
+	status := schedule.Status(time.Date(2026, time.December, 25, 4, 0, 0, 0, time.UTC))
+
+	assert.True(testing, status.IsOpen)
+	assert.True(testing, status.AcceptsOrders)
+	assert.Nil(testing, status.ClosesAt)
+}
+
+func TestPutRestaurantOpeningHoursSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/restaurants/1/opening-hours", []map[string]interface{}{
+		{"dayOfWeek": 1, "opensAt": "11:00", "closesAt": "23:00"},
+		{"dayOfWeek": 5, "opensAt": "18:00", "closesAt": "2:00"},
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var openingHours []models.OpeningHour
+	if err := json.NewDecoder(response.Body).Decode(&openingHours); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 2, len(openingHours))
+	assert.Equal(testing, "02:00", openingHours[1].ClosesAt)
+
+	// The opening hours are replaced.
+	response = sendRequest(router, http.MethodPut, "/restaurants/1/opening-hours", []map[string]interface{}{
+		{"dayOfWeek": 0, "opensAt": "10:00", "closesAt": "20:00"},
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = sendRequest(router, http.MethodGet, "/restaurants/1/opening-hours", nil, 2, 0)
+
+	openingHours = nil
+	if err := json.NewDecoder(response.Body).Decode(&openingHours); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(openingHours))
+	assert.Equal(testing, 0, openingHours[0].DayOfWeek)
+}
+
+func TestPutRestaurantOpeningHoursInvalidData(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/restaurants/1/opening-hours", []map[string]interface{}{
+		{"dayOfWeek": 7, "opensAt": "11:00", "closesAt": "23:00"},
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid day of week.")
+
+	response = sendRequest(router, http.MethodPut, "/restaurants/1/opening-hours", []map[string]interface{}{
+		{"dayOfWeek": 1, "opensAt": "25:00", "closesAt": "23:00"},
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid opening time.")
+}
+
+func TestPutRestaurantOpeningHoursAccessNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPut, "/restaurants/1/opening-hours", []map[string]interface{}{}, 2, 0)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
+
+func TestRestaurantClosureRejectsOrders(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPost, "/restaurants/1/closures", map[string]interface{}{
+		"startsAt": time.Now().Add(-time.Hour),
+		"endsAt":   time.Now().Add(time.Hour),
+		"reason":   "Christmas",
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	// The status is public.
+	request, err := http.NewRequest(http.MethodGet, "/restaurants/1/status", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	status := models.RestaurantStatusOutput{}
+	if err := json.NewDecoder(response.Body).Decode(&status); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.False(testing, status.IsOpen)
+	assert.Equal(testing, "Christmas", status.Reason)
+	assert.NotNil(testing, status.NextOpeningAt)
+
+	order := map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}
+
+	response = sendRequest(router, http.MethodPost, "/orders/", order, 2, 0)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Restaurant does not accept orders.")
+
+	// The second restaurant is not closed.
+	response = sendRequest(router, http.MethodPost, "/orders/", order, 5, 0)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	order["overrideOpeningHours"] = true
+
+	response = sendRequest(router, http.MethodPost, "/orders/", order, 2, 0)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Contains(testing, response.Body.String(), "Only managers can accept orders outside opening hours.")
+
+	response = sendRequest(router, http.MethodPost, "/orders/", order, 1, 0)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+}
+
+func TestPostRestaurantClosureInvalidPeriod(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPost, "/restaurants/1/closures", map[string]interface{}{
+		"startsAt": time.Now().Add(time.Hour),
+		"endsAt":   time.Now(),
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Closure must end after it starts.")
+}
+
+func TestDeleteRestaurantClosure(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := sendRequest(router, http.MethodPost, "/restaurants/1/closures", map[string]interface{}{
+		"startsAt": time.Now(),
+		"endsAt":   time.Now().Add(time.Hour),
+	}, 1, 0)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	// A closure cannot be deleted through another restaurant.
+	response = sendRequest(router, http.MethodDelete, "/restaurants/2/closures/1", nil, 1, 0)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Contains(testing, response.Body.String(), "Closure not found.")
+
+	response = sendRequest(router, http.MethodDelete, "/restaurants/1/closures/1", nil, 1, 0)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Closure deleted successfully.")
+}
@@ -0,0 +1,203 @@
+package retention
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/jobs"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initRetentionTest(testing *testing.T, mode string) time.Time {
+	tests.InitTest()
+
+	testing.Setenv("ORDER_RETENTION_DAYS", "30")
+	testing.Setenv("ORDER_ARCHIVE_MODE", mode)
+	testing.Setenv("ORDER_ARCHIVE_DIRECTORY", testing.TempDir())
+
+	now := time.Now()
+
+	// Order 4 was delivered long ago, order 1 was cancelled recently.
+	config.DB.Model(&models.Order{ID: 4}).Update("delivered_at", now.AddDate(0, 0, -40))
+	config.DB.Model(&models.Order{ID: 1}).Updates(map[string]interface{}{"status": models.Cancelled, "cancelled_at": now.AddDate(0, 0, -10)})
+
+	return now
+}
+
+func TestPurgeOrdersToTable(testing *testing.T) {
+	now := initRetentionTest(testing, config.ArchiveModeTable)
+
+	report, err := jobs.PurgeOrders(config.DB, now)
+	if err != nil {
+		log.Fatal("Unable to purge orders: ", err)
+	}
+
+	assert.Equal(testing, 1, report.Archived)
+	assert.Empty(testing, report.Files)
+
+	var ordersCount, orderItemsCount int64
+	config.DB.Model(&models.Order{}).Count(&ordersCount)
+	config.DB.Model(&models.OrderItem{}).Where("order_id = ?", 4).Count(&orderItemsCount)
+
+	assert.Equal(testing, int64(3), ordersCount)
+	assert.Equal(testing, int64(0), orderItemsCount)
+
+	var archivedOrder models.ArchivedOrder
+	if err := config.DB.First(&archivedOrder, 4).Error; err != nil {
+		log.Fatal("Unable to fetch archived order: ", err)
+	}
+
+	assert.Equal(testing, models.Delivered, archivedOrder.Status)
+	assert.Equal(testing, "004", archivedOrder.TicketNumber)
+
+	var payload models.OrderOutput
+	if err := json.Unmarshal([]byte(archivedOrder.Payload), &payload); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "004", payload.TicketNumber)
+	assert.Equal(testing, 1, len(payload.Items))
+	assert.Equal(testing, archivedOrder.TotalPrice, payload.TotalPrice)
+
+	// Running the job again has nothing left to do.
+	report, err = jobs.PurgeOrders(config.DB, now)
+	if err != nil {
+		log.Fatal("Unable to purge orders: ", err)
+	}
+
+	assert.Equal(testing, 0, report.Archived)
+}
+
+func TestPurgeOrdersToFile(testing *testing.T) {
+	now := initRetentionTest(testing, config.ArchiveModeFile)
+
+	report, err := jobs.PurgeOrders(config.DB, now)
+	if err != nil {
+		log.Fatal("Unable to purge orders: ", err)
+	}
+
+	assert.Equal(testing, 1, report.Archived)
+	assert.Equal(testing, []string{filepath.Join(config.OrderArchiveDirectory(), "orders-4-4.json.gz")}, report.Files)
+
+	var archivedOrdersCount int64
+	config.DB.Model(&models.ArchivedOrder{}).Count(&archivedOrdersCount)
+
+	assert.Equal(testing, int64(0), archivedOrdersCount)
+
+	file, err := os.Open(report.Files[0])
+	if err != nil {
+		log.Fatal("Unable to open archive: ", err)
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		log.Fatal("Unable to read archive: ", err)
+	}
+
+	var orders []models.OrderOutput
+	if err := json.NewDecoder(reader).Decode(&orders); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(orders))
+	assert.Equal(testing, uint(4), orders[0].ID)
+	assert.Equal(testing, models.Delivered, orders[0].Status)
+}
+
+func TestPurgeOrdersArchivesHistory(testing *testing.T) {
+	now := initRetentionTest(testing, config.ArchiveModeTable)
+
+	orderID := uint(4)
+	config.DB.Create(&models.OrderStatusHistory{OrderID: orderID, FromStatus: models.Prepared, ToStatus: models.Delivered, UserID: 4})
+	config.DB.Create(&models.OrderAmendment{OrderID: orderID, UserID: 2, Lines: []models.OrderAmendmentLine{{Name: "Test product 1", PreviousQuantity: 1, Quantity: 2, QuantityChange: 1}}})
+
+	_, err := jobs.PurgeOrders(config.DB, now)
+	if err != nil {
+		log.Fatal("Unable to purge orders: ", err)
+	}
+
+	var archivedOrder models.ArchivedOrder
+	if err := config.DB.First(&archivedOrder, orderID).Error; err != nil {
+		log.Fatal("Unable to fetch archived order: ", err)
+	}
+
+	var payload models.ArchivedOrderPayload
+	if err := json.Unmarshal([]byte(archivedOrder.Payload), &payload); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	if assert.Len(testing, payload.StatusHistory, 1) {
+		assert.Equal(testing, models.Delivered, payload.StatusHistory[0].ToStatus)
+	}
+
+	if assert.Len(testing, payload.Amendments, 1) {
+		assert.Len(testing, payload.Amendments[0].Lines, 1)
+	}
+
+	var historyCount, amendmentsCount int64
+	config.DB.Model(&models.OrderStatusHistory{}).Where("order_id = ?", orderID).Count(&historyCount)
+	config.DB.Model(&models.OrderAmendment{}).Where("order_id = ?", orderID).Count(&amendmentsCount)
+
+	assert.Equal(testing, int64(0), historyCount)
+	assert.Equal(testing, int64(0), amendmentsCount)
+}
+
+func TestPurgeOrdersDetachesReferences(testing *testing.T) {
+	now := initRetentionTest(testing, config.ArchiveModeTable)
+
+	orderID := uint(4)
+	config.DB.Create(&models.LoyaltyTransaction{CustomerID: 1, OrderID: &orderID, Type: models.Earned, Points: 10, Balance: 110})
+
+	event := models.OutboxEvent{Type: models.OrderCreated, AggregateID: orderID, Payload: "{}", DispatchedAt: now}
+	config.DB.Create(&event)
+
+	webhook := models.Webhook{URL: "https://example.com/webhook", Active: true}
+	config.DB.Create(&webhook)
+	config.DB.Create(&models.WebhookDelivery{WebhookID: webhook.ID, OutboxEventID: event.ID, OrderID: &orderID, Payload: "{}", Status: models.DeliverySucceeded})
+
+	_, err := jobs.PurgeOrders(config.DB, now)
+	if err != nil {
+		log.Fatal("Unable to purge orders: ", err)
+	}
+
+	// Nothing references the purged order anymore, the loyalty ledger of the customer being kept.
+	var transactionsCount, deliveriesCount, eventsCount int64
+	config.DB.Model(&models.LoyaltyTransaction{}).Where("order_id = ?", orderID).Count(&transactionsCount)
+	config.DB.Model(&models.WebhookDelivery{}).Where("order_id = ? OR outbox_event_id = ?", orderID, event.ID).Count(&deliveriesCount)
+	config.DB.Model(&models.OutboxEvent{}).Where("aggregate_id = ? AND type IN ?", orderID, models.OrderEventTypes).Count(&eventsCount)
+
+	assert.Equal(testing, int64(0), transactionsCount)
+	assert.Equal(testing, int64(0), deliveriesCount)
+	assert.Equal(testing, int64(0), eventsCount)
+
+	var transaction models.LoyaltyTransaction
+	if err := config.DB.Where("customer_id = ? AND points = ?", 1, 10).First(&transaction).Error; err != nil {
+		log.Fatal("Unable to fetch loyalty transaction: ", err)
+	}
+
+	assert.Nil(testing, transaction.OrderID)
+}
+
+func TestPurgeOrdersDisabled(testing *testing.T) {
+	now := initRetentionTest(testing, config.ArchiveModeTable)
+
+	testing.Setenv("ORDER_RETENTION_DAYS", "0")
+
+	_, err := jobs.PurgeOrders(config.DB, now)
+
+	assert.Error(testing, err)
+
+	var ordersCount int64
+	config.DB.Model(&models.Order{}).Count(&ordersCount)
+
+	assert.Equal(testing, int64(4), ordersCount)
+}
@@ -27,11 +27,13 @@ func TestGetUsersSuccess(testing *testing.T) {
 
 	assert.Equal(testing, http.StatusOK, response.Code)
 
-	var results []models.User
-	if err := json.NewDecoder(response.Body).Decode(&results); err != nil {
+	var list models.ListOutput[models.User]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
 		log.Fatal("Unable to decode JSON: ", err)
 	}
 
+	results := list.Items
+
 	assert.Equal(testing, 4, len(results))
 
 	assert.Equal(testing, "admin1@example.com", results[0].Email)
@@ -47,6 +49,49 @@ func TestGetUsersSuccess(testing *testing.T) {
 	assert.Equal(testing, models.UserRole("order_picker"), results[3].Role)
 }
 
+func TestGetUsersRoleFilterAndSearch(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/users/?role=greeter&search=GREETER2&sort=-email", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.UserOutput]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, int64(1), list.Total)
+	assert.Equal(testing, 1, len(list.Items))
+	assert.Equal(testing, "greeter2@example.com", list.Items[0].Email)
+	assert.Empty(testing, list.NextCursor)
+}
+
+func TestGetUsersInvalidRoleFilter(testing *testing.T) {
+	router := tests.InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/users/?role=chef", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	tests.AuthenticateUserAsAdmin(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid role filter.")
+}
+
 func TestGetUsersUnauthorized(testing *testing.T) {
 	router := tests.InitTest()
 
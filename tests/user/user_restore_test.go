@@ -0,0 +1,77 @@
+package user
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRestoreUser(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodDelete, "/users/4", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// A deleted user can neither log in nor use its token.
+	response = tests.SendRequest(router, http.MethodPost, "/authentication/login", map[string]interface{}{"email": "orderpicker1@example.com", "password": "OrderPicker1234!"}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Invalid email or password.")
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/", nil, 4)
+	assert.Equal(testing, http.StatusUnauthorized, response.Code)
+
+	response = tests.SendRequest(router, http.MethodGet, "/users/deleted", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var list models.ListOutput[models.UserOutput]
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, int64(1), list.Total)
+	assert.Equal(testing, "orderpicker1@example.com", list.Items[0].Email)
+	assert.NotNil(testing, list.Items[0].DeletedAt)
+
+	response = tests.SendRequest(router, http.MethodPost, "/users/", map[string]interface{}{"email": "orderpicker1@example.com", "password": "OrderPicker1234!", "role": "order_picker"}, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Email already used by a deleted user.")
+
+	response = tests.SendRequest(router, http.MethodPost, "/users/4/restore", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"DeletedAt":null`)
+
+	response = tests.SendRequest(router, http.MethodGet, "/orders/", nil, 4)
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
+
+func TestPurgeUser(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 2)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	tests.SendRequest(router, http.MethodDelete, "/users/2", nil, 1)
+	tests.SendRequest(router, http.MethodDelete, "/users/4", nil, 1)
+
+	// The orders of a deleted user still show who took them.
+	response = tests.SendRequest(router, http.MethodGet, "/orders/", nil, 1)
+	assert.Contains(testing, response.Body.String(), "greeter1@example.com")
+
+	response = tests.SendRequest(router, http.MethodDelete, "/users/2/purge", nil, 1)
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Cannot purge user: there are orders associated with it.")
+
+	response = tests.SendRequest(router, http.MethodDelete, "/users/4/purge", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	// Once purged, its email can be used again.
+	response = tests.SendRequest(router, http.MethodPost, "/users/", map[string]interface{}{"email": "orderpicker1@example.com", "password": "OrderPicker1234!", "role": "order_picker"}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+}
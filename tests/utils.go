@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +12,7 @@ import (
 	"time"
 	"wacdo/config"
 	"wacdo/controllers"
+	"wacdo/jobs"
 	"wacdo/models"
 	"wacdo/routes"
 	"wacdo/utils"
@@ -31,6 +35,8 @@ func InitTest() *gin.Engine {
 	config.DB = setupTestDatabase()
 	config.UploadAPI = &CloudinaryMock{}
 
+	jobs.RegisterEventSubscribers()
+
 	router := gin.Default()
 
 	routes.AuthenticationRoutes(router)
@@ -39,6 +45,11 @@ func InitTest() *gin.Engine {
 	routes.ProductRoutes(router)
 	routes.MenuRoutes(router)
 	routes.OrderRoutes(router)
+	routes.KitchenStationRoutes(router)
+	routes.RestaurantRoutes(router)
+	routes.CustomerRoutes(router)
+	routes.WebhookRoutes(router)
+	routes.CatalogRoutes(router)
 
 	return router
 }
@@ -53,6 +64,39 @@ func AuthenticateUserAsAdmin(request *http.Request) {
 	AuthenticateUser(request, 1)
 }
 
+// SendRequest sends a request authenticated as the given user, with the body encoded in JSON,
+// or sent as it is when it is a reader.
+func SendRequest(router http.Handler, method string, url string, body interface{}, userID uint) *httptest.ResponseRecorder {
+	reader, isReader := body.(io.Reader)
+	if !isReader {
+		var data []byte
+		if body != nil {
+			var err error
+			if data, err = json.Marshal(body); err != nil {
+				log.Fatal("Unable to marshal data: ", err)
+			}
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	if !isReader {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	AuthenticateUser(request, userID)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
 func AssertUnauthorized(testing *testing.T, response *httptest.ResponseRecorder) {
 	assert.Equal(testing, http.StatusUnauthorized, response.Code)
 
@@ -81,64 +125,109 @@ func setupTestDatabase() *gorm.DB {
 		log.Fatal("Unable to enforce foreign keys on database: ", err)
 	}
 
+	if err = models.RegisterRestaurantScope(db); err != nil {
+		log.Fatal("Unable to register restaurant scope: ", err)
+	}
+
 	err = db.AutoMigrate(
+		&models.Restaurant{},
+		&models.OpeningHour{},
+		&models.Closure{},
 		&models.User{},
+		&models.KitchenStation{},
 		&models.ProductCategory{},
 		&models.Product{},
+		&models.ProductVariant{},
 		&models.Menu{},
+		&models.Customer{},
 		&models.Order{},
 		&models.OrderItem{},
+		&models.OrderStationItem{},
+		&models.ArchivedOrder{},
+		&models.OrderStatusHistory{},
+		&models.OrderAmendment{},
+		&models.OrderAmendmentLine{},
+		&models.CatalogAvailability{},
+		&models.AvailabilitySlot{},
+		&models.LoyaltyTransaction{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.OutboxEvent{},
+		&models.PriceChange{},
 	)
 	if err != nil {
 		log.Fatal("Unable to migrate database: ", err)
 	}
 
+	// Restaurants
+	restaurant1 := &models.Restaurant{Name: "Test restaurant 1", Address: "Test restaurant address 1", LastTicketNumber: 4}
+	restaurant2 := &models.Restaurant{Name: "Test restaurant 2", Address: "Test restaurant address 2"}
+	db.Create(restaurant1)
+	db.Create(restaurant2)
+
+	// Kitchen stations
+	kitchenStation1 := &models.KitchenStation{Name: "Test kitchen station 1", Description: "Test kitchen station description 1"}
+	kitchenStation2 := &models.KitchenStation{Name: "Test kitchen station 2", Description: "Test kitchen station description 2"}
+	db.Create(kitchenStation1)
+	db.Create(kitchenStation2)
+	db.Create(&models.KitchenStation{Name: "Test kitchen station 3", Description: "Test kitchen station description 3"})
+
 	// Products categories
-	productCategory1 := &models.ProductCategory{Name: "Test product category 1", Description: "Test product category description 1"}
+	productCategory1 := &models.ProductCategory{Name: "Test product category 1", Description: "Test product category description 1", KitchenStationID: &kitchenStation1.ID}
 	productCategory2 := &models.ProductCategory{Name: "Test product category 2", Description: "Test product category description 2"}
 	db.Create(productCategory1)
 	db.Create(productCategory2)
 	db.Create(&models.ProductCategory{Name: "Test product category 3", Description: "Test product category description 3"})
 
 	// Products
-	product1 := &models.Product{Name: "Test product 1", Description: "Test product description 1", Price: 2.50, IsAvailable: true, Category: *productCategory1}
-	product2 := &models.Product{Name: "Test product 2", Description: "Test product description 2", Price: 4.99, IsAvailable: false, Category: *productCategory2}
-	product3 := &models.Product{Name: "Test product 3", Description: "Test product description 3", Price: 3.65, IsAvailable: true, Category: *productCategory1}
+	product1 := &models.Product{Name: "Test product 1", Description: "Test product description 1", Price: 2.50, LoyaltyPoints: 30, IsAvailable: true, Category: *productCategory1, Allergens: models.Allergens{models.Gluten, models.Milk}, IsVegetarian: true, Nutrition: models.Nutrition{Calories: 250, Proteins: 12, Fats: 9.5, Salt: 1.2}}
+	product2 := &models.Product{Name: "Test product 2", Description: "Test product description 2", Price: 4.99, IsAvailable: false, Category: *productCategory2, IsVegetarian: true, Nutrition: models.Nutrition{Calories: 150, Sugars: 35}}
+	product3 := &models.Product{Name: "Test product 3", Description: "Test product description 3", Price: 3.65, IsAvailable: true, Category: *productCategory1, KitchenStationID: &kitchenStation2.ID, Allergens: models.Allergens{models.Gluten}, Nutrition: models.Nutrition{Calories: 500, Proteins: 25, Fats: 20.25, Salt: 2}}
 	db.Create(product1)
 	db.Create(product2)
 	db.Create(product3)
 	db.Create(&models.Product{Name: "Test product 4", Description: "Test product description 4", Price: 9.10, IsAvailable: true, Category: *productCategory1})
 
 	// Menus
-	menu1 := &models.Menu{Name: "Test menu 1", Description: "Test menu description 1", Price: 8.54, IsAvailable: true, Products: []models.Product{*product1, *product2}}
+	menu1 := &models.Menu{Name: "Test menu 1", Description: "Test menu description 1", Price: 8.54, LoyaltyPoints: 80, IsAvailable: true, Products: []models.Product{*product1, *product2}}
 	menu2 := &models.Menu{Name: "Test menu 2", Description: "Test menu description 2", Price: 7.20, IsAvailable: false, Products: []models.Product{*product1, *product3}}
 	db.Create(menu1)
 	db.Create(menu2)
 
 	// Users
-	db.Create(&models.User{Email: "admin1@example.com", Password: utils.HashPassword("Admin1234!"), Role: "admin"})
+	db.Create(&models.User{Email: "admin1@example.com", Password: utils.HashPassword("Admin1234!"), Role: "admin", RestaurantID: restaurant1.ID, Restaurants: []models.Restaurant{*restaurant2}})
 
-	userGreeter1 := &models.User{Email: "greeter1@example.com", Password: utils.HashPassword("Greeter1234!"), Role: "greeter"}
+	userGreeter1 := &models.User{Email: "greeter1@example.com", Password: utils.HashPassword("Greeter1234!"), Role: "greeter", RestaurantID: restaurant1.ID}
 	db.Create(userGreeter1)
 
-	userGreeter2 := &models.User{Email: "greeter2@example.com", Password: utils.HashPassword("Greeter5678!"), Role: "greeter"}
+	userGreeter2 := &models.User{Email: "greeter2@example.com", Password: utils.HashPassword("Greeter5678!"), Role: "greeter", RestaurantID: restaurant1.ID}
 	db.Create(userGreeter2)
 
-	db.Create(&models.User{Email: "orderpicker1@example.com", Password: utils.HashPassword("OrderPicker1234!"), Role: "order_picker"})
+	db.Create(&models.User{Email: "orderpicker1@example.com", Password: utils.HashPassword("OrderPicker1234!"), Role: "order_picker", RestaurantID: restaurant1.ID})
+
+	// User of the second restaurant
+	db.Create(&models.User{Email: "greeter3@example.com", Password: utils.HashPassword("Greeter9012!"), Role: "greeter", RestaurantID: restaurant2.ID})
+
+	// Customers
+	customerEmail, customerPhone := "customer1@example.com", "+33612345678"
+	db.Create(&models.Customer{Name: "Test customer 1", Email: &customerEmail, Phone: &customerPhone, LoyaltyConsent: true, LoyaltyPoints: 100})
+
+	otherCustomerPhone := "+33698765432"
+	db.Create(&models.Customer{Name: "Test customer 2", Phone: &otherCustomerPhone})
 
 	// Orders
 	orderItem1 := &models.OrderItem{Quantity: 2, OrderContentName: product1.Name, OrderContentDescription: product1.Description, OrderContentImage: product1.Image, OrderContentPrice: product1.Price}
 	orderItem2 := &models.OrderItem{Quantity: 1, OrderContentName: menu1.Name, OrderContentDescription: menu1.Description, OrderContentImage: menu1.Image, OrderContentPrice: menu1.Price}
-	db.Create(&models.Order{Status: models.Created, TicketNumber: "001", User: *userGreeter1, Items: []models.OrderItem{*orderItem1, *orderItem2}})
+	db.Create(&models.Order{Status: models.Created, TicketNumber: "001", RestaurantID: restaurant1.ID, User: *userGreeter1, Items: []models.OrderItem{*orderItem1, *orderItem2}})
 
 	orderItem3 := &models.OrderItem{Quantity: 1, OrderContentName: product2.Name, OrderContentDescription: product2.Description, OrderContentImage: product2.Image, OrderContentPrice: product2.Price}
-	db.Create(&models.Order{Status: models.InPreparation, TicketNumber: "002", User: *userGreeter2, Items: []models.OrderItem{*orderItem3}})
+	db.Create(&models.Order{Status: models.InPreparation, TicketNumber: "002", RestaurantID: restaurant1.ID, User: *userGreeter2, Items: []models.OrderItem{*orderItem3}})
 
 	orderItem4 := &models.OrderItem{Quantity: 1, OrderContentName: menu2.Name, OrderContentDescription: menu2.Description, OrderContentImage: menu2.Image, OrderContentPrice: menu2.Price}
-	db.Create(&models.Order{Status: models.Prepared, TicketNumber: "003", User: *userGreeter1, Items: []models.OrderItem{*orderItem4}})
+	db.Create(&models.Order{Status: models.Prepared, TicketNumber: "003", RestaurantID: restaurant1.ID, User: *userGreeter1, Items: []models.OrderItem{*orderItem4}})
 
 	orderItem5 := &models.OrderItem{Quantity: 2, OrderContentName: product3.Name, OrderContentDescription: product3.Description, OrderContentImage: product3.Image, OrderContentPrice: product3.Price}
-	db.Create(&models.Order{Status: models.Delivered, TicketNumber: "004", User: *userGreeter2, Items: []models.OrderItem{*orderItem5}})
+	db.Create(&models.Order{Status: models.Delivered, TicketNumber: "004", RestaurantID: restaurant1.ID, User: *userGreeter2, Items: []models.OrderItem{*orderItem5}})
 
 	return db
 }
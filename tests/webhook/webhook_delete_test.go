@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteWebhookSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	webhook := createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+	config.DB.Create(&models.WebhookDelivery{WebhookID: webhook.ID, Event: models.OrderCreatedEvent, Status: models.DeliveryPending, NextAttemptAt: time.Now()})
+
+	response := tests.SendRequest(router, http.MethodDelete, "/webhooks/1", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.JSONEq(testing, `{"message": "Webhook deleted successfully."}`, response.Body.String())
+
+	var count int64
+	config.DB.Model(&models.WebhookDelivery{}).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestDeleteWebhookNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodDelete, "/webhooks/99", nil, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
+
+func TestDeleteWebhookNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodDelete, "/webhooks/1", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"wacdo/config"
+	"wacdo/jobs"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedDelivery struct {
+	Header  http.Header
+	Body    []byte
+	Payload models.WebhookPayload
+}
+
+type receiver struct {
+	mutex      sync.Mutex
+	deliveries []receivedDelivery
+	status     int
+}
+
+func newReceiver(testing *testing.T) (*receiver, *httptest.Server) {
+	receiver := &receiver{status: http.StatusOK}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			log.Fatal("Unable to read body: ", err)
+		}
+
+		var payload models.WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			log.Fatal("Unable to decode JSON: ", err)
+		}
+
+		receiver.mutex.Lock()
+		defer receiver.mutex.Unlock()
+
+		receiver.deliveries = append(receiver.deliveries, receivedDelivery{Header: request.Header, Body: body, Payload: payload})
+		writer.WriteHeader(receiver.status)
+	}))
+	testing.Cleanup(server.Close)
+
+	return receiver, server
+}
+
+func TestDispatchWebhookOrderCreated(testing *testing.T) {
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	createWebhook(server.URL, models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	sent, err := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, sent)
+
+	if assert.Len(testing, receiver.deliveries, 1) {
+		delivery := receiver.deliveries[0]
+
+		timestamp, err := strconv.ParseInt(delivery.Header.Get("X-Wacdo-Timestamp"), 10, 64)
+		assert.Nil(testing, err)
+
+		assert.Equal(testing, "order.created", delivery.Header.Get("X-Wacdo-Event"))
+		assert.Equal(testing, "1", delivery.Header.Get("X-Wacdo-Delivery"))
+		assert.Equal(testing, models.SignWebhookPayload("test-secret", timestamp, delivery.Body), delivery.Header.Get("X-Wacdo-Signature"))
+		assert.Equal(testing, models.OrderCreatedEvent, delivery.Payload.Event)
+		assert.Equal(testing, "005", delivery.Payload.Order.TicketNumber)
+	}
+
+	var deliveries []models.WebhookDelivery
+	config.DB.Find(&deliveries)
+
+	assert.Equal(testing, models.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(testing, 1, deliveries[0].Attempts)
+	assert.Equal(testing, http.StatusOK, deliveries[0].ResponseStatus)
+
+	// Sent deliveries are not sent again
+	sent, err = jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 0, sent)
+}
+
+func TestDispatchWebhookOrderCancelled(testing *testing.T) {
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	createWebhook(server.URL, models.OrderStatusChangedEvent, models.OrderCancelledEvent)
+	createWebhook(server.URL, models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/cancelled", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	sent, err := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 2, sent)
+
+	if assert.Len(testing, receiver.deliveries, 2) {
+		assert.Equal(testing, models.OrderStatusChangedEvent, receiver.deliveries[0].Payload.Event)
+		assert.Equal(testing, models.OrderCancelledEvent, receiver.deliveries[1].Payload.Event)
+		assert.Equal(testing, models.Created, receiver.deliveries[1].Payload.PreviousStatus)
+		assert.Equal(testing, models.Cancelled, receiver.deliveries[1].Payload.Order.Status)
+	}
+}
+
+func TestDispatchWebhookOrderAmended(testing *testing.T) {
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	createWebhook(server.URL, models.OrderAmendedEvent)
+
+	// Order 2 is in preparation.
+	response := tests.SendRequest(router, http.MethodPut, "/orders/2", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 2, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	sent, err := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, sent)
+
+	if assert.Len(testing, receiver.deliveries, 1) {
+		assert.Equal(testing, models.OrderAmendedEvent, receiver.deliveries[0].Payload.Event)
+		assert.Equal(testing, "002", receiver.deliveries[0].Payload.Order.TicketNumber)
+		assert.Len(testing, receiver.deliveries[0].Payload.Order.Amendments, 1)
+	}
+}
+
+func TestDispatchWebhookInactive(testing *testing.T) {
+	router := tests.InitTest()
+
+	_, server := newReceiver(testing)
+	webhook := createWebhook(server.URL, models.OrderStatusChangedEvent)
+	config.DB.Model(webhook).Update("active", false)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/in-preparation", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	var count int64
+	config.DB.Model(&models.WebhookDelivery{}).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestDispatchWebhookOtherRestaurant(testing *testing.T) {
+	router := tests.InitTest()
+
+	_, server := newReceiver(testing)
+	webhook := createWebhook(server.URL, models.OrderStatusChangedEvent)
+	config.DB.Model(webhook).Update("restaurant_id", 2)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/in-preparation", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	var count int64
+	config.DB.Model(&models.WebhookDelivery{}).Count(&count)
+	assert.Equal(testing, int64(0), count)
+}
+
+func TestDispatchWebhookRetry(testing *testing.T) {
+	testing.Setenv("WEBHOOK_RETRY_DELAY", "30")
+	testing.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	receiver.status = http.StatusInternalServerError
+	createWebhook(server.URL, models.OrderStatusChangedEvent)
+
+	response := tests.SendRequest(router, http.MethodPatch, "/orders/1/in-preparation", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	now := time.Now()
+	jobs.DispatchWebhookDeliveries(config.DB, server.Client(), now)
+
+	var delivery models.WebhookDelivery
+	config.DB.First(&delivery)
+
+	assert.Equal(testing, models.DeliveryPending, delivery.Status)
+	assert.Equal(testing, 1, delivery.Attempts)
+	assert.Equal(testing, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.Equal(testing, "webhook answered with status 500", delivery.Error)
+	assert.WithinDuration(testing, now.Add(30*time.Second), delivery.NextAttemptAt, time.Second)
+
+	// The delivery is not retried before its next attempt
+	sent, _ := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), now.Add(10*time.Second))
+	assert.Equal(testing, 0, sent)
+
+	now = now.Add(30 * time.Second)
+	jobs.DispatchWebhookDeliveries(config.DB, server.Client(), now)
+
+	config.DB.First(&delivery)
+	assert.Equal(testing, 2, delivery.Attempts)
+	assert.WithinDuration(testing, now.Add(time.Minute), delivery.NextAttemptAt, time.Second)
+
+	jobs.DispatchWebhookDeliveries(config.DB, server.Client(), now.Add(time.Minute))
+
+	config.DB.First(&delivery)
+	assert.Equal(testing, models.DeliveryFailed, delivery.Status)
+	assert.Equal(testing, 3, delivery.Attempts)
+	assert.Len(testing, receiver.deliveries, 3)
+}
+
+func TestGetWebhookDeliveriesAndReplay(testing *testing.T) {
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	webhook := createWebhook(server.URL, models.OrderCreatedEvent)
+	config.DB.Create(&models.WebhookDelivery{WebhookID: webhook.ID, Event: models.OrderCreatedEvent, Payload: "{}", Status: models.DeliveryFailed, Attempts: 8, Error: "webhook answered with status 500"})
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/1/deliveries?status=failed", nil, 1)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var deliveries []models.WebhookDelivery
+	if err := json.NewDecoder(response.Body).Decode(&deliveries); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Len(testing, deliveries, 1)
+	assert.Equal(testing, 8, deliveries[0].Attempts)
+
+	response = tests.SendRequest(router, http.MethodPost, "/webhooks/deliveries/1/replay", nil, 1)
+	assert.Equal(testing, http.StatusAccepted, response.Code)
+
+	var delivery models.WebhookDelivery
+	if err := json.NewDecoder(response.Body).Decode(&delivery); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.DeliveryPending, delivery.Status)
+	assert.Equal(testing, 0, delivery.Attempts)
+
+	sent, err := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, sent)
+	assert.Len(testing, receiver.deliveries, 1)
+
+	config.DB.First(&delivery)
+	assert.Equal(testing, models.DeliverySucceeded, delivery.Status)
+	assert.Empty(testing, delivery.Error)
+}
+
+func TestReplayWebhookDeliveryNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/deliveries/99/replay", nil, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.JSONEq(testing, `{"error": "Webhook delivery not found."}`, response.Body.String())
+}
+
+func TestDispatchWebhookMissingWebhook(testing *testing.T) {
+	router := tests.InitTest()
+
+	receiver, server := newReceiver(testing)
+	createWebhook(server.URL, models.OrderCreatedEvent)
+
+	// Queued before the other one, the delivery of a deleted webhook must not hold up the batch.
+	orphan := models.WebhookDelivery{WebhookID: 999, Event: models.OrderCreatedEvent, Payload: "{}", Status: models.DeliveryPending, NextAttemptAt: time.Now().Add(-time.Minute)}
+	config.DB.Create(&orphan)
+
+	response := tests.SendRequest(router, http.MethodPost, "/orders/", map[string]interface{}{
+		"items": []map[string]interface{}{{"quantity": 1, "productID": 1}},
+	}, 1)
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	jobs.DispatchOutboxEvents(config.DB, time.Now())
+
+	sent, err := jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 1, sent)
+	assert.Len(testing, receiver.deliveries, 1)
+
+	config.DB.First(&orphan, orphan.ID)
+	assert.Equal(testing, models.DeliveryFailed, orphan.Status)
+	assert.Equal(testing, "Webhook not found.", orphan.Error)
+
+	sent, err = jobs.DispatchWebhookDeliveries(config.DB, server.Client(), time.Now())
+
+	assert.Nil(testing, err)
+	assert.Equal(testing, 0, sent)
+}
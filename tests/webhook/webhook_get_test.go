@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createWebhook(url string, events ...models.WebhookEvent) *models.Webhook {
+	webhook := &models.Webhook{RestaurantID: 1, URL: url, Secret: "test-secret", Events: events, Active: true}
+	if err := config.DB.Create(webhook).Error; err != nil {
+		log.Fatal("Unable to create webhook: ", err)
+	}
+
+	return webhook
+}
+
+func TestGetWebhooksSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	createWebhook("https://example.com/orders", models.OrderCreatedEvent, models.OrderCancelledEvent)
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var webhooks []models.Webhook
+	if err := json.NewDecoder(response.Body).Decode(&webhooks); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, 1, len(webhooks))
+	assert.Equal(testing, "https://example.com/orders", webhooks[0].URL)
+	assert.Equal(testing, models.WebhookEvents{models.OrderCreatedEvent, models.OrderCancelledEvent}, webhooks[0].Events)
+}
+
+func TestGetWebhookSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	webhook := createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/1", nil, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.NotContains(testing, response.Body.String(), "test-secret")
+
+	var output models.Webhook
+	if err := json.NewDecoder(response.Body).Decode(&output); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, webhook.ID, output.ID)
+	assert.True(testing, output.Active)
+}
+
+func TestGetWebhookOtherRestaurant(testing *testing.T) {
+	router := tests.InitTest()
+
+	config.DB.Create(&models.Webhook{RestaurantID: 2, URL: "https://example.com/orders", Events: models.WebhookEvents{models.OrderCreatedEvent}, Active: true})
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/1", nil, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.JSONEq(testing, `{"error": "Webhook not found."}`, response.Body.String())
+}
+
+func TestGetWebhookNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/99", nil, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.JSONEq(testing, `{"error": "Webhook not found."}`, response.Body.String())
+}
+
+func TestGetWebhooksNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodGet, "/webhooks/", nil, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostWebhookSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url":         "https://example.com/orders",
+		"description": "Loyalty app",
+		"events":      []string{"order.created", "order.statusChanged", "order.created"},
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var webhook models.WebhookSecretOutput
+	if err := json.NewDecoder(response.Body).Decode(&webhook); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, uint(1), webhook.RestaurantID)
+	assert.Equal(testing, "https://example.com/orders", webhook.URL)
+	assert.Equal(testing, models.WebhookEvents{models.OrderCreatedEvent, models.OrderStatusChangedEvent}, webhook.Events)
+	assert.Len(testing, webhook.Secret, 64)
+	assert.True(testing, webhook.Active)
+}
+
+func TestPostWebhookWithSecret(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url":    "http://localhost:9000/hook",
+		"secret": "shared-secret",
+		"events": []string{"order.cancelled"},
+		"active": false,
+	}, 1)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var webhook models.WebhookSecretOutput
+	if err := json.NewDecoder(response.Body).Decode(&webhook); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, "shared-secret", webhook.Secret)
+	assert.False(testing, webhook.Active)
+}
+
+func TestPostWebhookInvalidURL(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url":    "ftp://example.com",
+		"events": []string{"order.created"},
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Invalid webhook URL."}`, response.Body.String())
+}
+
+func TestPostWebhookInvalidEvent(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url":    "https://example.com/orders",
+		"events": []string{"order.eaten"},
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Invalid webhook event: order.eaten."}`, response.Body.String())
+}
+
+func TestPostWebhookWithoutEvents(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url": "https://example.com/orders",
+	}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "At least one event is required."}`, response.Body.String())
+}
+
+func TestPostWebhookNotAllowed(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPost, "/webhooks/", map[string]interface{}{
+		"url":    "https://example.com/orders",
+		"events": []string{"order.created"},
+	}, 2)
+
+	tests.AssertAccessNotAllowed(testing, response)
+}
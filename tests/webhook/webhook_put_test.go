@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+	"wacdo/config"
+	"wacdo/models"
+	"wacdo/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutWebhookSuccess(testing *testing.T) {
+	router := tests.InitTest()
+
+	createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodPut, "/webhooks/1", map[string]interface{}{
+		"events": []string{"order.cancelled"},
+		"active": false,
+	}, 1)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.NotContains(testing, response.Body.String(), "test-secret")
+
+	var webhook models.Webhook
+	if err := json.NewDecoder(response.Body).Decode(&webhook); err != nil {
+		log.Fatal("Unable to decode JSON: ", err)
+	}
+
+	assert.Equal(testing, models.WebhookEvents{models.OrderCancelledEvent}, webhook.Events)
+	assert.False(testing, webhook.Active)
+
+	config.DB.First(&webhook, 1)
+	assert.Equal(testing, "test-secret", webhook.Secret)
+}
+
+func TestPutWebhookEmptySecret(testing *testing.T) {
+	router := tests.InitTest()
+
+	createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodPut, "/webhooks/1", map[string]interface{}{"secret": " "}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "Webhook secret cannot be empty."}`, response.Body.String())
+}
+
+func TestPutWebhookNoData(testing *testing.T) {
+	router := tests.InitTest()
+
+	createWebhook("https://example.com/orders", models.OrderCreatedEvent)
+
+	response := tests.SendRequest(router, http.MethodPut, "/webhooks/1", map[string]interface{}{}, 1)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.JSONEq(testing, `{"error": "No data to update."}`, response.Body.String())
+}
+
+func TestPutWebhookNotFound(testing *testing.T) {
+	router := tests.InitTest()
+
+	response := tests.SendRequest(router, http.MethodPut, "/webhooks/99", map[string]interface{}{"active": false}, 1)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
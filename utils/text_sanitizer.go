@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// SanitizeText removes HTML tags and control characters from a free text typed by an employee,
+// so that it can safely be displayed on screens and printed on kitchen tickets.
+func SanitizeText(text string) string {
+	text = htmlTagPattern.ReplaceAllString(text, "")
+
+	text = strings.Map(func(character rune) rune {
+		if character == '\n' {
+			return ' '
+		}
+
+		if unicode.IsControl(character) {
+			return -1
+		}
+
+		return character
+	}, text)
+
+	return strings.Join(strings.Fields(text), " ")
+}